@@ -9,10 +9,16 @@ var protectedPaths = []string{
 	"init",
 	"spec",
 	"context resolve",
+	"context verify",
+	"context bundle",
+	"context graph",
+	"context lint",
 	"usage",
 	"usage report",
 	"usage status",
 	"usage refresh",
+	"usage export",
+	"usage advise",
 	"usage clear",
 	"usage enable",
 	"usage disable",
@@ -26,6 +32,12 @@ var protectedPaths = []string{
 	"pr fix",
 	"pr orchestrate",
 	"improve run",
+	"improve mine",
+	"improve propose",
+	"improve validate",
+	"improve report",
+	"improve pr-body",
+	"improve compare",
 	"rules add",
 	"rules list",
 	"rules view",
@@ -42,6 +54,10 @@ var telemetryPaths = []string{
 	"init",
 	"spec",
 	"context resolve",
+	"context verify",
+	"context bundle",
+	"context graph",
+	"context lint",
 	"status",
 	"registry status",
 	"health",
@@ -52,6 +68,12 @@ var telemetryPaths = []string{
 	"pr fix",
 	"pr orchestrate",
 	"improve run",
+	"improve mine",
+	"improve propose",
+	"improve validate",
+	"improve report",
+	"improve pr-body",
+	"improve compare",
 	"rules add",
 	"rules list",
 	"rules view",
@@ -45,8 +45,12 @@ func (c *Config) sortRemovedFeatures() {
 	})
 }
 
+// ErrProjectNotFound reports that no .kit.yaml exists in the current
+// directory or any parent.
+var ErrProjectNotFound = fmt.Errorf("%s not found. Run 'kit init' to initialize a project", ConfigFileName)
+
 // FindProjectRoot traverses upward from the current directory to find .kit.yaml.
-// Returns the directory containing .kit.yaml, or an error if not found.
+// Returns the directory containing .kit.yaml, or ErrProjectNotFound.
 func FindProjectRoot() (string, error) {
 	dir, err := os.Getwd()
 	if err != nil {
@@ -62,7 +66,7 @@ func FindProjectRoot() (string, error) {
 		parent := filepath.Dir(dir)
 		if parent == dir {
 			// reached filesystem root
-			return "", fmt.Errorf("%s not found. Run 'kit init' to initialize a project", ConfigFileName)
+			return "", ErrProjectNotFound
 		}
 		dir = parent
 	}
@@ -0,0 +1,61 @@
+package context
+
+import "fmt"
+
+// estimateTokens uses the same bytes/4 heuristic as improve text metrics.
+func estimateTokens(size int) int {
+	return (size + 3) / 4
+}
+
+func measureEvidence(item *EvidenceItem, data []byte) {
+	if item.State != "present" {
+		item.Tokens = 0
+		return
+	}
+	if len(item.Spans) == 0 {
+		item.Tokens = estimateTokens(len(data))
+		return
+	}
+	size := 0
+	for _, span := range item.Spans {
+		size += span.EndByte - span.StartByte
+	}
+	item.Tokens = estimateTokens(size)
+}
+
+// applyBudget drops optional evidence, last selected first, until the
+// contract estimate fits the requested budget. Required evidence is never
+// dropped; a required set larger than the budget is reported instead.
+func (r *resolver) applyBudget() {
+	total := 0
+	for _, item := range r.contract.Evidence {
+		total += item.Tokens
+	}
+	budget := r.contract.Request.Budget
+	if budget <= 0 || total <= budget {
+		r.contract.Tokens = total
+		return
+	}
+	excluded := map[int]bool{}
+	for index := len(r.contract.Evidence) - 1; index >= 0 && total > budget; index-- {
+		item := r.contract.Evidence[index]
+		if item.Required || item.Tokens == 0 {
+			continue
+		}
+		excluded[index] = true
+		total -= item.Tokens
+		r.addDiagnostic("info", "budget-excluded", item.Path, fmt.Sprintf("optional %s evidence of about %d tokens excluded to fit budget %d", item.Kind, item.Tokens, budget))
+		r.trace("budget-exclude", item.Kind, item.Path, fmt.Sprintf("optional evidence of about %d tokens excluded to fit budget %d", item.Tokens, budget))
+	}
+	kept := make([]EvidenceItem, 0, len(r.contract.Evidence)-len(excluded))
+	for index, item := range r.contract.Evidence {
+		if !excluded[index] {
+			kept = append(kept, item)
+		}
+	}
+	r.contract.Evidence = kept
+	r.contract.Tokens = total
+	if total > budget {
+		r.addDiagnostic("warning", "budget-exceeded", "", fmt.Sprintf("required evidence needs about %d tokens, over budget %d", total, budget))
+	}
+}
@@ -0,0 +1,56 @@
+package context
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestResolveBudgetDropsOptionalEvidenceLastFirst(t *testing.T) {
+	root := contextProject(t)
+	document := `---
+kind: workflow
+slug: check
+description: test
+rules: []
+evidence:
+  - kind: required
+    path: required.md
+    required: true
+  - kind: optional
+    path: first.md
+    required: false
+  - kind: optional
+    path: second.md
+    required: false
+---
+# Check
+`
+	writeContextFile(t, root, "docs/references/workflows/check.md", document)
+	writeContextFile(t, root, "required.md", strings.Repeat("r", 400))
+	writeContextFile(t, root, "first.md", strings.Repeat("f", 400))
+	writeContextFile(t, root, "second.md", strings.Repeat("s", 400))
+
+	unbounded := Resolve(root, Request{Workflow: "check"})
+	budget := unbounded.Tokens - 50
+	contract := Resolve(root, Request{Workflow: "check", Budget: budget})
+	if contract.Blocked || contract.Tokens > budget {
+		t.Fatalf("budgeted contract = %#v", contract)
+	}
+	if !evidencePathPresent(contract, "first.md") || evidencePathPresent(contract, "second.md") {
+		t.Fatalf("budget dropped the wrong evidence: %#v", contract.Evidence)
+	}
+	excluded := ""
+	for _, diagnostic := range contract.Diagnostics {
+		if diagnostic.Code == "budget-excluded" {
+			excluded = diagnostic.Path
+		}
+	}
+	if excluded != "second.md" {
+		t.Fatalf("budget diagnostics = %#v", contract.Diagnostics)
+	}
+
+	tight := Resolve(root, Request{Workflow: "check", Budget: 10})
+	if !evidencePathPresent(tight, "required.md") || !diagnosticCodePresent(tight, "budget-exceeded") || tight.Blocked {
+		t.Fatalf("required evidence over budget = %#v", tight)
+	}
+}
@@ -0,0 +1,122 @@
+package context
+
+import (
+	"archive/tar"
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"time"
+)
+
+const BundleSchemaVersion = "kit.context.bundle/v1"
+
+type Bundle struct {
+	Manifest BundleManifest
+	Contract []byte
+	Files    [][]byte
+}
+
+type BundleManifest struct {
+	SchemaVersion  string        `json:"schema_version"`
+	Request        Request       `json:"request"`
+	ContractDigest string        `json:"contract_digest"`
+	Entries        []BundleEntry `json:"entries"`
+}
+
+type bundleFile struct {
+	name string
+	data []byte
+}
+
+type BundleEntry struct {
+	Order    int            `json:"order"`
+	Path     string         `json:"path,omitempty"`
+	Source   string         `json:"source"`
+	Kind     string         `json:"kind"`
+	Required bool           `json:"required"`
+	State    string         `json:"state"`
+	Digest   string         `json:"digest,omitempty"`
+	Bytes    int            `json:"bytes"`
+	Spans    []EvidenceSpan `json:"spans,omitempty"`
+}
+
+// BuildBundle snapshots the evidence a resolved contract selects. Evidence
+// with spans contributes only its selected sections. Every file is re-read and
+// checked against the contract digest so a bundle never mixes revisions.
+func BuildBundle(projectRoot string, contract Contract) (Bundle, error) {
+	if contract.Blocked {
+		return Bundle{}, fmt.Errorf("context contract is blocked; resolve required evidence before bundling")
+	}
+	root, err := canonicalRoot(projectRoot)
+	if err != nil {
+		return Bundle{}, err
+	}
+	contractData, err := json.MarshalIndent(contract, "", "  ")
+	if err != nil {
+		return Bundle{}, err
+	}
+	contractData = append(contractData, '\n')
+	bundle := Bundle{
+		Manifest: BundleManifest{SchemaVersion: BundleSchemaVersion, Request: contract.Request, ContractDigest: digest(contractData), Entries: []BundleEntry{}},
+		Contract: contractData,
+	}
+	for index, item := range contract.Evidence {
+		entry := BundleEntry{Order: index + 1, Source: item.Path, Kind: item.Kind, Required: item.Required, State: item.State, Spans: item.Spans}
+		if item.State != "present" {
+			bundle.Manifest.Entries = append(bundle.Manifest.Entries, entry)
+			continue
+		}
+		_, data, err := secureRead(root, item.Path)
+		if err != nil {
+			return Bundle{}, fmt.Errorf("read evidence %s: %w", item.Path, err)
+		}
+		if digest(data) != item.Digest {
+			return Bundle{}, fmt.Errorf("evidence %s changed since resolution; resolve again", item.Path)
+		}
+		content := data
+		if len(item.Spans) > 0 {
+			content = nil
+			for _, span := range item.Spans {
+				content = append(content, data[span.StartByte:span.EndByte]...)
+			}
+		}
+		entry.Path = fmt.Sprintf("evidence/%04d/%s", entry.Order, item.Path)
+		entry.Digest = digest(content)
+		entry.Bytes = len(content)
+		bundle.Manifest.Entries = append(bundle.Manifest.Entries, entry)
+		bundle.Files = append(bundle.Files, content)
+	}
+	return bundle, nil
+}
+
+// WriteTar writes a reproducible archive: fixed entry order, epoch
+// timestamps, and no owner or host metadata.
+func (b Bundle) WriteTar(w io.Writer) error {
+	manifest, err := json.MarshalIndent(b.Manifest, "", "  ")
+	if err != nil {
+		return err
+	}
+	writer := tar.NewWriter(w)
+	files := append([]bundleFile{{"manifest.json", append(manifest, '\n')}, {"contract.json", b.Contract}}, b.evidenceFiles()...)
+	for _, file := range files {
+		header := &tar.Header{Name: file.name, Mode: 0o644, Size: int64(len(file.data)), ModTime: time.Unix(0, 0).UTC(), Typeflag: tar.TypeReg, Format: tar.FormatPAX}
+		if err := writer.WriteHeader(header); err != nil {
+			return err
+		}
+		if _, err := io.Copy(writer, bytes.NewReader(file.data)); err != nil {
+			return err
+		}
+	}
+	return writer.Close()
+}
+
+func (b Bundle) evidenceFiles() []bundleFile {
+	var files []bundleFile
+	for _, entry := range b.Manifest.Entries {
+		if entry.Path != "" {
+			files = append(files, bundleFile{entry.Path, b.Files[len(files)]})
+		}
+	}
+	return files
+}
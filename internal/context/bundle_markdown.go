@@ -0,0 +1,72 @@
+package context
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+)
+
+// WriteMarkdown writes the bundle as one Markdown document. Each fence is
+// longer than any backtick run in its content so evidence cannot close it.
+func (b Bundle) WriteMarkdown(w io.Writer) error {
+	manifest, err := json.MarshalIndent(b.Manifest, "", "  ")
+	if err != nil {
+		return err
+	}
+	var out bytes.Buffer
+	out.WriteString("# Kit Context Bundle\n\n")
+	fmt.Fprintf(&out, "Schema: `%s`. Load the evidence sections in order.\n\n", BundleSchemaVersion)
+	writeFenced(&out, "## Manifest", "json", append(manifest, '\n'))
+	writeFenced(&out, "## Contract", "json", b.Contract)
+	files := b.evidenceFiles()
+	next := 0
+	for _, entry := range b.Manifest.Entries {
+		requirement := "optional"
+		if entry.Required {
+			requirement = "required"
+		}
+		heading := fmt.Sprintf("## Evidence %d: %s (%s, %s)", entry.Order, entry.Source, entry.Kind, requirement)
+		if entry.Path == "" {
+			fmt.Fprintf(&out, "%s\n\nState: `%s`; no content.\n\n", heading, entry.State)
+			continue
+		}
+		fmt.Fprintf(&out, "%s\n\nDigest: `%s`\n\n", heading, entry.Digest)
+		writeFenced(&out, "", "", files[next].data)
+		next++
+	}
+	_, err = w.Write(out.Bytes())
+	return err
+}
+
+func writeFenced(out *bytes.Buffer, heading, language string, data []byte) {
+	if heading != "" {
+		out.WriteString(heading + "\n\n")
+	}
+	fence := strings.Repeat("`", longestBacktickRun(data)+1)
+	if len(fence) < 3 {
+		fence = "```"
+	}
+	out.WriteString(fence + language + "\n")
+	out.Write(data)
+	if len(data) > 0 && data[len(data)-1] != '\n' {
+		out.WriteByte('\n')
+	}
+	out.WriteString(fence + "\n\n")
+}
+
+func longestBacktickRun(data []byte) int {
+	longest, current := 0, 0
+	for _, character := range data {
+		if character != '`' {
+			current = 0
+			continue
+		}
+		current++
+		if current > longest {
+			longest = current
+		}
+	}
+	return longest
+}
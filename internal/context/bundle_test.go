@@ -0,0 +1,100 @@
+package context
+
+import (
+	"archive/tar"
+	"bytes"
+	"errors"
+	"io"
+	"strings"
+	"testing"
+)
+
+func TestBuildBundleIsReproducibleAcrossFormats(t *testing.T) {
+	root := contextProject(t)
+	writeContextFile(t, root, "docs/references/workflows/check.md", workflowDocument("check", nil, nil, []string{"docs/notes.md"}))
+	writeContextFile(t, root, "docs/notes.md", "# Notes\n\n```go\nfmt.Println()\n```\n")
+	contract := Resolve(root, Request{Workflow: "check"})
+
+	var archives, documents [2]bytes.Buffer
+	for index := range archives {
+		bundle, err := BuildBundle(root, contract)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if err := bundle.WriteTar(&archives[index]); err != nil {
+			t.Fatal(err)
+		}
+		if err := bundle.WriteMarkdown(&documents[index]); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if !bytes.Equal(archives[0].Bytes(), archives[1].Bytes()) || !bytes.Equal(documents[0].Bytes(), documents[1].Bytes()) {
+		t.Fatal("bundle output is not reproducible")
+	}
+
+	reader := tar.NewReader(bytes.NewReader(archives[0].Bytes()))
+	var names []string
+	for {
+		header, err := reader.Next()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			t.Fatal(err)
+		}
+		if header.ModTime.Unix() != 0 || header.Uid != 0 || header.Uname != "" {
+			t.Fatalf("archive header is host-dependent: %#v", header)
+		}
+		names = append(names, header.Name)
+	}
+	want := "manifest.json contract.json evidence/0001/docs/references/workflows/check.md evidence/0002/docs/notes.md"
+	if strings.Join(names, " ") != want {
+		t.Fatalf("archive entries = %v", names)
+	}
+	if !strings.Contains(documents[0].String(), "````\n# Notes") {
+		t.Fatalf("markdown fence does not guard embedded fences:\n%s", documents[0].String())
+	}
+}
+
+func TestBuildBundleIsReproducibleAcrossCheckouts(t *testing.T) {
+	var archives, documents [2]bytes.Buffer
+	for index := range archives {
+		root := contextProject(t)
+		writeContextFile(t, root, "docs/references/workflows/check.md", workflowDocument("check", nil, nil, nil))
+		writeContextFile(t, root, "docs/specs/0001-alpha/SPEC.md", v3Spec("0001", "alpha", "0001-alpha", "", ""))
+		contract := Resolve(root, Request{Workflow: "check", Feature: "alpha"})
+		if !diagnosticCodePresent(contract, "missing-evidence") {
+			t.Fatalf("missing project index was not reported: %#v", contract.Diagnostics)
+		}
+		bundle, err := BuildBundle(root, contract)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if err := bundle.WriteTar(&archives[index]); err != nil {
+			t.Fatal(err)
+		}
+		if err := bundle.WriteMarkdown(&documents[index]); err != nil {
+			t.Fatal(err)
+		}
+		if strings.Contains(documents[index].String(), root) {
+			t.Fatalf("bundle embeds the checkout path %s:\n%s", root, documents[index].String())
+		}
+	}
+	if !bytes.Equal(archives[0].Bytes(), archives[1].Bytes()) || !bytes.Equal(documents[0].Bytes(), documents[1].Bytes()) {
+		t.Fatal("bundle output differs between checkouts")
+	}
+}
+
+func TestBuildBundleRejectsBlockedOrChangedEvidence(t *testing.T) {
+	root := contextProject(t)
+	writeContextFile(t, root, "docs/references/workflows/check.md", workflowDocument("check", nil, nil, []string{"docs/notes.md"}))
+	if _, err := BuildBundle(root, Resolve(root, Request{Workflow: "check"})); err == nil {
+		t.Fatal("blocked contract was bundled")
+	}
+	writeContextFile(t, root, "docs/notes.md", "before\n")
+	contract := Resolve(root, Request{Workflow: "check"})
+	writeContextFile(t, root, "docs/notes.md", "after\n")
+	if _, err := BuildBundle(root, contract); err == nil || !strings.Contains(err.Error(), "changed since resolution") {
+		t.Fatalf("changed evidence error = %v", err)
+	}
+}
@@ -0,0 +1,84 @@
+package context
+
+import (
+	"fmt"
+	"strings"
+)
+
+const defaultWorkflow = "implementation-delivery"
+
+func normalizeWorkflows(request Request) []string {
+	candidates := request.Workflows
+	if len(candidates) == 0 {
+		candidates = []string{request.Workflow}
+	}
+	seen := map[string]bool{}
+	var workflows []string
+	for _, candidate := range candidates {
+		candidate = strings.TrimSpace(candidate)
+		if candidate == "" || seen[candidate] {
+			continue
+		}
+		seen[candidate] = true
+		workflows = append(workflows, candidate)
+	}
+	if len(workflows) == 0 {
+		workflows = []string{defaultWorkflow}
+	}
+	return workflows
+}
+
+// resolveWorkflows resolves each requested root through the shared DFS state,
+// so dependencies common to several roots are selected once, in first-visit
+// order. Evidence and reasons merge through addEvidence.
+func (r *resolver) resolveWorkflows(roots []string) {
+	for _, root := range roots {
+		r.currentRoot = root
+		r.source = "--workflow"
+		r.resolveWorkflow(root, nil)
+	}
+	r.currentRoot = ""
+	if len(roots) > 1 {
+		r.reportRequirementConflicts()
+	}
+}
+
+func (r *resolver) recordRequirement(path string, required bool) {
+	if r.currentRoot == "" {
+		return
+	}
+	byRequirement := r.requirements[path]
+	if byRequirement == nil {
+		byRequirement = map[bool][]string{}
+		r.requirements[path] = byRequirement
+	}
+	byRequirement[required] = appendUnique(byRequirement[required], r.currentRoot)
+}
+
+func (r *resolver) reportRequirementConflicts() {
+	for _, item := range r.contract.Evidence {
+		byRequirement := r.requirements[item.Path]
+		var optionalOnly []string
+		for _, root := range byRequirement[false] {
+			if !containsString(byRequirement[true], root) {
+				optionalOnly = append(optionalOnly, root)
+			}
+		}
+		if len(byRequirement[true]) == 0 || len(optionalOnly) == 0 {
+			continue
+		}
+		r.addDiagnostic("info", "evidence-requirement-conflict", item.Path, fmt.Sprintf(
+			"required by workflow %s but optional in workflow %s; treated as required",
+			strings.Join(byRequirement[true], ", "), strings.Join(optionalOnly, ", "),
+		))
+	}
+}
+
+func containsString(values []string, value string) bool {
+	for _, candidate := range values {
+		if candidate == value {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,59 @@
+package context
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestResolveComposesMultipleWorkflowRoots(t *testing.T) {
+	root := contextProject(t)
+	writeContextFile(t, root, "docs/references/workflows/base.md", workflowDocument("base", nil, []string{"shared"}, nil))
+	writeContextFile(t, root, "docs/references/workflows/repair.md", workflowDocument("repair", []string{"base"}, nil, []string{"shared.md"}))
+	release := `---
+kind: workflow
+slug: release
+description: test
+dependencies:
+  - base
+rules: []
+evidence:
+  - kind: reference
+    path: shared.md
+    required: false
+---
+# Release
+`
+	writeContextFile(t, root, "docs/references/workflows/release.md", release)
+	writeContextFile(t, root, "docs/references/rules/shared.md", rulesetDocument("shared"))
+	writeContextFile(t, root, "shared.md", "shared\n")
+
+	contract := Resolve(root, Request{Workflows: []string{"repair", "release", "repair"}})
+	if contract.Blocked {
+		t.Fatalf("composition blocked: %#v", contract.Diagnostics)
+	}
+	if contract.Request.Workflow != "repair" || !reflect.DeepEqual(contract.Request.Workflows, []string{"repair", "release"}) {
+		t.Fatalf("request roots = %#v", contract.Request)
+	}
+	var slugs []string
+	for _, workflow := range contract.Workflows {
+		slugs = append(slugs, workflow.Slug)
+	}
+	if !reflect.DeepEqual(slugs, []string{"base", "repair", "release"}) {
+		t.Fatalf("workflow order = %#v", slugs)
+	}
+	shared := evidenceByPath(t, contract, "shared.md")
+	if !shared.Required || len(shared.Reasons) != 2 || !strings.Contains(strings.Join(shared.Reasons, " "), "workflow release") {
+		t.Fatalf("merged evidence = %#v", shared)
+	}
+	if !diagnosticCodePresent(contract, "evidence-requirement-conflict") {
+		t.Fatalf("missing requirement conflict diagnostic: %#v", contract.Diagnostics)
+	}
+}
+
+func TestResolveDefaultsToImplementationDeliveryWorkflow(t *testing.T) {
+	contract := Resolve(contextProject(t), Request{Workflows: []string{" "}})
+	if contract.Request.Workflow != defaultWorkflow || !reflect.DeepEqual(contract.Request.Workflows, []string{defaultWorkflow}) {
+		t.Fatalf("default workflow request = %#v", contract.Request)
+	}
+}
@@ -0,0 +1,121 @@
+package context
+
+import (
+	"fmt"
+	"path/filepath"
+	"strings"
+
+	"github.com/jamesonstone/kit/v3/internal/config"
+	"github.com/jamesonstone/kit/v3/internal/document"
+	"github.com/jamesonstone/kit/v3/internal/feature"
+)
+
+type conditionFacts struct {
+	phase          string
+	deliveryIntent string
+	paths          []string
+	config         *config.Config
+}
+
+var conditionConfigFlags = map[string]func(*config.Config) bool{
+	"aws.enabled":        func(cfg *config.Config) bool { return cfg.AWS.IsEnabled() },
+	"usage.enabled":      func(cfg *config.Config) bool { return cfg.IsUsageEnabled() },
+	"allow_out_of_order": func(cfg *config.Config) bool { return cfg.AllowOutOfOrder },
+}
+
+func validateCondition(condition *WorkflowCondition) error {
+	if condition == nil {
+		return nil
+	}
+	for _, name := range condition.Config {
+		if _, ok := conditionConfigFlags[strings.TrimPrefix(strings.TrimSpace(name), "!")]; !ok {
+			return fmt.Errorf("unsupported when config flag %q", name)
+		}
+	}
+	for _, prefix := range condition.PathPrefix {
+		if err := ValidatePathGlob(prefix); err != nil {
+			return fmt.Errorf("invalid when path_prefix: %w", err)
+		}
+		if strings.ContainsAny(prefix, "*?[{") {
+			return fmt.Errorf("invalid when path_prefix %q: use a literal directory prefix, not a glob", prefix)
+		}
+	}
+	return nil
+}
+
+// loadConditionFacts gathers the feature and configuration state that
+// workflow `when` clauses match against. Failures leave facts empty; the
+// feature and configuration resolvers report them as diagnostics.
+func (r *resolver) loadConditionFacts(request Request) {
+	r.facts = conditionFacts{paths: request.Paths}
+	cfg, err := config.Load(r.root)
+	if err != nil {
+		return
+	}
+	r.facts.config = cfg
+	if request.Feature == "" {
+		return
+	}
+	feat, err := feature.Resolve(cfg.SpecsPath(r.root), request.Feature)
+	if err != nil {
+		return
+	}
+	doc, err := document.ParseFile(filepath.Join(feat.Path, "SPEC.md"), document.TypeSpec)
+	if err != nil || doc.Metadata == nil {
+		return
+	}
+	r.facts.phase = strings.TrimSpace(doc.Metadata.Phase)
+	r.facts.deliveryIntent = doc.DeliveryIntent()
+}
+
+// unmetCondition returns a description of the first unmet clause, or "" when
+// every clause matches. Values within a clause are alternatives.
+func (r *resolver) unmetCondition(condition *WorkflowCondition) string {
+	if condition == nil {
+		return ""
+	}
+	if len(condition.Phase) > 0 && !containsFold(condition.Phase, r.facts.phase) {
+		return fmt.Sprintf("phase in [%s], feature phase is %q", strings.Join(condition.Phase, ", "), r.facts.phase)
+	}
+	if len(condition.DeliveryIntent) > 0 && !containsFold(condition.DeliveryIntent, r.facts.deliveryIntent) {
+		return fmt.Sprintf("delivery_intent in [%s], feature delivery_intent is %q", strings.Join(condition.DeliveryIntent, ", "), r.facts.deliveryIntent)
+	}
+	if len(condition.PathPrefix) > 0 && !r.hintUnderPrefix(condition.PathPrefix) {
+		return fmt.Sprintf("path hint under [%s]", strings.Join(condition.PathPrefix, ", "))
+	}
+	for _, name := range condition.Config {
+		name = strings.TrimSpace(name)
+		want := !strings.HasPrefix(name, "!")
+		check := conditionConfigFlags[strings.TrimPrefix(name, "!")]
+		if r.facts.config == nil || check == nil || check(r.facts.config) != want {
+			return fmt.Sprintf("config %s", name)
+		}
+	}
+	return ""
+}
+
+func (r *resolver) hintUnderPrefix(prefixes []string) bool {
+	for _, prefix := range prefixes {
+		prefix = strings.TrimSuffix(filepath.ToSlash(filepath.Clean(strings.TrimSpace(prefix))), "/")
+		for _, hint := range r.facts.paths {
+			if hint == prefix || strings.HasPrefix(hint, prefix+"/") {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func (r *resolver) skip(kind, path, workflow, condition string) {
+	r.contract.Skipped = append(r.contract.Skipped, SkippedEvidence{Kind: kind, Path: path, Workflow: workflow, Condition: condition})
+	r.trace("skip-condition", kind, path, "when "+condition+" is not met")
+}
+
+func containsFold(values []string, value string) bool {
+	for _, candidate := range values {
+		if strings.EqualFold(strings.TrimSpace(candidate), value) && value != "" {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,96 @@
+package context
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/jamesonstone/kit/v3/internal/config"
+)
+
+const conditionalWorkflow = `---
+kind: workflow
+slug: check
+description: test
+rules:
+  - slug: aws-guidance
+    required: true
+    when:
+      config: [aws.enabled]
+  - slug: frontend
+    required: false
+    when:
+      path_prefix: [web/]
+evidence:
+  - kind: strategy
+    path: implementation.md
+    required: true
+    when:
+      phase: [implementation]
+---
+# Check
+`
+
+func TestResolveSkipsEntriesWithUnmetWhenClauses(t *testing.T) {
+	root := contextProject(t)
+	writeContextFile(t, root, "docs/references/workflows/check.md", conditionalWorkflow)
+	contract := Resolve(root, Request{Workflow: "check"})
+	if contract.Blocked || len(contract.Evidence) != 1 {
+		t.Fatalf("conditional entries were selected: %#v", contract)
+	}
+	conditions := map[string]string{}
+	for _, skipped := range contract.Skipped {
+		conditions[skipped.Path] = skipped.Condition
+	}
+	for path, want := range map[string]string{
+		"docs/references/rules/aws-guidance.md": "config aws.enabled",
+		"docs/references/rules/frontend.md":     "path hint under [web/]",
+		"implementation.md":                     "phase in [implementation]",
+	} {
+		if !strings.HasPrefix(conditions[path], want) {
+			t.Errorf("skipped %s condition = %q, want prefix %q", path, conditions[path], want)
+		}
+	}
+}
+
+func TestResolveIncludesEntriesWithMetWhenClauses(t *testing.T) {
+	root := t.TempDir()
+	cfg := config.Default()
+	cfg.AWS = &config.AWSConfig{}
+	if err := config.Save(root, cfg); err != nil {
+		t.Fatal(err)
+	}
+	writeContextFile(t, root, "docs/references/workflows/check.md", conditionalWorkflow)
+	writeContextFile(t, root, "docs/references/rules/aws-guidance.md", rulesetDocument("aws-guidance"))
+	writeContextFile(t, root, "docs/references/rules/frontend.md", rulesetDocument("frontend"))
+	writeContextFile(t, root, "implementation.md", "strategy\n")
+	writeContextFile(t, root, "web/app.tsx", "app\n")
+	writeContextFile(t, root, "docs/specs/0001-alpha/SPEC.md", v3Spec("0001", "alpha", "0001-alpha", "", ""))
+
+	contract := Resolve(root, Request{Workflow: "check", Feature: "alpha", Paths: []string{"web/app.tsx"}})
+	if contract.Blocked || len(contract.Skipped) != 0 {
+		t.Fatalf("met conditions were skipped: %#v", contract)
+	}
+	for _, path := range []string{"docs/references/rules/aws-guidance.md", "docs/references/rules/frontend.md", "implementation.md"} {
+		if !evidencePathPresent(contract, path) {
+			t.Fatalf("missing conditional evidence %s: %#v", path, contract.Evidence)
+		}
+	}
+}
+
+func TestValidateManifestRejectsUnknownWhenConfigFlag(t *testing.T) {
+	manifest := WorkflowManifest{Description: "test", Evidence: []WorkflowEvidence{{
+		Kind: "reference", Path: "a.md", When: &WorkflowCondition{Config: []string{"unknown.flag"}},
+	}}}
+	if err := validateManifest(manifest); err == nil {
+		t.Fatal("unknown when config flag was accepted")
+	}
+}
+
+func TestValidateManifestRejectsGlobPathPrefix(t *testing.T) {
+	manifest := WorkflowManifest{Description: "test", Evidence: []WorkflowEvidence{{
+		Kind: "reference", Path: "a.md", When: &WorkflowCondition{PathPrefix: []string{"docs/*"}},
+	}}}
+	if err := validateManifest(manifest); err == nil || !strings.Contains(err.Error(), "literal directory prefix") {
+		t.Fatalf("glob path_prefix error = %v", err)
+	}
+}
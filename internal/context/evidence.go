@@ -9,18 +9,30 @@ import (
 )
 
 func (r *resolver) addEvidence(kind, path string, required bool, reason string) []byte {
+	return r.addSelectedEvidence(kind, path, required, reason, "", "")
+}
+
+func (r *resolver) addSelectedEvidence(kind, path string, required bool, reason, selectorType, selector string) []byte {
 	relativePath, data, err := secureRead(r.root, path)
 	if relativePath == "" {
 		relativePath = filepath.ToSlash(filepath.Clean(strings.TrimSpace(path)))
 	}
+	r.recordRequirement(relativePath, required)
 	if index, ok := r.evidenceIndex[relativePath]; ok {
 		item := &r.contract.Evidence[index]
 		wasRequired := item.Required
 		item.Required = item.Required || required
 		item.Reasons = appendUnique(item.Reasons, reason)
-		if required && !wasRequired && item.State != "present" {
-			r.addDiagnostic("error", "missing-evidence", relativePath, "evidence became required through another selected context")
+		if required && !wasRequired {
+			r.trace("promote", item.Kind, relativePath, "optional evidence promoted to required: "+reason)
+			if item.State != "present" {
+				r.addDiagnostic("error", "missing-evidence", relativePath, "evidence became required through another selected context")
+			}
+		} else {
+			r.trace("merge", item.Kind, relativePath, reason)
 		}
+		r.attachSpan(item, data, required, selectorType, selector)
+		measureEvidence(item, data)
 		return data
 	}
 	item := EvidenceItem{Kind: kind, Path: relativePath, Required: required, State: "present", Reasons: []string{reason}}
@@ -38,15 +50,35 @@ func (r *resolver) addEvidence(kind, path string, required bool, reason string)
 	} else {
 		item.Digest = digest(data)
 	}
+	r.trace("add", kind, relativePath, reason)
 	r.evidenceIndex[relativePath] = len(r.contract.Evidence)
 	r.contract.Evidence = append(r.contract.Evidence, item)
+	added := &r.contract.Evidence[len(r.contract.Evidence)-1]
+	r.attachSpan(added, data, required, selectorType, selector)
+	measureEvidence(added, data)
 	return data
 }
 
 func secureRead(root, value string) (string, []byte, error) {
+	relative, resolved, err := confinePath(root, value)
+	if err != nil {
+		return relative, nil, err
+	}
+	info, err := os.Stat(resolved)
+	if err != nil {
+		return relative, nil, err
+	}
+	if info.IsDir() {
+		return relative, nil, fmt.Errorf("evidence path is a directory")
+	}
+	data, err := os.ReadFile(resolved)
+	return relative, data, err
+}
+
+func confinePath(root, value string) (string, string, error) {
 	value = strings.TrimSpace(value)
 	if value == "" {
-		return "", nil, fmt.Errorf("evidence path is blank")
+		return "", "", fmt.Errorf("evidence path is blank")
 	}
 	path := value
 	if !filepath.IsAbs(path) {
@@ -54,33 +86,31 @@ func secureRead(root, value string) (string, []byte, error) {
 	}
 	abs, err := filepath.Abs(path)
 	if err != nil {
-		return filepath.ToSlash(value), nil, err
+		return filepath.ToSlash(value), "", err
 	}
 	relative, err := filepath.Rel(root, abs)
 	if err != nil || relative == ".." || strings.HasPrefix(relative, ".."+string(filepath.Separator)) {
-		return filepath.ToSlash(value), nil, fmt.Errorf("evidence path escapes the project root")
+		return filepath.ToSlash(value), "", fmt.Errorf("evidence path escapes the project root")
 	}
 	relative = filepath.ToSlash(relative)
 	resolved, err := filepath.EvalSymlinks(abs)
 	if err != nil {
-		return relative, nil, err
+		return relative, "", err
 	}
 	resolvedRelative, err := filepath.Rel(root, resolved)
 	if err != nil || resolvedRelative == ".." || strings.HasPrefix(resolvedRelative, ".."+string(filepath.Separator)) {
-		return relative, nil, fmt.Errorf("evidence symlink escapes the project root")
-	}
-	info, err := os.Stat(resolved)
-	if err != nil {
-		return relative, nil, err
+		return relative, "", fmt.Errorf("evidence symlink escapes the project root")
 	}
-	if info.IsDir() {
-		return relative, nil, fmt.Errorf("evidence path is a directory")
-	}
-	data, err := os.ReadFile(resolved)
-	return relative, data, err
+	return relative, resolved, nil
 }
 
+// addDiagnostic records message relative to the project root so contracts,
+// and the bundles built from them, do not depend on where the checkout lives.
 func (r *resolver) addDiagnostic(level, code, path, message string) {
+	if r.root != "" {
+		message = strings.ReplaceAll(message, r.root+string(filepath.Separator), "")
+		message = strings.ReplaceAll(message, r.root, ".")
+	}
 	r.contract.Diagnostics = append(r.contract.Diagnostics, Diagnostic{Level: level, Code: code, Path: path, Message: message})
 }
 
@@ -0,0 +1,158 @@
+package context
+
+import (
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+const maxExpandedEvidence = 64
+
+// skippedEvidenceDirs are never walked during expansion: version-control
+// metadata and vendored dependencies are not project evidence.
+var skippedEvidenceDirs = map[string]bool{".git": true, "node_modules": true, "vendor": true}
+
+// addEvidenceSet selects a file, directory, or glob target. Directories expand
+// recursively and globs match slash-separated paths with `**` support; both
+// yield sorted per-file evidence items without following symlinks that leave
+// the file tree or the project root. Expansions larger than
+// maxExpandedEvidence select nothing, and expansions matching nothing are
+// reported rather than treated as a missing literal path.
+func (r *resolver) addEvidenceSet(kind, target string, required bool, reason, selectorType, selector string) {
+	target = strings.TrimSpace(target)
+	var files []string
+	var err error
+	switch {
+	case hasGlob(target) && !r.existsLiteral(target):
+		files, err = r.expandGlob(target)
+	case r.isDirectory(target):
+		files, err = r.expandDirectory(target)
+	default:
+		r.addSelectedEvidence(kind, target, required, reason, selectorType, selector)
+		return
+	}
+	level := "warning"
+	if required {
+		level = "error"
+	}
+	if err != nil {
+		r.addDiagnostic(level, "invalid-evidence-path", filepath.ToSlash(target), err.Error())
+		return
+	}
+	if len(files) == 0 {
+		r.addDiagnostic(level, "evidence-expansion-empty", filepath.ToSlash(target), "target matches no files")
+		return
+	}
+	if len(files) > maxExpandedEvidence {
+		r.addDiagnostic(level, "evidence-expansion-too-large", filepath.ToSlash(target), fmt.Sprintf("target expands to more than %d files; narrow the directory or glob", maxExpandedEvidence))
+		return
+	}
+	for _, file := range files {
+		r.addEvidence(kind, file, required, reason+" via "+filepath.ToSlash(target))
+	}
+}
+
+func hasGlob(value string) bool {
+	return strings.ContainsAny(value, "*?[")
+}
+
+// existsLiteral reports whether target names an existing path as written, so
+// literal paths such as app/[id]/page.tsx are not treated as globs.
+func (r *resolver) existsLiteral(target string) bool {
+	_, err := os.Lstat(filepath.Join(r.root, filepath.FromSlash(target)))
+	return err == nil
+}
+
+func (r *resolver) isDirectory(target string) bool {
+	_, resolved, err := confinePath(r.root, target)
+	if err != nil {
+		return false
+	}
+	info, err := os.Stat(resolved)
+	return err == nil && info.IsDir()
+}
+
+func (r *resolver) isFile(target string) bool {
+	_, resolved, err := confinePath(r.root, target)
+	if err != nil {
+		return false
+	}
+	info, err := os.Stat(resolved)
+	return err == nil && info.Mode().IsRegular()
+}
+
+func (r *resolver) expandDirectory(target string) ([]string, error) {
+	relative, resolved, err := confinePath(r.root, target)
+	if err != nil {
+		return nil, err
+	}
+	return r.walkEvidenceFiles(resolved, relative, func(string) bool { return true })
+}
+
+func (r *resolver) expandGlob(pattern string) ([]string, error) {
+	pattern = filepath.ToSlash(filepath.Clean(pattern))
+	if err := ValidatePathGlob(pattern); err != nil {
+		return nil, err
+	}
+	var base []string
+	for _, segment := range strings.Split(pattern, "/") {
+		if hasGlob(segment) {
+			break
+		}
+		base = append(base, segment)
+	}
+	baseRelative := strings.Join(base, "/")
+	if baseRelative == "" {
+		baseRelative = "."
+	}
+	relative, resolved, err := confinePath(r.root, baseRelative)
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return r.walkEvidenceFiles(resolved, relative, func(path string) bool { return matchPathGlob(pattern, path) })
+}
+
+// walkEvidenceFiles stops as soon as more than maxExpandedEvidence files
+// match, so callers see an oversized expansion without a full walk.
+func (r *resolver) walkEvidenceFiles(resolved, relative string, match func(string) bool) ([]string, error) {
+	var files []string
+	err := filepath.WalkDir(resolved, func(path string, entry fs.DirEntry, walkErr error) error {
+		if walkErr != nil {
+			return walkErr
+		}
+		if entry.IsDir() {
+			if path != resolved && skippedEvidenceDirs[entry.Name()] {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		inner, err := filepath.Rel(resolved, path)
+		if err != nil {
+			return err
+		}
+		logical := filepath.ToSlash(filepath.Join(relative, inner))
+		if entry.Type()&fs.ModeSymlink != 0 {
+			if info, err := os.Stat(path); err != nil || info.IsDir() {
+				return nil
+			}
+			if _, _, err := confinePath(r.root, logical); err != nil {
+				return nil
+			}
+		}
+		if match(logical) {
+			files = append(files, logical)
+		}
+		if len(files) > maxExpandedEvidence {
+			return fs.SkipAll
+		}
+		return nil
+	})
+	sort.Strings(files)
+	return files, err
+}
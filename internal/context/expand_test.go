@@ -0,0 +1,105 @@
+package context
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func TestResolveExpandsDirectoryAndGlobEvidence(t *testing.T) {
+	root := contextProject(t)
+	writeContextFile(t, root, "docs/references/workflows/check.md", workflowDocument("check", nil, nil, []string{"docs/adr/", "docs/references/*.md"}))
+	writeContextFile(t, root, "docs/adr/0002-second.md", "second\n")
+	writeContextFile(t, root, "docs/adr/0001-first.md", "first\n")
+	writeContextFile(t, root, "docs/adr/nested/0003-third.md", "third\n")
+	writeContextFile(t, root, "docs/references/b.md", "b\n")
+	writeContextFile(t, root, "docs/references/a.md", "a\n")
+	outside := t.TempDir()
+	writeContextFile(t, outside, "secret.md", "secret\n")
+	if err := os.Symlink(outside, filepath.Join(root, "docs", "adr", "linked")); err != nil {
+		t.Fatal(err)
+	}
+
+	contract := Resolve(root, Request{Workflow: "check"})
+	if contract.Blocked {
+		t.Fatalf("expansion blocked: %#v", contract.Diagnostics)
+	}
+	var paths []string
+	for _, item := range contract.Evidence[1:] {
+		paths = append(paths, item.Path)
+		if item.Digest == "" {
+			t.Fatalf("expanded evidence lacks digest: %#v", item)
+		}
+	}
+	want := []string{
+		"docs/adr/0001-first.md", "docs/adr/0002-second.md", "docs/adr/nested/0003-third.md",
+		"docs/references/a.md", "docs/references/b.md",
+	}
+	if !reflect.DeepEqual(paths, want) {
+		t.Fatalf("expanded evidence = %#v, want %#v", paths, want)
+	}
+}
+
+func TestResolveTreatsExistingBracketPathsAsLiterals(t *testing.T) {
+	root := contextProject(t)
+	writeContextFile(t, root, "docs/references/workflows/check.md", workflowDocument("check", nil, nil, []string{"app/[id]/page.tsx", "app/[id]"}))
+	writeContextFile(t, root, "app/[id]/page.tsx", "export default function Page() {}\n")
+	writeContextFile(t, root, "app/[id]/layout.tsx", "export default function Layout() {}\n")
+
+	contract := Resolve(root, Request{Workflow: "check"})
+	if contract.Blocked || diagnosticCodePresent(contract, "evidence-expansion-empty") {
+		t.Fatalf("literal bracket paths did not resolve: %#v", contract.Diagnostics)
+	}
+	var paths []string
+	for _, item := range contract.Evidence[1:] {
+		paths = append(paths, item.Path)
+	}
+	if want := []string{"app/[id]/page.tsx", "app/[id]/layout.tsx"}; !reflect.DeepEqual(paths, want) {
+		t.Fatalf("literal bracket evidence = %#v, want %#v", paths, want)
+	}
+}
+
+func TestResolveCapsLargeEvidenceExpansion(t *testing.T) {
+	root := contextProject(t)
+	writeContextFile(t, root, "docs/references/workflows/check.md", workflowDocument("check", nil, nil, []string{"notes/**"}))
+	for index := 0; index <= maxExpandedEvidence; index++ {
+		writeContextFile(t, root, fmt.Sprintf("notes/%03d.md", index), "note\n")
+	}
+	contract := Resolve(root, Request{Workflow: "check"})
+	if !contract.Blocked || !diagnosticCodePresent(contract, "evidence-expansion-too-large") || len(contract.Evidence) != 1 {
+		t.Fatalf("oversized required expansion = %#v", contract)
+	}
+}
+
+func TestResolveExpansionSkipsVendoredDirsAndEscapingFileSymlinks(t *testing.T) {
+	root := contextProject(t)
+	writeContextFile(t, root, "docs/references/workflows/check.md", workflowDocument("check", nil, nil, []string{"src/**/*.go", "missing/*.md"}))
+	writeContextFile(t, root, "src/main.go", "package main\n")
+	writeContextFile(t, root, "src/.git/hooks/hook.go", "package hooks\n")
+	writeContextFile(t, root, "src/node_modules/dep/dep.go", "package dep\n")
+	writeContextFile(t, root, "src/vendor/lib/lib.go", "package lib\n")
+	outside := t.TempDir()
+	writeContextFile(t, outside, "secret.go", "package secret\n")
+	if err := os.Symlink(filepath.Join(outside, "secret.go"), filepath.Join(root, "src", "linked.go")); err != nil {
+		t.Fatal(err)
+	}
+
+	contract := Resolve(root, Request{Workflow: "check"})
+	var paths []string
+	for _, item := range contract.Evidence[1:] {
+		paths = append(paths, item.Path)
+	}
+	if !reflect.DeepEqual(paths, []string{"src/main.go"}) {
+		t.Fatalf("expanded evidence = %#v", paths)
+	}
+	if diagnosticCodePresent(contract, "invalid-evidence-path") || !diagnosticCodePresent(contract, "evidence-expansion-empty") {
+		t.Fatalf("diagnostics = %#v", contract.Diagnostics)
+	}
+	for _, diagnostic := range contract.Diagnostics {
+		if diagnostic.Code == "missing-evidence" && diagnostic.Path == "missing/*.md" {
+			t.Fatalf("empty glob reported as a missing path: %#v", diagnostic)
+		}
+	}
+}
@@ -0,0 +1,167 @@
+package context
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+const GraphSchemaVersion = "kit.context.graph/v1"
+
+type Graph struct {
+	SchemaVersion    string          `json:"schema_version"`
+	Valid            bool            `json:"valid"`
+	Workflows        []GraphWorkflow `json:"workflows"`
+	Rules            []GraphRule     `json:"rules"`
+	Edges            []GraphEdge     `json:"edges"`
+	Cycles           [][]string      `json:"cycles"`
+	UnreachableRules []string        `json:"unreachable_rules"`
+	Diagnostics      []Diagnostic    `json:"diagnostics"`
+}
+
+type GraphWorkflow struct {
+	Slug        string `json:"slug"`
+	Path        string `json:"path"`
+	Description string `json:"description,omitempty"`
+	Valid       bool   `json:"valid"`
+}
+
+type GraphRule struct {
+	Slug    string `json:"slug"`
+	Path    string `json:"path"`
+	Present bool   `json:"present"`
+}
+
+type GraphEdge struct {
+	From     string `json:"from"`
+	To       string `json:"to"`
+	Kind     string `json:"kind"`
+	Required bool   `json:"required"`
+}
+
+// LoadGraph validates every workflow manifest and rule reference under
+// docs/references/ and returns the full dependency graph, independent of any
+// single resolution path.
+func LoadGraph(projectRoot string) (Graph, error) {
+	root, err := canonicalRoot(projectRoot)
+	if err != nil {
+		return Graph{}, err
+	}
+	graph := Graph{
+		SchemaVersion: GraphSchemaVersion, Workflows: []GraphWorkflow{}, Rules: []GraphRule{},
+		Edges: []GraphEdge{}, Cycles: [][]string{}, UnreachableRules: []string{}, Diagnostics: []Diagnostic{},
+	}
+	workflowSlugs := markdownSlugs(filepath.Join(root, "docs", "references", "workflows"))
+	known := map[string]bool{}
+	for _, slug := range workflowSlugs {
+		known[slug] = true
+	}
+	referenced := map[string]bool{}
+	for _, slug := range workflowSlugs {
+		manifest, path, _, err := loadWorkflow(root, slug)
+		if path == "" {
+			path = filepath.ToSlash(filepath.Join("docs", "references", "workflows", slug+".md"))
+		}
+		node := GraphWorkflow{Slug: slug, Path: path, Description: manifest.Description, Valid: err == nil}
+		graph.Workflows = append(graph.Workflows, node)
+		if err != nil {
+			graph.addDiagnostic("error", "invalid-workflow", path, err.Error())
+			continue
+		}
+		for _, dependency := range manifest.Dependencies {
+			graph.Edges = append(graph.Edges, GraphEdge{From: slug, To: dependency, Kind: "dependency", Required: true})
+			if !known[dependency] {
+				graph.addDiagnostic("error", "missing-workflow", path, fmt.Sprintf("workflow %s depends on missing workflow %s", slug, dependency))
+			}
+		}
+		for _, rule := range manifest.Rules {
+			referenced[rule.Slug] = true
+			graph.Edges = append(graph.Edges, GraphEdge{From: slug, To: "rule:" + rule.Slug, Kind: "rule", Required: rule.Required})
+		}
+	}
+	graph.addRules(root, referenced)
+	graph.Cycles = dependencyCycles(graph.Workflows, graph.Edges)
+	for _, cycle := range graph.Cycles {
+		graph.addDiagnostic("error", "workflow-cycle", "", "workflow dependency cycle among: "+strings.Join(cycle, ", "))
+	}
+	graph.Valid = true
+	for _, diagnostic := range graph.Diagnostics {
+		if diagnostic.Level == "error" {
+			graph.Valid = false
+		}
+	}
+	return graph, nil
+}
+
+func (g *Graph) addRules(root string, referenced map[string]bool) {
+	rulesDir := filepath.Join(root, "docs", "references", "rules")
+	installed := map[string]bool{}
+	all := map[string]bool{}
+	for _, slug := range markdownSlugs(rulesDir) {
+		installed[slug] = true
+		all[slug] = true
+	}
+	for slug := range referenced {
+		all[slug] = true
+	}
+	required := map[string]bool{}
+	for _, edge := range g.Edges {
+		if edge.Kind == "rule" && edge.Required {
+			required[strings.TrimPrefix(edge.To, "rule:")] = true
+		}
+	}
+	slugs := make([]string, 0, len(all))
+	for slug := range all {
+		slugs = append(slugs, slug)
+	}
+	sort.Strings(slugs)
+	for _, slug := range slugs {
+		path := filepath.ToSlash(filepath.Join("docs", "references", "rules", slug+".md"))
+		_, data, err := secureRead(root, path)
+		g.Rules = append(g.Rules, GraphRule{Slug: slug, Path: path, Present: err == nil})
+		level := "warning"
+		if required[slug] {
+			level = "error"
+		}
+		var header rulesetHeader
+		switch {
+		case errors.Is(err, os.ErrNotExist):
+			g.addDiagnostic(level, "missing-rule", path, "workflow rule file is missing")
+		case err != nil:
+			g.addDiagnostic(level, "invalid-rule", path, err.Error())
+		default:
+			if headerErr := validateArtifactHeader(data, "ruleset", slug); headerErr != nil {
+				g.addDiagnostic(level, "invalid-rule", path, headerErr.Error())
+			} else if header, err = parseRulesetHeader(root, path, slug); err != nil {
+				g.addDiagnostic("warning", "invalid-rule-paths", path, err.Error())
+			}
+		}
+		if !installed[slug] || referenced[slug] || len(header.Paths) > 0 {
+			continue
+		}
+		g.UnreachableRules = append(g.UnreachableRules, slug)
+		g.addDiagnostic("warning", "unreachable-rule", path, "no workflow references this ruleset and it declares no paths globs")
+	}
+}
+
+func (g *Graph) addDiagnostic(level, code, path, message string) {
+	g.Diagnostics = append(g.Diagnostics, Diagnostic{Level: level, Code: code, Path: path, Message: message})
+}
+
+func markdownSlugs(dir string) []string {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil
+	}
+	var slugs []string
+	for _, entry := range entries {
+		if !entry.IsDir() && strings.HasSuffix(entry.Name(), ".md") {
+			slugs = append(slugs, strings.TrimSuffix(entry.Name(), ".md"))
+		}
+	}
+	sort.Strings(slugs)
+	return slugs
+}
@@ -0,0 +1,108 @@
+package context
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// dependencyCycles returns every strongly connected component of the workflow
+// dependency graph that contains a cycle, each sorted, in sorted order.
+func dependencyCycles(workflows []GraphWorkflow, edges []GraphEdge) [][]string {
+	adjacency := map[string][]string{}
+	for _, edge := range edges {
+		if edge.Kind == "dependency" {
+			adjacency[edge.From] = append(adjacency[edge.From], edge.To)
+		}
+	}
+	index, next := map[string]int{}, 0
+	low, onStack := map[string]int{}, map[string]bool{}
+	var stack []string
+	cycles := [][]string{}
+	var visit func(string)
+	visit = func(node string) {
+		index[node], low[node] = next, next
+		next++
+		stack = append(stack, node)
+		onStack[node] = true
+		for _, target := range adjacency[node] {
+			if _, seen := index[target]; !seen {
+				visit(target)
+				low[node] = min(low[node], low[target])
+			} else if onStack[target] {
+				low[node] = min(low[node], index[target])
+			}
+		}
+		if low[node] != index[node] {
+			return
+		}
+		var component []string
+		for {
+			top := stack[len(stack)-1]
+			stack = stack[:len(stack)-1]
+			onStack[top] = false
+			component = append(component, top)
+			if top == node {
+				break
+			}
+		}
+		if len(component) > 1 || containsString(adjacency[node], node) {
+			sort.Strings(component)
+			cycles = append(cycles, component)
+		}
+	}
+	for _, workflow := range workflows {
+		if _, seen := index[workflow.Slug]; !seen {
+			visit(workflow.Slug)
+		}
+	}
+	sort.Slice(cycles, func(i, j int) bool { return cycles[i][0] < cycles[j][0] })
+	return cycles
+}
+
+func (g Graph) DOT() string {
+	var builder strings.Builder
+	builder.WriteString("digraph kit_context {\n  rankdir=LR;\n")
+	for _, workflow := range g.Workflows {
+		fmt.Fprintf(&builder, "  %q [shape=box];\n", workflow.Slug)
+	}
+	for _, rule := range g.Rules {
+		fmt.Fprintf(&builder, "  %q [shape=note];\n", "rule:"+rule.Slug)
+	}
+	for _, edge := range g.Edges {
+		style := "solid"
+		if !edge.Required {
+			style = "dashed"
+		}
+		fmt.Fprintf(&builder, "  %q -> %q [label=%q, style=%s];\n", edge.From, edge.To, edge.Kind, style)
+	}
+	builder.WriteString("}\n")
+	return builder.String()
+}
+
+func (g Graph) Mermaid() string {
+	var builder strings.Builder
+	builder.WriteString("flowchart LR\n")
+	for _, workflow := range g.Workflows {
+		fmt.Fprintf(&builder, "  %s[%q]\n", mermaidID("workflow", workflow.Slug), workflow.Slug)
+	}
+	for _, rule := range g.Rules {
+		fmt.Fprintf(&builder, "  %s>%q]\n", mermaidID("rule", rule.Slug), rule.Slug)
+	}
+	for _, edge := range g.Edges {
+		arrow := "-->"
+		if !edge.Required {
+			arrow = "-.->"
+		}
+		target := mermaidID("workflow", edge.To)
+		if edge.Kind == "rule" {
+			target = mermaidID("rule", strings.TrimPrefix(edge.To, "rule:"))
+		}
+		fmt.Fprintf(&builder, "  %s %s %s\n", mermaidID("workflow", edge.From), arrow, target)
+	}
+	return builder.String()
+}
+
+func mermaidID(kind, slug string) string {
+	return kind + "_" + strings.ReplaceAll(slug, "-", "_")
+}
@@ -0,0 +1,60 @@
+package context
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestLoadGraphReportsEveryCycleAndUnreachableRule(t *testing.T) {
+	root := contextProject(t)
+	writeContextFile(t, root, "docs/references/workflows/a.md", workflowDocument("a", []string{"b"}, []string{"shared"}, nil))
+	writeContextFile(t, root, "docs/references/workflows/b.md", workflowDocument("b", []string{"a"}, nil, nil))
+	writeContextFile(t, root, "docs/references/workflows/c.md", workflowDocument("c", []string{"c", "ghost"}, []string{"absent"}, nil))
+	writeContextFile(t, root, "docs/references/rules/shared.md", rulesetDocument("shared"))
+	writeContextFile(t, root, "docs/references/rules/orphan.md", rulesetDocument("orphan"))
+
+	graph, err := LoadGraph(root)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if graph.Valid {
+		t.Fatal("graph with cycles was reported valid")
+	}
+	if !reflect.DeepEqual(graph.Cycles, [][]string{{"a", "b"}, {"c"}}) {
+		t.Fatalf("cycles = %#v", graph.Cycles)
+	}
+	if !reflect.DeepEqual(graph.UnreachableRules, []string{"orphan"}) {
+		t.Fatalf("unreachable rules = %#v", graph.UnreachableRules)
+	}
+	codes := map[string]bool{}
+	for _, diagnostic := range graph.Diagnostics {
+		codes[diagnostic.Code] = true
+	}
+	for _, code := range []string{"workflow-cycle", "missing-workflow", "missing-rule", "unreachable-rule"} {
+		if !codes[code] {
+			t.Fatalf("missing %s diagnostic: %#v", code, graph.Diagnostics)
+		}
+	}
+	if dot := graph.DOT(); !strings.Contains(dot, `"a" -> "rule:shared"`) {
+		t.Fatalf("dot output = %s", dot)
+	}
+	if mermaid := graph.Mermaid(); !strings.Contains(mermaid, "workflow_a --> workflow_b") {
+		t.Fatalf("mermaid output = %s", mermaid)
+	}
+}
+
+func TestLoadGraphTreatsPathRoutedRulesAsReachable(t *testing.T) {
+	root := contextProject(t)
+	writeContextFile(t, root, "docs/references/workflows/a.md", workflowDocument("a", nil, nil, nil))
+	routed := strings.Replace(rulesetDocument("routed"), "kind: ruleset\n", "kind: ruleset\npaths:\n  - pkg/**\n", 1)
+	writeContextFile(t, root, "docs/references/rules/routed.md", routed)
+
+	graph, err := LoadGraph(root)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !graph.Valid || len(graph.UnreachableRules) != 0 {
+		t.Fatalf("graph = %#v", graph)
+	}
+}
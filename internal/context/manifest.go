@@ -26,6 +26,7 @@ func loadWorkflow(projectRoot, slug string) (WorkflowManifest, string, []byte, e
 	if err := yaml.Unmarshal(frontMatter, &manifest); err != nil {
 		return WorkflowManifest{}, relativePath, data, fmt.Errorf("parse workflow front matter: %w", err)
 	}
+	annotateManifestLines(frontMatter, &manifest)
 	if manifest.Kind != "workflow" {
 		return WorkflowManifest{}, relativePath, data, fmt.Errorf("workflow kind is %q, want workflow", manifest.Kind)
 	}
@@ -60,12 +61,23 @@ func validateManifest(manifest WorkflowManifest) error {
 		if seenRules[rule.Slug] {
 			return fmt.Errorf("duplicate workflow rule %q", rule.Slug)
 		}
+		for _, glob := range rule.Paths {
+			if err := ValidatePathGlob(glob); err != nil {
+				return fmt.Errorf("invalid workflow rule %q paths: %w", rule.Slug, err)
+			}
+		}
+		if err := validateCondition(rule.When); err != nil {
+			return fmt.Errorf("invalid workflow rule %q: %w", rule.Slug, err)
+		}
 		seenRules[rule.Slug] = true
 	}
 	for _, evidence := range manifest.Evidence {
 		if strings.TrimSpace(evidence.Kind) == "" || strings.TrimSpace(evidence.Path) == "" {
 			return fmt.Errorf("workflow evidence requires kind and path")
 		}
+		if err := validateCondition(evidence.When); err != nil {
+			return fmt.Errorf("invalid workflow evidence %q: %w", evidence.Path, err)
+		}
 	}
 	return nil
 }
@@ -0,0 +1,147 @@
+package context
+
+import (
+	"fmt"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/jamesonstone/kit/v3/internal/document"
+)
+
+// ValidatePathGlob reports whether pattern is a repository-relative slash
+// glob. Segments use path.Match syntax and `**` matches zero or more segments.
+func ValidatePathGlob(pattern string) error {
+	pattern = strings.TrimSpace(pattern)
+	if pattern == "" {
+		return fmt.Errorf("path glob is blank")
+	}
+	if strings.HasPrefix(pattern, "/") || filepath.IsAbs(pattern) {
+		return fmt.Errorf("path glob %q must be repository-relative", pattern)
+	}
+	for _, segment := range strings.Split(pattern, "/") {
+		if segment == ".." {
+			return fmt.Errorf("path glob %q escapes the project root", pattern)
+		}
+		if segment == "**" {
+			continue
+		}
+		if _, err := path.Match(segment, ""); err != nil {
+			return fmt.Errorf("path glob %q is invalid: %w", pattern, err)
+		}
+	}
+	return nil
+}
+
+func matchPathGlob(pattern, value string) bool {
+	return matchSegments(strings.Split(strings.TrimSpace(pattern), "/"), strings.Split(value, "/"))
+}
+
+func matchSegments(pattern, value []string) bool {
+	if len(pattern) == 0 {
+		return len(value) == 0
+	}
+	if pattern[0] == "**" {
+		for index := 0; index <= len(value); index++ {
+			if matchSegments(pattern[1:], value[index:]) {
+				return true
+			}
+		}
+		return false
+	}
+	if len(value) == 0 {
+		return false
+	}
+	matched, err := path.Match(pattern[0], value[0])
+	return err == nil && matched && matchSegments(pattern[1:], value[1:])
+}
+
+func matchHints(globs, hints []string) (string, string, bool) {
+	for _, glob := range globs {
+		for _, hint := range hints {
+			if matchPathGlob(glob, hint) {
+				return glob, hint, true
+			}
+		}
+	}
+	return "", "", false
+}
+
+func pathHintReason(glob, hint string) string {
+	return fmt.Sprintf("path hint %s matched %s", glob, hint)
+}
+
+// routePathHints selects installed rulesets whose front matter `paths` globs
+// match a path hint. Stale rulesets and skip-by-default rulesets are ignored.
+func (r *resolver) routePathHints(hints []string) {
+	if len(hints) == 0 {
+		return
+	}
+	entries, err := os.ReadDir(filepath.Join(r.root, "docs", "references", "rules"))
+	if err != nil {
+		return
+	}
+	names := make([]string, 0, len(entries))
+	for _, entry := range entries {
+		if !entry.IsDir() && strings.HasSuffix(entry.Name(), ".md") {
+			names = append(names, entry.Name())
+		}
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		slug := strings.TrimSuffix(name, ".md")
+		relativePath := filepath.ToSlash(filepath.Join("docs", "references", "rules", name))
+		header, ok := r.readRulesetHeader(relativePath, slug)
+		if !ok || header.Status == document.ReferenceStatusStale || header.ReadPolicyDefault == document.ReferenceReadPolicySkip {
+			continue
+		}
+		glob, hint, matched := matchHints(header.Paths, hints)
+		if !matched {
+			continue
+		}
+		rule := WorkflowRule{Slug: slug, Required: header.ReadPolicyDefault == document.ReferenceReadPolicyMust}
+		r.source = relativePath + " paths"
+		r.addRule(rule, pathHintReason(glob, hint))
+	}
+}
+
+func (r *resolver) readRulesetHeader(relativePath, slug string) (rulesetHeader, bool) {
+	header, err := parseRulesetHeader(r.root, relativePath, slug)
+	if err != nil {
+		if header.Kind != "" {
+			r.addDiagnostic("warning", "invalid-rule-paths", relativePath, err.Error())
+		}
+		return rulesetHeader{}, false
+	}
+	return header, true
+}
+
+// parseRulesetHeader returns a partially populated header alongside an error
+// only when the ruleset identity is valid but its paths globs are not.
+func parseRulesetHeader(root, relativePath, slug string) (rulesetHeader, error) {
+	_, data, err := secureRead(root, relativePath)
+	if err != nil {
+		return rulesetHeader{}, err
+	}
+	frontMatter, err := markdownFrontMatter(data)
+	if err != nil {
+		return rulesetHeader{}, err
+	}
+	var header rulesetHeader
+	if err := yaml.Unmarshal(frontMatter, &header); err != nil {
+		return rulesetHeader{}, err
+	}
+	if header.Kind != "ruleset" || header.Slug != slug {
+		return rulesetHeader{}, fmt.Errorf("artifact identity is kind=%q slug=%q, want kind=%q slug=%q", header.Kind, header.Slug, "ruleset", slug)
+	}
+	for _, glob := range header.Paths {
+		if err := ValidatePathGlob(glob); err != nil {
+			return header, err
+		}
+	}
+	return header, nil
+}
@@ -0,0 +1,68 @@
+package context
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestMatchPathGlobSupportsDoubleStar(t *testing.T) {
+	for _, test := range []struct {
+		pattern, value string
+		want           bool
+	}{
+		{"pkg/cli/**", "pkg/cli/foo.go", true},
+		{"pkg/cli/**", "pkg/cli/nested/foo.go", true},
+		{"pkg/cli/**", "pkg/other/foo.go", false},
+		{"**/*.tsx", "web/src/App.tsx", true},
+		{"docs/*.md", "docs/nested/a.md", false},
+		{"internal/**/types.go", "internal/types.go", true},
+	} {
+		if got := matchPathGlob(test.pattern, test.value); got != test.want {
+			t.Errorf("matchPathGlob(%q, %q) = %v, want %v", test.pattern, test.value, got, test.want)
+		}
+	}
+	for _, invalid := range []string{"", "/abs/**", "../outside/**", "pkg/[/**"} {
+		if ValidatePathGlob(invalid) == nil {
+			t.Errorf("ValidatePathGlob(%q) accepted an invalid glob", invalid)
+		}
+	}
+}
+
+func TestResolveRoutesPathHintsToRulesets(t *testing.T) {
+	root := contextProject(t)
+	writeContextFile(t, root, "docs/references/workflows/check.md", workflowDocument("check", nil, nil, nil))
+	writeContextFile(t, root, "docs/references/rules/cli-rules.md", "---\nkind: ruleset\nslug: cli-rules\nstatus: active\nread_policy_default: must\npaths:\n  - pkg/cli/**\n---\n# Rule\n")
+	writeContextFile(t, root, "docs/references/rules/web-rules.md", "---\nkind: ruleset\nslug: web-rules\nstatus: active\nread_policy_default: conditional\npaths:\n  - web/**\n---\n# Rule\n")
+	writeContextFile(t, root, "docs/references/rules/old-rules.md", "---\nkind: ruleset\nslug: old-rules\nstatus: stale\nread_policy_default: must\npaths:\n  - pkg/**\n---\n# Rule\n")
+	writeContextFile(t, root, "pkg/cli/foo.go", "package cli\n")
+
+	contract := Resolve(root, Request{Workflow: "check", Paths: []string{"pkg/cli/foo.go"}})
+	if contract.Blocked {
+		t.Fatalf("path routing blocked: %#v", contract.Diagnostics)
+	}
+	rule := evidenceByPath(t, contract, "docs/references/rules/cli-rules.md")
+	if !rule.Required || rule.Kind != "rule" || !strings.Contains(strings.Join(rule.Reasons, " "), "path hint pkg/cli/** matched pkg/cli/foo.go") {
+		t.Fatalf("routed rule = %#v", rule)
+	}
+	for _, unrelated := range []string{"docs/references/rules/web-rules.md", "docs/references/rules/old-rules.md"} {
+		if evidencePathPresent(contract, unrelated) {
+			t.Fatalf("unmatched or stale ruleset %s was routed", unrelated)
+		}
+	}
+}
+
+func TestResolveGatesWorkflowRulesOnPathHints(t *testing.T) {
+	root := contextProject(t)
+	document := "---\nkind: workflow\nslug: check\ndescription: test\nrules:\n  - slug: frontend\n    required: true\n    paths:\n      - web/**\n---\n# Check\n"
+	writeContextFile(t, root, "docs/references/workflows/check.md", document)
+	writeContextFile(t, root, "docs/references/rules/frontend.md", rulesetDocument("frontend"))
+	writeContextFile(t, root, "web/app.tsx", "app\n")
+
+	if contract := Resolve(root, Request{Workflow: "check"}); evidencePathPresent(contract, "docs/references/rules/frontend.md") {
+		t.Fatalf("path-gated rule selected without a matching hint: %#v", contract.Evidence)
+	}
+	contract := Resolve(root, Request{Workflow: "check", Paths: []string{"web/app.tsx"}})
+	if !evidencePathPresent(contract, "docs/references/rules/frontend.md") || contract.Blocked {
+		t.Fatalf("path-gated rule not selected: %#v", contract)
+	}
+}
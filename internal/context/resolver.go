@@ -13,20 +13,24 @@ import (
 	"github.com/jamesonstone/kit/v3/internal/config"
 	"github.com/jamesonstone/kit/v3/internal/document"
 	"github.com/jamesonstone/kit/v3/internal/feature"
+	"github.com/jamesonstone/kit/v3/internal/verify"
 )
 
 type resolver struct {
 	root          string
 	contract      Contract
 	evidenceIndex map[string]int
+	wholeEvidence map[string]bool
 	workflowState map[string]string
+	facts         conditionFacts
+	currentRoot   string
+	requirements  map[string]map[bool][]string
+	source        string
 }
 
 func Resolve(projectRoot string, request Request) Contract {
-	request.Workflow = strings.TrimSpace(request.Workflow)
-	if request.Workflow == "" {
-		request.Workflow = "implementation-delivery"
-	}
+	request.Workflows = normalizeWorkflows(request)
+	request.Workflow = request.Workflows[0]
 	request.Feature = strings.TrimSpace(request.Feature)
 	request.Paths = normalizeHints(request.Paths)
 	contract := Contract{
@@ -34,31 +38,32 @@ func Resolve(projectRoot string, request Request) Contract {
 		Request:       request,
 		Workflows:     []SelectedWorkflow{},
 		Evidence:      []EvidenceItem{},
+		Skipped:       []SkippedEvidence{},
 		Diagnostics:   []Diagnostic{},
 		NextActions:   []string{},
 	}
-	root, err := filepath.Abs(projectRoot)
-	if err != nil {
-		contract.Diagnostics = append(contract.Diagnostics, Diagnostic{Level: "error", Code: "invalid-project-root", Message: err.Error()})
-		return finalize(contract)
-	}
-	root, err = filepath.EvalSymlinks(root)
+	root, err := canonicalRoot(projectRoot)
 	if err != nil {
 		contract.Diagnostics = append(contract.Diagnostics, Diagnostic{Level: "error", Code: "invalid-project-root", Message: err.Error()})
 		return finalize(contract)
 	}
-	r := &resolver{root: filepath.Clean(root), contract: contract, evidenceIndex: map[string]int{}, workflowState: map[string]string{}}
+	r := &resolver{root: root, contract: contract, evidenceIndex: map[string]int{}, wholeEvidence: map[string]bool{}, workflowState: map[string]string{}, requirements: map[string]map[bool][]string{}}
 	if !config.Exists(r.root) {
 		r.addDiagnostic("error", "project-not-initialized", config.ConfigFileName, "Kit project configuration is missing")
 		return finalize(r.contract)
 	}
-	r.resolveWorkflow(request.Workflow, nil)
+	r.loadConditionFacts(request)
+	r.resolveWorkflows(request.Workflows)
 	if request.Feature != "" {
 		r.resolveFeature(request.Feature)
 	}
+	r.routePathHints(request.Paths)
+	r.source = "--path"
 	for _, hint := range request.Paths {
 		r.addEvidence("path", hint, true, "explicit path hint")
 	}
+	r.source = "--budget"
+	r.applyBudget()
 	return finalize(r.contract)
 }
 
@@ -74,6 +79,11 @@ func (r *resolver) resolveWorkflow(slug string, stack []string) {
 	if path == "" {
 		path = filepath.ToSlash(filepath.Join("docs", "references", "workflows", slug+".md"))
 	}
+	visit := "requested workflow " + slug
+	if len(stack) > 0 {
+		visit = fmt.Sprintf("workflow %s, dependency of %s", slug, stack[len(stack)-1])
+	}
+	r.trace("visit-workflow", "workflow", path, visit)
 	r.addEvidence("workflow", path, true, "selected workflow "+slug)
 	if err != nil {
 		code := "invalid-workflow"
@@ -84,7 +94,8 @@ func (r *resolver) resolveWorkflow(slug string, stack []string) {
 		r.workflowState[slug] = "done"
 		return
 	}
-	for _, dependency := range manifest.Dependencies {
+	for index, dependency := range manifest.Dependencies {
+		r.source = manifestSource(path, lineAt(manifest.dependencyLines, index))
 		r.resolveWorkflow(dependency, append(stack, slug))
 	}
 	r.contract.Workflows = append(r.contract.Workflows, SelectedWorkflow{
@@ -92,17 +103,33 @@ func (r *resolver) resolveWorkflow(slug string, stack []string) {
 		Dependencies: append([]string{}, manifest.Dependencies...), Digest: digest(data),
 	})
 	for _, evidence := range manifest.Evidence {
-		r.addEvidence(evidence.Kind, evidence.Path, evidence.Required, "workflow "+slug)
+		r.source = manifestSource(path, evidence.line)
+		if condition := r.unmetCondition(evidence.When); condition != "" {
+			r.skip(evidence.Kind, evidence.Path, slug, condition)
+			continue
+		}
+		r.addEvidenceSet(evidence.Kind, evidence.Path, evidence.Required, "workflow "+slug, "", "")
 	}
 	for _, rule := range manifest.Rules {
-		r.addRule(rule, slug)
+		r.source = manifestSource(path, rule.line)
+		if condition := r.unmetCondition(rule.When); condition != "" {
+			r.skip("rule", filepath.ToSlash(filepath.Join("docs", "references", "rules", rule.Slug+".md")), slug, condition)
+			continue
+		}
+		if len(rule.Paths) == 0 {
+			r.addRule(rule, "workflow "+slug+" rule "+rule.Slug)
+		} else if glob, hint, ok := matchHints(rule.Paths, r.contract.Request.Paths); ok {
+			r.addRule(rule, "workflow "+slug+" "+pathHintReason(glob, hint))
+		} else {
+			r.trace("skip-rule", "rule", filepath.ToSlash(filepath.Join("docs", "references", "rules", rule.Slug+".md")), "no path hint matches the rule paths globs")
+		}
 	}
 	r.workflowState[slug] = "done"
 }
 
-func (r *resolver) addRule(rule WorkflowRule, workflow string) {
+func (r *resolver) addRule(rule WorkflowRule, reason string) {
 	path := filepath.ToSlash(filepath.Join("docs", "references", "rules", rule.Slug+".md"))
-	data := r.addEvidence("rule", path, rule.Required, "workflow "+workflow+" rule "+rule.Slug)
+	data := r.addEvidence("rule", path, rule.Required, reason)
 	if len(data) == 0 {
 		return
 	}
@@ -116,6 +143,7 @@ func (r *resolver) addRule(rule WorkflowRule, workflow string) {
 }
 
 func (r *resolver) resolveFeature(reference string) {
+	r.source = "--feature"
 	cfg, err := config.Load(r.root)
 	if err != nil {
 		r.addDiagnostic("error", "invalid-project-config", config.ConfigFileName, err.Error())
@@ -129,6 +157,9 @@ func (r *resolver) resolveFeature(reference string) {
 	specPath := filepath.ToSlash(filepath.Join(cfg.SpecsDir, feat.DirName, "SPEC.md"))
 	data := r.addEvidence("feature-spec", specPath, true, "selected feature "+feat.DirName)
 	r.addEvidence("project-index", "docs/PROJECT_PROGRESS_SUMMARY.md", false, "feature discovery index")
+	if latestRun := verify.LatestRunPath(feat.DirName); r.isFile(latestRun) {
+		r.addEvidence("validation-run", latestRun, false, "latest kit check --run-validation result for "+feat.DirName)
+	}
 	if len(data) == 0 {
 		return
 	}
@@ -145,6 +176,7 @@ func (r *resolver) resolveFeature(reference string) {
 	if doc.Metadata == nil {
 		return
 	}
+	r.source = specPath
 	for _, relationship := range doc.Metadata.Relationships {
 		r.resolveHistoricalSpec(cfg, relationship, specPath)
 	}
@@ -164,11 +196,16 @@ func (r *resolver) resolveHistoricalSpec(cfg *config.Config, relationship docume
 }
 
 func (r *resolver) resolveFeatureReference(reference document.MetadataReference, source string) {
-	if reference.ReadPolicy == document.ReferenceReadPolicySkip || reference.Status == document.ReferenceStatusStale {
-		return
-	}
 	target := strings.Trim(strings.TrimSpace(reference.Target), "`\"'")
-	if !isLocalReference(target) {
+	switch {
+	case reference.ReadPolicy == document.ReferenceReadPolicySkip:
+		r.trace("skip-reference", reference.Type, target, fmt.Sprintf("feature reference %s has read_policy: skip", reference.Name))
+		return
+	case reference.Status == document.ReferenceStatusStale:
+		r.trace("skip-reference", reference.Type, target, fmt.Sprintf("feature reference %s has status: stale", reference.Name))
+		return
+	case !isLocalReference(target):
+		r.trace("skip-reference", reference.Type, target, fmt.Sprintf("feature reference %s is not a local path", reference.Name))
 		return
 	}
 	required := reference.ReadPolicy == document.ReferenceReadPolicyMust
@@ -176,11 +213,12 @@ func (r *resolver) resolveFeatureReference(reference document.MetadataReference,
 	if kind == "" {
 		kind = "reference"
 	}
-	r.addEvidence(kind, target, required, fmt.Sprintf("feature reference %s from %s", reference.Name, source))
+	reason := fmt.Sprintf("feature reference %s from %s", reference.Name, source)
+	r.addEvidenceSet(kind, target, required, reason, strings.TrimSpace(reference.SelectorType), strings.TrimSpace(reference.Selector))
 }
 
 func isLocalReference(target string) bool {
-	if target == "" || strings.Contains(target, "|") || strings.Contains(target, "*") {
+	if target == "" || strings.Contains(target, "|") {
 		return false
 	}
 	lower := strings.ToLower(target)
@@ -190,6 +228,18 @@ func isLocalReference(target string) bool {
 	return strings.Contains(target, "/") || strings.HasSuffix(target, ".md") || filepath.IsAbs(target)
 }
 
+func canonicalRoot(projectRoot string) (string, error) {
+	root, err := filepath.Abs(projectRoot)
+	if err != nil {
+		return "", err
+	}
+	root, err = filepath.EvalSymlinks(root)
+	if err != nil {
+		return "", err
+	}
+	return filepath.Clean(root), nil
+}
+
 func normalizeHints(paths []string) []string {
 	seen := map[string]bool{}
 	var normalized []string
@@ -0,0 +1,194 @@
+package context
+
+import (
+	"bytes"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/jamesonstone/kit/v3/internal/document"
+	"github.com/jamesonstone/kit/v3/internal/feature"
+)
+
+func (r *resolver) attachSpan(item *EvidenceItem, data []byte, required bool, selectorType, selector string) {
+	if item.State != "present" {
+		return
+	}
+	if selector == "" || (selectorType != document.ReferenceSelectorTypeHeading && selectorType != document.ReferenceSelectorTypeSymbol) {
+		r.wholeEvidence[item.Path] = true
+		item.Spans = nil
+		return
+	}
+	if r.wholeEvidence[item.Path] {
+		return
+	}
+	span, ok, present := locateSpan(data, selectorType, selector)
+	if !ok {
+		// A selector kit check accepts still names loadable evidence, so only
+		// a selector absent from the file blocks a required reference.
+		level, message := "warning", "%s selector %q has no span; load the whole file"
+		if !present {
+			message = "%s selector %q not found; load the whole file"
+			if required {
+				level = "error"
+			}
+		}
+		r.addDiagnostic(level, "unresolved-selector", item.Path, fmt.Sprintf(message, selectorType, selector))
+		r.wholeEvidence[item.Path] = true
+		item.Spans = nil
+		return
+	}
+	for _, existing := range item.Spans {
+		if existing.SelectorType == span.SelectorType && existing.Selector == span.Selector {
+			return
+		}
+	}
+	item.Spans = append(item.Spans, span)
+	sort.SliceStable(item.Spans, func(i, j int) bool { return item.Spans[i].StartByte < item.Spans[j].StartByte })
+}
+
+// locateSpan reports the span for selector and, when there is none, whether
+// the selector still matches the file the way kit check matches it.
+func locateSpan(data []byte, selectorType, selector string) (EvidenceSpan, bool, bool) {
+	var start, end int
+	var ok, present bool
+	switch selectorType {
+	case document.ReferenceSelectorTypeHeading:
+		start, end, ok, present = headingSpan(data, selector)
+	case document.ReferenceSelectorTypeSymbol:
+		start, end, ok, present = symbolSpan(data, selector)
+	}
+	if !ok {
+		return EvidenceSpan{}, false, present
+	}
+	return EvidenceSpan{
+		SelectorType: selectorType,
+		Selector:     selector,
+		StartLine:    bytes.Count(data[:start], []byte("\n")) + 1,
+		EndLine:      bytes.Count(bytes.TrimSuffix(data[:end], []byte("\n")), []byte("\n")) + 1,
+		StartByte:    start,
+		EndByte:      end,
+		Digest:       digest(data[start:end]),
+	}, true, true
+}
+
+type sourceLine struct {
+	start int
+	end   int
+	text  string
+}
+
+func splitSourceLines(data []byte) []sourceLine {
+	var lines []sourceLine
+	for offset := 0; offset < len(data); {
+		next := bytes.IndexByte(data[offset:], '\n')
+		end := len(data)
+		if next >= 0 {
+			end = offset + next + 1
+		}
+		lines = append(lines, sourceLine{start: offset, end: end, text: strings.TrimRight(string(data[offset:end]), "\r\n")})
+		offset = end
+	}
+	return lines
+}
+
+func headingSpan(data []byte, selector string) (int, int, bool, bool) {
+	want := feature.NormalizeReferenceSelector(selector)
+	lines := splitSourceLines(data)
+	fenced, present := false, false
+	start, level := -1, 0
+	for _, line := range lines {
+		trimmed := strings.TrimSpace(line.text)
+		if strings.HasPrefix(trimmed, "```") || strings.HasPrefix(trimmed, "~~~") {
+			fenced = !fenced
+			continue
+		}
+		lineLevel, heading := feature.ReferenceHeading(trimmed)
+		if lineLevel == 0 {
+			continue
+		}
+		matches := feature.NormalizeReferenceSelector(heading) == want
+		present = present || matches
+		if fenced {
+			continue
+		}
+		if start >= 0 && lineLevel <= level {
+			return start, line.start, true, true
+		}
+		if start < 0 && matches {
+			start, level = line.start, lineLevel
+		}
+	}
+	if start < 0 {
+		return 0, 0, false, present
+	}
+	return start, len(data), true, true
+}
+
+func symbolSpan(data []byte, selector string) (int, int, bool, bool) {
+	offset, declared, ok := feature.LocateReferenceSymbol(string(data), selector)
+	if !declared {
+		return 0, 0, false, ok
+	}
+	lines := splitSourceLines(data)
+	index := 0
+	for index < len(lines)-1 && lines[index].end <= offset {
+		index++
+	}
+	first := index
+	for first > 0 && strings.HasPrefix(strings.TrimSpace(lines[first-1].text), "//") {
+		first--
+	}
+	return lines[first].start, declarationEnd(data, offset), true, true
+}
+
+// declarationEnd returns the end of the first line, from offset on, where
+// every bracket opened by the declaration is closed again, skipping brackets
+// inside comments, strings, and runes.
+func declarationEnd(data []byte, offset int) int {
+	depth := 0
+	for index := offset; index < len(data); index++ {
+		switch data[index] {
+		case '(', '{', '[':
+			depth++
+		case ')', '}', ']':
+			depth--
+		case '"', '\'', '`':
+			index = literalEnd(data, index)
+		case '/':
+			if index+1 < len(data) && data[index+1] == '/' {
+				for index+1 < len(data) && data[index+1] != '\n' {
+					index++
+				}
+			} else if index+1 < len(data) && data[index+1] == '*' {
+				if closing := bytes.Index(data[index+2:], []byte("*/")); closing >= 0 {
+					index += closing + 3
+				} else {
+					index = len(data) - 1
+				}
+			}
+		case '\n':
+			if depth <= 0 {
+				return index + 1
+			}
+		}
+	}
+	return len(data)
+}
+
+// literalEnd returns the index of the quote closing the literal that opens at
+// index, or the last byte when the literal is never closed.
+func literalEnd(data []byte, index int) int {
+	quote := data[index]
+	for next := index + 1; next < len(data); next++ {
+		switch {
+		case data[next] == quote:
+			return next
+		case data[next] == '\\' && quote != '`':
+			next++
+		case data[next] == '\n' && quote != '`':
+			return next - 1
+		}
+	}
+	return len(data) - 1
+}
@@ -0,0 +1,176 @@
+package context
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestResolveFeatureReferenceSelectorsProduceSpans(t *testing.T) {
+	root := contextProject(t)
+	writeContextFile(t, root, "docs/references/workflows/check.md", workflowDocument("check", nil, nil, nil))
+	writeContextFile(t, root, "docs/references/guide.md", "# Guide\n\nIntro.\n\n## Setup\n\nInstall.\n\n### Detail\n\nMore.\n\n## Usage\n\nRun.\n")
+	writeContextFile(t, root, "pkg/source.go", "package pkg\n\n// Helper does work.\nfunc Helper() {\n\treturn\n}\n\nfunc Other() {}\n")
+	references := `references:
+  - id: guide
+    name: Guide setup
+    type: doc
+    target: docs/references/guide.md
+    selector_type: heading
+    selector: setup
+    relation: guides
+    read_policy: must
+    used_for: test
+    status: active
+  - id: helper
+    name: Helper
+    type: code
+    target: pkg/source.go
+    selector_type: symbol
+    selector: Helper
+    relation: implements
+    read_policy: conditional
+    used_for: test
+    status: active`
+	writeContextFile(t, root, "docs/specs/0001-alpha/SPEC.md", v3Spec("0001", "alpha", "0001-alpha", "", references))
+
+	contract := Resolve(root, Request{Workflow: "check", Feature: "alpha"})
+	if contract.Blocked {
+		t.Fatalf("selector resolution blocked: %#v", contract.Diagnostics)
+	}
+	guide := evidenceByPath(t, contract, "docs/references/guide.md")
+	if len(guide.Spans) != 1 {
+		t.Fatalf("guide spans = %#v", guide.Spans)
+	}
+	span := guide.Spans[0]
+	if span.StartLine != 5 || span.EndLine != 12 || !strings.HasPrefix(span.Digest, "sha256:") || span.Digest == guide.Digest {
+		t.Fatalf("heading span = %#v", span)
+	}
+	source := evidenceByPath(t, contract, "pkg/source.go")
+	if len(source.Spans) != 1 || source.Spans[0].StartLine != 3 || source.Spans[0].EndLine != 6 {
+		t.Fatalf("symbol span = %#v", source.Spans)
+	}
+}
+
+func TestResolveSelectorFallsBackToWholeFile(t *testing.T) {
+	root := contextProject(t)
+	writeContextFile(t, root, "docs/references/workflows/check.md", workflowDocument("check", nil, nil, []string{"docs/references/guide.md"}))
+	writeContextFile(t, root, "docs/references/guide.md", "# Guide\n\n## Setup\n")
+	writeContextFile(t, root, "docs/references/other.md", "# Other\n")
+	references := `references:
+  - id: guide
+    name: Guide
+    type: doc
+    target: docs/references/guide.md
+    selector_type: heading
+    selector: Setup
+    relation: guides
+    read_policy: conditional
+    used_for: test
+    status: active
+  - id: other
+    name: Other
+    type: doc
+    target: docs/references/other.md
+    selector_type: heading
+    selector: Absent
+    relation: guides
+    read_policy: conditional
+    used_for: test
+    status: active`
+	writeContextFile(t, root, "docs/specs/0001-alpha/SPEC.md", v3Spec("0001", "alpha", "0001-alpha", "", references))
+
+	contract := Resolve(root, Request{Workflow: "check", Feature: "alpha"})
+	if spans := evidenceByPath(t, contract, "docs/references/guide.md").Spans; len(spans) != 0 {
+		t.Fatalf("workflow whole-file evidence kept spans: %#v", spans)
+	}
+	if spans := evidenceByPath(t, contract, "docs/references/other.md").Spans; len(spans) != 0 {
+		t.Fatalf("unresolved selector kept spans: %#v", spans)
+	}
+	if contract.Blocked || !diagnosticCodePresent(contract, "unresolved-selector") {
+		t.Fatalf("unresolved optional selector diagnostics = %#v", contract.Diagnostics)
+	}
+}
+
+func TestResolveSymbolSelectorsMatchCheckDeclarations(t *testing.T) {
+	root := contextProject(t)
+	writeContextFile(t, root, "docs/references/workflows/check.md", workflowDocument("check", nil, nil, nil))
+	writeContextFile(t, root, "pkg/modes.go", "package pkg\n\nconst (\n\tModeA = iota\n\tModeB\n)\n\nfunc Other() {}\n")
+	writeContextFile(t, root, "pkg/wrapped.go", "package pkg\n\n// Wrapped has a long signature.\nfunc Wrapped(\n\tvalue int,\n) error {\n\tif value > 0 {\n\t\treturn nil // }\n\t}\n\treturn nil\n}\n\nfunc After() {}\n")
+	writeContextFile(t, root, "pkg/nested.go", "package pkg\n\nfunc Outer() {\n\ttype Inner struct {\n\t\tName string\n\t}\n\t_ = Inner{}\n}\n")
+	writeContextFile(t, root, "pkg/mention.go", "package pkg\n\n// Mentioned is only named in a comment.\n")
+	references := `references:
+  - id: mode
+    name: Mode
+    type: code
+    target: pkg/modes.go
+    selector_type: symbol
+    selector: ModeA
+    relation: implements
+    read_policy: must
+    used_for: test
+    status: active
+  - id: wrapped
+    name: Wrapped
+    type: code
+    target: pkg/wrapped.go
+    selector_type: symbol
+    selector: Wrapped
+    relation: implements
+    read_policy: must
+    used_for: test
+    status: active
+  - id: inner
+    name: Inner
+    type: code
+    target: pkg/nested.go
+    selector_type: symbol
+    selector: Inner
+    relation: implements
+    read_policy: must
+    used_for: test
+    status: active
+  - id: mentioned
+    name: Mentioned
+    type: code
+    target: pkg/mention.go
+    selector_type: symbol
+    selector: Mentioned
+    relation: implements
+    read_policy: must
+    used_for: test
+    status: active`
+	writeContextFile(t, root, "docs/specs/0001-alpha/SPEC.md", v3Spec("0001", "alpha", "0001-alpha", "", references))
+
+	contract := Resolve(root, Request{Workflow: "check", Feature: "alpha"})
+	if contract.Blocked {
+		t.Fatalf("declared symbols blocked resolution: %#v", contract.Diagnostics)
+	}
+	for path, lines := range map[string][2]int{"pkg/modes.go": {3, 6}, "pkg/wrapped.go": {3, 11}, "pkg/nested.go": {4, 6}} {
+		spans := evidenceByPath(t, contract, path).Spans
+		if len(spans) != 1 || spans[0].StartLine != lines[0] || spans[0].EndLine != lines[1] {
+			t.Fatalf("%s spans = %#v, want lines %v", path, spans, lines)
+		}
+	}
+	if spans := evidenceByPath(t, contract, "pkg/mention.go").Spans; len(spans) != 0 {
+		t.Fatalf("plain-text symbol kept spans: %#v", spans)
+	}
+	for _, diagnostic := range contract.Diagnostics {
+		if diagnostic.Code == "unresolved-selector" && (diagnostic.Path != "pkg/mention.go" || diagnostic.Level != "warning") {
+			t.Fatalf("unexpected selector diagnostic: %#v", diagnostic)
+		}
+	}
+	if !diagnosticCodePresent(contract, "unresolved-selector") {
+		t.Fatalf("plain-text symbol was not reported: %#v", contract.Diagnostics)
+	}
+}
+
+func evidenceByPath(t *testing.T, contract Contract, path string) EvidenceItem {
+	t.Helper()
+	for _, item := range contract.Evidence {
+		if item.Path == path {
+			return item
+		}
+	}
+	t.Fatalf("missing evidence %s: %#v", path, contract.Evidence)
+	return EvidenceItem{}
+}
@@ -0,0 +1,80 @@
+package context
+
+import (
+	"fmt"
+
+	"gopkg.in/yaml.v3"
+)
+
+// frontMatterLineOffset converts a front matter node line to a file line: the
+// opening `---` delimiter occupies line 1.
+const frontMatterLineOffset = 1
+
+// annotateManifestLines records the file line of every dependency, rule, and
+// evidence entry so an explain trace can cite the manifest line that selected
+// each item. Unparseable front matter leaves the lines unset.
+func annotateManifestLines(frontMatter []byte, manifest *WorkflowManifest) {
+	var document yaml.Node
+	if err := yaml.Unmarshal(frontMatter, &document); err != nil || len(document.Content) == 0 {
+		return
+	}
+	mapping := document.Content[0]
+	if mapping.Kind != yaml.MappingNode {
+		return
+	}
+	for index := 0; index+1 < len(mapping.Content); index += 2 {
+		lines := sequenceLines(mapping.Content[index+1])
+		switch mapping.Content[index].Value {
+		case "dependencies":
+			manifest.dependencyLines = lines
+		case "rules":
+			for item := range manifest.Rules {
+				if item < len(lines) {
+					manifest.Rules[item].line = lines[item]
+				}
+			}
+		case "evidence":
+			for item := range manifest.Evidence {
+				if item < len(lines) {
+					manifest.Evidence[item].line = lines[item]
+				}
+			}
+		}
+	}
+}
+
+func sequenceLines(node *yaml.Node) []int {
+	if node.Kind != yaml.SequenceNode {
+		return nil
+	}
+	lines := make([]int, len(node.Content))
+	for index, item := range node.Content {
+		lines[index] = item.Line + frontMatterLineOffset
+	}
+	return lines
+}
+
+func manifestSource(path string, line int) string {
+	if line <= 0 {
+		return path
+	}
+	return fmt.Sprintf("%s:%d", path, line)
+}
+
+func lineAt(lines []int, index int) int {
+	if index < len(lines) {
+		return lines[index]
+	}
+	return 0
+}
+
+// trace appends a resolution step when the request asked for an explanation.
+// Each step cites the resolver's current source.
+func (r *resolver) trace(action, kind, path, detail string) {
+	if !r.contract.Request.Explain {
+		return
+	}
+	r.contract.Trace = append(r.contract.Trace, TraceStep{
+		Step: len(r.contract.Trace) + 1, Action: action, Kind: kind, Path: path, Source: r.source, Detail: detail,
+	})
+}
@@ -0,0 +1,72 @@
+package context
+
+import "testing"
+
+func TestResolveExplainTracesVisitsSourcesPromotionsAndSkippedReferences(t *testing.T) {
+	root := contextProject(t)
+	writeContextFile(t, root, "docs/references/workflows/base.md", `---
+kind: workflow
+slug: base
+description: test
+dependencies: []
+rules: []
+evidence:
+  - kind: reference
+    path: shared.md
+    required: false
+---
+# Base
+`)
+	writeContextFile(t, root, "docs/references/workflows/main.md", workflowDocument("main", []string{"base"}, nil, []string{"shared.md"}))
+	writeContextFile(t, root, "shared.md", "shared\n")
+	references := `references:
+  - id: old
+    name: Old design
+    type: doc
+    target: docs/old.md
+    relation: informs
+    read_policy: conditional
+    used_for: history
+    status: stale
+  - id: noise
+    name: Noise
+    type: doc
+    target: docs/noise.md
+    relation: informs
+    read_policy: skip
+    used_for: nothing
+    status: active`
+	writeContextFile(t, root, "docs/specs/0001-alpha/SPEC.md", v3Spec("0001", "alpha", "0001-alpha", "", references))
+
+	if plain := Resolve(root, Request{Workflow: "main", Feature: "alpha"}); len(plain.Trace) != 0 {
+		t.Fatalf("trace recorded without explain: %#v", plain.Trace)
+	}
+	contract := Resolve(root, Request{Workflow: "main", Feature: "alpha", Explain: true})
+	if contract.Blocked {
+		t.Fatalf("resolution blocked: %#v", contract.Diagnostics)
+	}
+	want := []TraceStep{
+		{Action: "visit-workflow", Path: "docs/references/workflows/main.md", Source: "--workflow"},
+		{Action: "visit-workflow", Path: "docs/references/workflows/base.md", Source: "docs/references/workflows/main.md:6"},
+		{Action: "add", Path: "shared.md", Source: "docs/references/workflows/base.md:8"},
+		{Action: "promote", Path: "shared.md", Source: "docs/references/workflows/main.md:9"},
+		{Action: "skip-reference", Path: "docs/old.md", Source: "docs/specs/0001-alpha/SPEC.md", Detail: "feature reference Old design has status: stale"},
+		{Action: "skip-reference", Path: "docs/noise.md", Source: "docs/specs/0001-alpha/SPEC.md", Detail: "feature reference Noise has read_policy: skip"},
+	}
+	next := 0
+	for index, step := range contract.Trace {
+		if step.Step != index+1 {
+			t.Fatalf("trace step numbering = %#v", contract.Trace)
+		}
+		if next == len(want) {
+			break
+		}
+		expected := want[next]
+		if step.Action == expected.Action && step.Path == expected.Path && step.Source == expected.Source && (expected.Detail == "" || step.Detail == expected.Detail) {
+			next++
+		}
+	}
+	if next != len(want) {
+		t.Fatalf("trace is missing %#v in order:\n%#v", want[next], contract.Trace)
+	}
+}
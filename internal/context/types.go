@@ -3,9 +3,12 @@ package context
 const SchemaVersion = "kit.context/v1"
 
 type Request struct {
-	Workflow string   `json:"workflow"`
-	Feature  string   `json:"feature,omitempty"`
-	Paths    []string `json:"paths,omitempty"`
+	Workflow  string   `json:"workflow"`
+	Workflows []string `json:"workflows,omitempty"`
+	Feature   string   `json:"feature,omitempty"`
+	Paths     []string `json:"paths,omitempty"`
+	Budget    int      `json:"budget,omitempty"`
+	Explain   bool     `json:"explain,omitempty"`
 }
 
 type Contract struct {
@@ -13,9 +16,12 @@ type Contract struct {
 	Request       Request            `json:"request"`
 	Workflows     []SelectedWorkflow `json:"workflows"`
 	Evidence      []EvidenceItem     `json:"evidence"`
+	Skipped       []SkippedEvidence  `json:"skipped"`
+	Tokens        int                `json:"estimated_tokens"`
 	Blocked       bool               `json:"blocked"`
 	Diagnostics   []Diagnostic       `json:"diagnostics"`
 	NextActions   []string           `json:"next_actions"`
+	Trace         []TraceStep        `json:"trace,omitempty"`
 }
 
 type SelectedWorkflow struct {
@@ -27,12 +33,42 @@ type SelectedWorkflow struct {
 }
 
 type EvidenceItem struct {
-	Kind     string   `json:"kind"`
-	Path     string   `json:"path"`
-	Required bool     `json:"required"`
-	State    string   `json:"state"`
-	Digest   string   `json:"digest,omitempty"`
-	Reasons  []string `json:"reasons"`
+	Kind     string         `json:"kind"`
+	Path     string         `json:"path"`
+	Required bool           `json:"required"`
+	State    string         `json:"state"`
+	Digest   string         `json:"digest,omitempty"`
+	Spans    []EvidenceSpan `json:"spans,omitempty"`
+	Tokens   int            `json:"estimated_tokens"`
+	Reasons  []string       `json:"reasons"`
+}
+
+type EvidenceSpan struct {
+	SelectorType string `json:"selector_type"`
+	Selector     string `json:"selector"`
+	StartLine    int    `json:"start_line"`
+	EndLine      int    `json:"end_line"`
+	StartByte    int    `json:"start_byte"`
+	EndByte      int    `json:"end_byte"`
+	Digest       string `json:"digest"`
+}
+
+type SkippedEvidence struct {
+	Kind      string `json:"kind"`
+	Path      string `json:"path"`
+	Workflow  string `json:"workflow"`
+	Condition string `json:"condition"`
+}
+
+// TraceStep records one resolution decision when Request.Explain is set.
+// Source names the manifest line, spec, or flag that caused the step.
+type TraceStep struct {
+	Step   int    `json:"step"`
+	Action string `json:"action"`
+	Kind   string `json:"kind,omitempty"`
+	Path   string `json:"path,omitempty"`
+	Source string `json:"source,omitempty"`
+	Detail string `json:"detail,omitempty"`
 }
 
 type Diagnostic struct {
@@ -49,20 +85,44 @@ type WorkflowManifest struct {
 	Dependencies []string           `yaml:"dependencies"`
 	Rules        []WorkflowRule     `yaml:"rules"`
 	Evidence     []WorkflowEvidence `yaml:"evidence"`
+
+	dependencyLines []int
 }
 
 type WorkflowRule struct {
-	Slug     string `yaml:"slug"`
-	Required bool   `yaml:"required"`
+	Slug     string             `yaml:"slug"`
+	Required bool               `yaml:"required"`
+	Paths    []string           `yaml:"paths"`
+	When     *WorkflowCondition `yaml:"when"`
+
+	line int
 }
 
 type WorkflowEvidence struct {
-	Kind     string `yaml:"kind"`
-	Path     string `yaml:"path"`
-	Required bool   `yaml:"required"`
+	Kind     string             `yaml:"kind"`
+	Path     string             `yaml:"path"`
+	Required bool               `yaml:"required"`
+	When     *WorkflowCondition `yaml:"when"`
+
+	line int
+}
+
+type WorkflowCondition struct {
+	Phase          []string `yaml:"phase"`
+	DeliveryIntent []string `yaml:"delivery_intent"`
+	PathPrefix     []string `yaml:"path_prefix"`
+	Config         []string `yaml:"config"`
 }
 
 type artifactHeader struct {
 	Kind string `yaml:"kind"`
 	Slug string `yaml:"slug"`
 }
+
+type rulesetHeader struct {
+	Kind              string   `yaml:"kind"`
+	Slug              string   `yaml:"slug"`
+	Status            string   `yaml:"status"`
+	ReadPolicyDefault string   `yaml:"read_policy_default"`
+	Paths             []string `yaml:"paths"`
+}
@@ -0,0 +1,90 @@
+package context
+
+import (
+	"fmt"
+	"sort"
+)
+
+const DriftSchemaVersion = "kit.context.drift/v1"
+
+type Drift struct {
+	SchemaVersion string           `json:"schema_version"`
+	Request       Request          `json:"request"`
+	Fresh         bool             `json:"fresh"`
+	Material      bool             `json:"material"`
+	Blocked       bool             `json:"blocked"`
+	Changes       []EvidenceChange `json:"changes"`
+	Diagnostics   []Diagnostic     `json:"diagnostics"`
+}
+
+type EvidenceChange struct {
+	Change   string `json:"change"`
+	Kind     string `json:"kind"`
+	Path     string `json:"path"`
+	Required bool   `json:"required"`
+	Material bool   `json:"material"`
+	Previous string `json:"previous_digest,omitempty"`
+	Current  string `json:"current_digest,omitempty"`
+}
+
+// Verify re-resolves a saved contract's request and reports evidence that was
+// added, removed, or changed since the contract was produced. Drift is
+// material when it touches required evidence on either side.
+func Verify(projectRoot string, saved Contract) (Drift, error) {
+	if saved.SchemaVersion != SchemaVersion {
+		return Drift{}, fmt.Errorf("contract schema is %q, want %q", saved.SchemaVersion, SchemaVersion)
+	}
+	current := Resolve(projectRoot, saved.Request)
+	drift := Drift{SchemaVersion: DriftSchemaVersion, Request: current.Request, Blocked: current.Blocked, Changes: []EvidenceChange{}, Diagnostics: current.Diagnostics}
+	previous := map[string]EvidenceItem{}
+	for _, item := range saved.Evidence {
+		previous[item.Path] = item
+	}
+	seen := map[string]bool{}
+	for _, item := range current.Evidence {
+		seen[item.Path] = true
+		before, ok := previous[item.Path]
+		if !ok {
+			drift.add(EvidenceChange{Change: "added", Kind: item.Kind, Path: item.Path, Required: item.Required, Current: item.Digest})
+			continue
+		}
+		if loadedDigest(before) != loadedDigest(item) || before.State != item.State {
+			drift.add(EvidenceChange{
+				Change: "changed", Kind: item.Kind, Path: item.Path, Required: item.Required || before.Required,
+				Previous: before.Digest, Current: item.Digest,
+			})
+		}
+	}
+	for _, item := range saved.Evidence {
+		if !seen[item.Path] {
+			drift.add(EvidenceChange{Change: "removed", Kind: item.Kind, Path: item.Path, Required: item.Required, Previous: item.Digest})
+		}
+	}
+	sort.SliceStable(drift.Changes, func(i, j int) bool {
+		if drift.Changes[i].Path != drift.Changes[j].Path {
+			return drift.Changes[i].Path < drift.Changes[j].Path
+		}
+		return drift.Changes[i].Change < drift.Changes[j].Change
+	})
+	drift.Fresh = len(drift.Changes) == 0
+	return drift, nil
+}
+
+func (d *Drift) add(change EvidenceChange) {
+	change.Material = change.Required
+	d.Material = d.Material || change.Material
+	d.Changes = append(d.Changes, change)
+}
+
+// loadedDigest identifies the bytes an agent actually loaded: the span
+// digests for selected sections, otherwise the whole-file digest.
+func loadedDigest(item EvidenceItem) string {
+	if len(item.Spans) == 0 {
+		return item.Digest
+	}
+	value := ""
+	for _, span := range item.Spans {
+		value += span.SelectorType + ":" + span.Selector + "=" + span.Digest + "\n"
+	}
+	return value
+}
@@ -0,0 +1,63 @@
+package context
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestVerifyReportsEvidenceDriftSinceContract(t *testing.T) {
+	root := contextProject(t)
+	document := `---
+kind: workflow
+slug: check
+description: test
+rules: []
+evidence:
+  - kind: required
+    path: required.md
+    required: true
+  - kind: optional
+    path: optional.md
+    required: false
+---
+# Check
+`
+	writeContextFile(t, root, "docs/references/workflows/check.md", document)
+	writeContextFile(t, root, "required.md", "required\n")
+	writeContextFile(t, root, "optional.md", "optional\n")
+	saved := Resolve(root, Request{Workflow: "check"})
+
+	fresh, err := Verify(root, saved)
+	if err != nil || !fresh.Fresh || fresh.Material || len(fresh.Changes) != 0 {
+		t.Fatalf("unchanged project drift = %#v err=%v", fresh, err)
+	}
+
+	writeContextFile(t, root, "optional.md", "optional changed\n")
+	optional, err := Verify(root, saved)
+	if err != nil || optional.Fresh || optional.Material {
+		t.Fatalf("optional drift = %#v err=%v", optional, err)
+	}
+
+	writeContextFile(t, root, "required.md", "required changed\n")
+	if err := os.Remove(filepath.Join(root, "optional.md")); err != nil {
+		t.Fatal(err)
+	}
+	drift, err := Verify(root, saved)
+	if err != nil || !drift.Material {
+		t.Fatalf("required drift = %#v err=%v", drift, err)
+	}
+	changes := map[string]string{}
+	for _, change := range drift.Changes {
+		changes[change.Path] = change.Change
+	}
+	if changes["required.md"] != "changed" || changes["optional.md"] != "changed" {
+		t.Fatalf("drift changes = %#v", drift.Changes)
+	}
+}
+
+func TestVerifyRejectsUnknownContractSchema(t *testing.T) {
+	if _, err := Verify(contextProject(t), Contract{SchemaVersion: "kit.context/v0"}); err == nil {
+		t.Fatal("unknown contract schema was accepted")
+	}
+}
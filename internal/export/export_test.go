@@ -0,0 +1,121 @@
+package export
+
+import (
+	"bytes"
+	"encoding/json"
+	"encoding/xml"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/jamesonstone/kit/v3/internal/improve"
+	"github.com/jamesonstone/kit/v3/internal/verify"
+)
+
+func TestVerifyRunJUnitWritesOneCasePerCommand(t *testing.T) {
+	start := time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC)
+	run := verify.Run{
+		RunID:     "run-1",
+		Feature:   verify.FeatureRef{Slug: "alpha"},
+		StartedAt: start,
+		EndedAt:   start.Add(1500 * time.Millisecond),
+		Results: []verify.CommandResult{
+			{CommandID: "VALIDATION-001", TaskID: "build", Status: "pass", DurationMS: 250, Stdout: "ok\n"},
+			{CommandID: "VALIDATION-002", TaskID: "test", Status: "fail", ExitCode: 1, Raw: "go test ./...", StderrPath: ".kit/verify/x.stderr.log", Stderr: "token=abc123\n"},
+			{CommandID: "VALIDATION-003", TaskID: "lint", Status: "skipped", Error: "dependency test did not pass"},
+		},
+	}
+
+	var out bytes.Buffer
+	if err := WriteJUnit(&out, VerifyRunJUnit(run)); err != nil {
+		t.Fatalf("WriteJUnit() error = %v", err)
+	}
+	var report JUnitReport
+	if err := xml.Unmarshal(out.Bytes(), &report); err != nil {
+		t.Fatalf("output is not valid XML: %v\n%s", err, out.String())
+	}
+	if report.Tests != 3 || report.Failures != 1 || report.Skipped != 1 || len(report.Suites) != 1 {
+		t.Fatalf("report tallies = %+v", report)
+	}
+	suite := report.Suites[0]
+	if suite.Name != "kit.verify.alpha" || suite.Time != "1.500" || suite.Timestamp != "2026-01-02T03:04:05" {
+		t.Fatalf("suite = %+v", suite)
+	}
+	failed := suite.Cases[1]
+	if failed.ClassName != "kit.verify.alpha.test" || failed.Failure == nil || failed.Failure.Message != "command exited 1" {
+		t.Fatalf("failed case = %+v", failed)
+	}
+	if !strings.Contains(failed.Failure.Body, "logs: .kit/verify/x.stderr.log") {
+		t.Fatalf("failure body = %q", failed.Failure.Body)
+	}
+	if strings.Contains(out.String(), "abc123") {
+		t.Fatalf("JUnit output leaked a secret:\n%s", out.String())
+	}
+	if suite.Cases[2].Skipped == nil || suite.Cases[2].Skipped.Message != "dependency test did not pass" {
+		t.Fatalf("skipped case = %+v", suite.Cases[2])
+	}
+}
+
+func TestImproveRunJUnitWritesTaskAndAssertionCases(t *testing.T) {
+	manifest := improve.RunManifest{
+		RunID: "run-2",
+		Suite: "default",
+		Traces: []improve.Trace{{
+			TaskID:        "check-json",
+			RepeatIndex:   1,
+			Status:        "failed",
+			OracleResults: []improve.OracleResult{{Status: "failed", Message: "stdout missing marker"}},
+			Assertions: []improve.AssertionResult{
+				{Type: "exit_code", Status: "passed"},
+				{Type: "stdout_contains", Status: "failed", Message: "stdout missing marker"},
+				{Type: "custom", Status: "inconclusive", Message: "unsupported assertion type"},
+			},
+		}},
+	}
+
+	var out bytes.Buffer
+	if err := WriteJUnit(&out, ImproveRunJUnit(manifest)); err != nil {
+		t.Fatalf("WriteJUnit() error = %v", err)
+	}
+	var report JUnitReport
+	if err := xml.Unmarshal(out.Bytes(), &report); err != nil {
+		t.Fatalf("output is not valid XML: %v", err)
+	}
+	if report.Tests != 4 || report.Failures != 2 || report.Skipped != 1 {
+		t.Fatalf("report tallies = %+v", report)
+	}
+	cases := report.Suites[0].Cases
+	if cases[0].Name != "check-json#1" || cases[0].Failure == nil || cases[0].Failure.Message != "stdout missing marker" {
+		t.Fatalf("task case = %+v", cases[0])
+	}
+	if cases[2].Name != "check-json#1 assertion 2 stdout_contains" || cases[2].ClassName != "kit.improve.default.check-json" {
+		t.Fatalf("assertion case = %+v", cases[2])
+	}
+}
+
+func TestWriteSARIFGroupsRulesAndAnchorsLocations(t *testing.T) {
+	findings := []Finding{
+		{RuleID: "kit.contract.templates", Description: "templates", Level: "error", Message: "missing section", Path: "docs/specs/0001-alpha/SPEC.md"},
+		{RuleID: "kit.contract.constitution", Level: "note", Message: "advisory"},
+		{RuleID: "kit.contract.templates", Level: "warning", Message: "placeholder"},
+	}
+	var out bytes.Buffer
+	if err := WriteSARIF(&out, "v1.2.3", findings); err != nil {
+		t.Fatalf("WriteSARIF() error = %v", err)
+	}
+	var log sarifLog
+	if err := json.Unmarshal(out.Bytes(), &log); err != nil {
+		t.Fatalf("output is not valid JSON: %v", err)
+	}
+	if log.Version != SARIFVersion || len(log.Runs) != 1 {
+		t.Fatalf("log = %+v", log)
+	}
+	driver := log.Runs[0].Tool.Driver
+	if driver.Version != "v1.2.3" || len(driver.Rules) != 2 || driver.Rules[0].ID != "kit.contract.constitution" || driver.Rules[1].ShortDescription.Text != "templates" {
+		t.Fatalf("driver = %+v", driver)
+	}
+	results := log.Runs[0].Results
+	if len(results) != 3 || results[0].Locations[0].PhysicalLocation.ArtifactLocation.URI != "docs/specs/0001-alpha/SPEC.md" || results[1].Locations != nil {
+		t.Fatalf("results = %+v", results)
+	}
+}
@@ -0,0 +1,118 @@
+// Package export renders Kit run and finding artifacts in interchange
+// formats that CI dashboards and code-scanning tools consume directly.
+package export
+
+import (
+	"encoding/xml"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+// JUnitReport is the <testsuites> root of a JUnit XML document.
+type JUnitReport struct {
+	XMLName  xml.Name     `xml:"testsuites"`
+	Name     string       `xml:"name,attr,omitempty"`
+	Tests    int          `xml:"tests,attr"`
+	Failures int          `xml:"failures,attr"`
+	Skipped  int          `xml:"skipped,attr"`
+	Time     string       `xml:"time,attr"`
+	Suites   []JUnitSuite `xml:"testsuite"`
+}
+
+type JUnitSuite struct {
+	Name      string      `xml:"name,attr"`
+	Tests     int         `xml:"tests,attr"`
+	Failures  int         `xml:"failures,attr"`
+	Skipped   int         `xml:"skipped,attr"`
+	Time      string      `xml:"time,attr"`
+	Timestamp string      `xml:"timestamp,attr,omitempty"`
+	Cases     []JUnitCase `xml:"testcase"`
+}
+
+type JUnitCase struct {
+	Name      string        `xml:"name,attr"`
+	ClassName string        `xml:"classname,attr"`
+	Time      string        `xml:"time,attr"`
+	Failure   *JUnitFailure `xml:"failure,omitempty"`
+	Skipped   *JUnitSkipped `xml:"skipped,omitempty"`
+	SystemOut string        `xml:"system-out,omitempty"`
+	SystemErr string        `xml:"system-err,omitempty"`
+}
+
+type JUnitFailure struct {
+	Message string `xml:"message,attr"`
+	Type    string `xml:"type,attr,omitempty"`
+	Body    string `xml:",chardata"`
+}
+
+type JUnitSkipped struct {
+	Message string `xml:"message,attr,omitempty"`
+}
+
+// WriteJUnit fills in the suite and report tallies from the test cases and
+// writes an indented JUnit XML document.
+func WriteJUnit(w io.Writer, report JUnitReport) error {
+	report.Tests, report.Failures, report.Skipped = 0, 0, 0
+	for index := range report.Suites {
+		suite := &report.Suites[index]
+		suite.Tests, suite.Failures, suite.Skipped = len(suite.Cases), 0, 0
+		for _, testCase := range suite.Cases {
+			switch {
+			case testCase.Failure != nil:
+				suite.Failures++
+			case testCase.Skipped != nil:
+				suite.Skipped++
+			}
+		}
+		report.Tests += suite.Tests
+		report.Failures += suite.Failures
+		report.Skipped += suite.Skipped
+	}
+	if _, err := io.WriteString(w, xml.Header); err != nil {
+		return err
+	}
+	encoder := xml.NewEncoder(w)
+	encoder.Indent("", "  ")
+	if err := encoder.Encode(report); err != nil {
+		return err
+	}
+	_, err := io.WriteString(w, "\n")
+	return err
+}
+
+// WriteFile creates path and its parent directories and fills it with write.
+func WriteFile(path string, write func(io.Writer) error) error {
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return err
+	}
+	file, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+	if err := write(file); err != nil {
+		_ = file.Close()
+		return err
+	}
+	return file.Close()
+}
+
+func seconds(durationMS int64) string {
+	return fmt.Sprintf("%.3f", float64(durationMS)/1000)
+}
+
+func spanSeconds(start, end time.Time) string {
+	if start.IsZero() || end.Before(start) {
+		return seconds(0)
+	}
+	return seconds(end.Sub(start).Milliseconds())
+}
+
+func timestamp(value time.Time) string {
+	if value.IsZero() {
+		return ""
+	}
+	return value.UTC().Format("2006-01-02T15:04:05")
+}
@@ -0,0 +1,135 @@
+package export
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/jamesonstone/kit/v3/internal/improve"
+	"github.com/jamesonstone/kit/v3/internal/verify"
+)
+
+// VerifyRunJUnit maps a verification run to one test case per command,
+// grouped under its task ID.
+func VerifyRunJUnit(run verify.Run) JUnitReport {
+	name := run.Feature.Slug
+	if name == "" {
+		name = run.Feature.DirName
+	}
+	suite := JUnitSuite{
+		Name:      "kit.verify." + name,
+		Time:      spanSeconds(run.StartedAt, run.EndedAt),
+		Timestamp: timestamp(run.StartedAt),
+	}
+	for _, result := range run.Results {
+		testCase := JUnitCase{
+			Name:      result.CommandID,
+			ClassName: suite.Name + "." + result.TaskID,
+			Time:      seconds(result.DurationMS),
+			SystemOut: verify.Redact(result.Stdout),
+			SystemErr: verify.Redact(result.Stderr),
+		}
+		switch result.Status {
+		case "pass":
+		case "skipped":
+			testCase.Skipped = &JUnitSkipped{Message: verify.Redact(result.Error)}
+		default:
+			testCase.Failure = &JUnitFailure{
+				Message: verifyFailureMessage(result),
+				Type:    result.Status,
+				Body:    verifyFailureBody(result),
+			}
+		}
+		suite.Cases = append(suite.Cases, testCase)
+	}
+	return JUnitReport{Name: "kit verify " + run.RunID, Time: suite.Time, Suites: []JUnitSuite{suite}}
+}
+
+func verifyFailureMessage(result verify.CommandResult) string {
+	switch {
+	case result.TimedOut:
+		return "command timed out"
+	case result.ExitCode != 0 || strings.TrimSpace(result.Error) == "":
+		return fmt.Sprintf("command exited %d", result.ExitCode)
+	default:
+		return verify.Redact(result.Error)
+	}
+}
+
+func verifyFailureBody(result verify.CommandResult) string {
+	lines := []string{
+		"command: " + verify.Redact(result.Raw),
+		fmt.Sprintf("exit code: %d", result.ExitCode),
+	}
+	if result.Attempts > 1 {
+		lines = append(lines, fmt.Sprintf("attempts: %d", result.Attempts))
+	}
+	if len(result.Missing) > 0 {
+		lines = append(lines, "missing files: "+strings.Join(result.Missing, ", "))
+	}
+	if result.StdoutPath != "" || result.StderrPath != "" {
+		lines = append(lines, "logs: "+strings.Join(nonEmpty(result.StdoutPath, result.StderrPath), ", "))
+	}
+	return strings.Join(lines, "\n")
+}
+
+// ImproveRunJUnit maps a benchmark run to one test case per task repeat and
+// one per assertion, so a regression in a single assertion is visible on its
+// own even when the task-level case already fails.
+func ImproveRunJUnit(manifest improve.RunManifest) JUnitReport {
+	suite := JUnitSuite{
+		Name:      "kit.improve." + manifest.Suite,
+		Time:      spanSeconds(manifest.StartedAt, manifest.EndedAt),
+		Timestamp: timestamp(manifest.StartedAt),
+	}
+	for _, trace := range manifest.Traces {
+		taskName := fmt.Sprintf("%s#%d", trace.TaskID, trace.RepeatIndex)
+		taskCase := JUnitCase{Name: taskName, ClassName: suite.Name, Time: seconds(trace.DurationMS)}
+		if trace.Status != "passed" {
+			taskCase.Failure = &JUnitFailure{
+				Message: improveFailureMessage(trace),
+				Type:    trace.Status,
+				Body:    "workspace: " + trace.WorkspacePath,
+			}
+		}
+		suite.Cases = append(suite.Cases, taskCase)
+		for index, assertion := range trace.Assertions {
+			assertionCase := JUnitCase{
+				Name:      fmt.Sprintf("%s assertion %d %s", taskName, index+1, assertion.Type),
+				ClassName: suite.Name + "." + trace.TaskID,
+				Time:      seconds(0),
+			}
+			switch assertion.Status {
+			case "passed":
+			case "inconclusive":
+				assertionCase.Skipped = &JUnitSkipped{Message: assertion.Message}
+			default:
+				assertionCase.Failure = &JUnitFailure{Message: assertion.Message, Type: assertion.Status}
+			}
+			suite.Cases = append(suite.Cases, assertionCase)
+		}
+	}
+	return JUnitReport{Name: "kit improve " + manifest.RunID, Time: suite.Time, Suites: []JUnitSuite{suite}}
+}
+
+func improveFailureMessage(trace improve.Trace) string {
+	var messages []string
+	for _, result := range trace.OracleResults {
+		if strings.TrimSpace(result.Message) != "" {
+			messages = append(messages, result.Message)
+		}
+	}
+	if len(messages) == 0 {
+		return "task " + trace.Status
+	}
+	return strings.Join(messages, "; ")
+}
+
+func nonEmpty(values ...string) []string {
+	var out []string
+	for _, value := range values {
+		if value != "" {
+			out = append(out, value)
+		}
+	}
+	return out
+}
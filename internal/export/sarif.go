@@ -0,0 +1,116 @@
+package export
+
+import (
+	"encoding/json"
+	"io"
+	"path/filepath"
+	"sort"
+)
+
+const (
+	SARIFVersion = "2.1.0"
+	SARIFSchema  = "https://json.schemastore.org/sarif-2.1.0.json"
+	toolName     = "kit"
+	toolURI      = "https://github.com/jamesonstone/kit"
+)
+
+// Finding is one tool-neutral diagnostic. Path is repository-relative so
+// code-scanning UIs can anchor the result to a file.
+type Finding struct {
+	RuleID      string
+	Description string
+	Level       string // error, warning, or note
+	Message     string
+	Path        string
+	Properties  map[string]any
+}
+
+type sarifLog struct {
+	Version string     `json:"version"`
+	Schema  string     `json:"$schema"`
+	Runs    []sarifRun `json:"runs"`
+}
+
+type sarifRun struct {
+	Tool    sarifTool     `json:"tool"`
+	Results []sarifResult `json:"results"`
+}
+
+type sarifTool struct {
+	Driver sarifDriver `json:"driver"`
+}
+
+type sarifDriver struct {
+	Name           string      `json:"name"`
+	Version        string      `json:"version,omitempty"`
+	InformationURI string      `json:"informationUri"`
+	Rules          []sarifRule `json:"rules"`
+}
+
+type sarifRule struct {
+	ID               string       `json:"id"`
+	ShortDescription sarifMessage `json:"shortDescription"`
+}
+
+type sarifMessage struct {
+	Text string `json:"text"`
+}
+
+type sarifResult struct {
+	RuleID     string          `json:"ruleId"`
+	Level      string          `json:"level"`
+	Message    sarifMessage    `json:"message"`
+	Locations  []sarifLocation `json:"locations,omitempty"`
+	Properties map[string]any  `json:"properties,omitempty"`
+}
+
+type sarifLocation struct {
+	PhysicalLocation sarifPhysicalLocation `json:"physicalLocation"`
+}
+
+type sarifPhysicalLocation struct {
+	ArtifactLocation sarifArtifactLocation `json:"artifactLocation"`
+}
+
+type sarifArtifactLocation struct {
+	URI string `json:"uri"`
+}
+
+// WriteSARIF writes findings as a single-run SARIF 2.1.0 log. Rules are
+// derived from the distinct finding rule IDs in sorted order.
+func WriteSARIF(w io.Writer, toolVersion string, findings []Finding) error {
+	rules := map[string]string{}
+	results := make([]sarifResult, 0, len(findings))
+	for _, finding := range findings {
+		if rules[finding.RuleID] == "" {
+			rules[finding.RuleID] = finding.Description
+		}
+		result := sarifResult{
+			RuleID:     finding.RuleID,
+			Level:      finding.Level,
+			Message:    sarifMessage{Text: finding.Message},
+			Properties: finding.Properties,
+		}
+		if finding.Path != "" {
+			result.Locations = []sarifLocation{{PhysicalLocation: sarifPhysicalLocation{
+				ArtifactLocation: sarifArtifactLocation{URI: filepath.ToSlash(finding.Path)},
+			}}}
+		}
+		results = append(results, result)
+	}
+	driver := sarifDriver{Name: toolName, Version: toolVersion, InformationURI: toolURI, Rules: []sarifRule{}}
+	for id, description := range rules {
+		if description == "" {
+			description = id
+		}
+		driver.Rules = append(driver.Rules, sarifRule{ID: id, ShortDescription: sarifMessage{Text: description}})
+	}
+	sort.Slice(driver.Rules, func(i, j int) bool { return driver.Rules[i].ID < driver.Rules[j].ID })
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(sarifLog{
+		Version: SARIFVersion,
+		Schema:  SARIFSchema,
+		Runs:    []sarifRun{{Tool: sarifTool{Driver: driver}, Results: results}},
+	})
+}
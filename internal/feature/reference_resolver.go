@@ -5,7 +5,6 @@ import (
 	"os"
 	"os/exec"
 	"path/filepath"
-	"regexp"
 	"strings"
 
 	"github.com/jamesonstone/kit/v3/internal/config"
@@ -199,36 +198,6 @@ func resolveContentSelector(
 	return unresolvedReference(nodeID, fmt.Sprintf("%s selector %q not found", kind, selector))
 }
 
-func fileContainsHeading(content string, selector string) bool {
-	want := normalizeReferenceSelector(selector)
-	for _, line := range strings.Split(content, "\n") {
-		trimmed := strings.TrimSpace(line)
-		if !strings.HasPrefix(trimmed, "#") {
-			continue
-		}
-		heading := strings.TrimSpace(strings.TrimLeft(trimmed, "#"))
-		if normalizeReferenceSelector(heading) == want {
-			return true
-		}
-	}
-	return false
-}
-
-func fileContainsSymbol(content string, selector string) bool {
-	quoted := regexp.QuoteMeta(selector)
-	patterns := []*regexp.Regexp{
-		regexp.MustCompile(`(?m)^\s*func\s+(?:\([^)]+\)\s*)?` + quoted + `\b`),
-		regexp.MustCompile(`(?m)^\s*type\s+` + quoted + `\b`),
-		regexp.MustCompile(`(?m)^\s*(?:const|var)\s+(?:\([^)]*\b` + quoted + `\b|` + quoted + `\b)`),
-	}
-	for _, pattern := range patterns {
-		if pattern.MatchString(content) {
-			return true
-		}
-	}
-	return strings.Contains(content, selector)
-}
-
 func fileContainsCommand(content string, selector string) bool {
 	if strings.Contains(content, selector) {
 		return true
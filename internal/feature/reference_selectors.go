@@ -0,0 +1,70 @@
+package feature
+
+import (
+	"regexp"
+	"strings"
+)
+
+// NormalizeReferenceSelector folds case and whitespace so a selector matches
+// its heading however either is spaced or capitalized.
+func NormalizeReferenceSelector(value string) string {
+	value = strings.TrimSpace(strings.ToLower(value))
+	value = strings.Join(strings.Fields(value), " ")
+	return value
+}
+
+// ReferenceHeading returns the level and text of a line that heading
+// selectors treat as a heading, or level 0 for any other line.
+func ReferenceHeading(line string) (int, string) {
+	trimmed := strings.TrimSpace(line)
+	heading := strings.TrimLeft(trimmed, "#")
+	level := len(trimmed) - len(heading)
+	if level == 0 {
+		return 0, ""
+	}
+	return level, strings.TrimSpace(heading)
+}
+
+// LocateReferenceSymbol finds selector in Go-style source. It returns the
+// offset of the line declaring the symbol with declared set, including
+// indented declarations and grouped const or var blocks. When no declaration
+// matches it falls back to the first plain-text occurrence, and ok reports
+// whether either was found.
+func LocateReferenceSymbol(content string, selector string) (offset int, declared bool, ok bool) {
+	quoted := regexp.QuoteMeta(selector)
+	patterns := []*regexp.Regexp{
+		regexp.MustCompile(`(?m)^\s*func\s+(?:\([^)]+\)\s*)?` + quoted + `\b`),
+		regexp.MustCompile(`(?m)^\s*type\s+` + quoted + `\b`),
+		regexp.MustCompile(`(?m)^\s*(?:const|var)\s+(?:\([^)]*\b` + quoted + `\b|` + quoted + `\b)`),
+	}
+	first := -1
+	for _, pattern := range patterns {
+		if loc := pattern.FindStringIndex(content); loc != nil && (first < 0 || loc[0] < first) {
+			first = loc[0]
+		}
+	}
+	if first >= 0 {
+		// (?m)^\s* can consume blank lines before the declaration keyword.
+		keyword := first + len(content[first:]) - len(strings.TrimLeft(content[first:], " \t\r\n"))
+		return strings.LastIndexByte(content[:keyword], '\n') + 1, true, true
+	}
+	if index := strings.Index(content, selector); index >= 0 {
+		return index, false, true
+	}
+	return 0, false, false
+}
+
+func fileContainsHeading(content string, selector string) bool {
+	want := NormalizeReferenceSelector(selector)
+	for _, line := range strings.Split(content, "\n") {
+		if level, heading := ReferenceHeading(line); level > 0 && NormalizeReferenceSelector(heading) == want {
+			return true
+		}
+	}
+	return false
+}
+
+func fileContainsSymbol(content string, selector string) bool {
+	_, _, ok := LocateReferenceSymbol(content, selector)
+	return ok
+}
@@ -90,12 +90,6 @@ func referenceNodeID(reference document.MetadataReference) string {
 	return strings.Join(parts, "#")
 }
 
-func normalizeReferenceSelector(value string) string {
-	value = strings.TrimSpace(strings.ToLower(value))
-	value = strings.Join(strings.Fields(value), " ")
-	return value
-}
-
 func unresolvedReference(nodeID string, message string) referenceResolution {
 	return referenceResolution{NodeID: nodeID, Kind: "unresolved", Resolved: false, Error: message}
 }
@@ -17,8 +17,11 @@ func Mine(projectRoot, from string) (WeaknessReport, error) {
 	if err != nil {
 		return WeaknessReport{}, err
 	}
+	// Mining defines what the proposer sees: traces hidden by the suite never
+	// reach clusters, and every visible task is recorded as seen.
+	visible := proposerVisibleTraces(traces)
 	bySignature := map[string][]Trace{}
-	for _, trace := range traces {
+	for _, trace := range visible {
 		if trace.Status == "passed" || strings.TrimSpace(trace.FailureSignature) == "" {
 			continue
 		}
@@ -28,6 +31,12 @@ func Mine(projectRoot, from string) (WeaknessReport, error) {
 		SchemaVersion: SchemaVersion,
 		Kind:          "weakness_report",
 		SourceDir:     sourceDir,
+		Split:         minedSplit(sourceDir),
+		SeenTasks:     sortedTaskIDs(visible),
+		HiddenTraces:  len(traces) - len(visible),
+	}
+	if report.SeenTasks == nil {
+		report.SeenTasks = []string{}
 	}
 	for signature, values := range bySignature {
 		cluster := WeaknessCluster{
@@ -37,7 +46,7 @@ func Mine(projectRoot, from string) (WeaknessReport, error) {
 			Actionability:        "needs-review",
 			Confidence:           confidenceFor(values),
 			ReproducibilityCount: len(values),
-			FlakeRate:            flakeRateFor(values, traces),
+			FlakeRate:            flakeRateFor(values, visible),
 			ProposedEvalTasks:    []string{"preserve " + signature},
 			AffectedTasks:        uniqueTaskIDs(values),
 			RepresentativeTraces: traceIDs(values),
@@ -116,6 +125,8 @@ func Propose(projectRoot, from string, maxCandidates int) ([]Candidate, error) {
 			RegressionRisks:  []string{"Prompt bloat", "Overfitting to held-in tasks"},
 			Rollback:         "Revert the candidate patch and rerun kit improve validate.",
 			Status:           "proposed",
+			Split:            report.Split,
+			SeenTasks:        report.SeenTasks,
 		}
 		if err := os.MkdirAll(filepath.Dir(promptPath), 0o755); err != nil {
 			return nil, err
@@ -131,26 +142,6 @@ func Propose(projectRoot, from string, maxCandidates int) ([]Candidate, error) {
 	return candidates, nil
 }
 
-func Validate(projectRoot, candidatePath string) (Scorecard, error) {
-	candidate, err := readCandidate(candidatePath)
-	if err != nil {
-		return Scorecard{}, err
-	}
-	scorecard := Scorecard{
-		SchemaVersion:      SchemaVersion,
-		CandidateID:        candidate.ID,
-		Score:              0,
-		Acceptance:         "inconclusive",
-		Reasons:            []string{"This command validates candidate metadata only; it does not run or compare benchmark behavior."},
-		ValidationCommands: []string{"kit improve validate --candidate " + candidatePath + " --json"},
-	}
-	if candidate.Status == "proposed" {
-		scorecard.Acceptance = "metadata-only"
-		scorecard.Reasons = []string{"candidate metadata is well-formed", "score 0 is not a task-quality judgment", "run identical benchmark suites separately before review"}
-	}
-	return scorecard, nil
-}
-
 func Report(projectRoot, from string) (string, error) {
 	sourceDir := resolveArtifactDir(projectRoot, from)
 	manifest, err := readRunManifest(filepath.Join(sourceDir, "run.json"))
@@ -223,16 +214,6 @@ func readTraces(dir string) ([]Trace, error) {
 	return traces, nil
 }
 
-func resolveArtifactDir(projectRoot, from string) string {
-	if strings.TrimSpace(from) == "" {
-		return filepath.Join(artifactRoot(projectRoot), "latest")
-	}
-	if filepath.IsAbs(from) {
-		return from
-	}
-	return filepath.Join(projectRoot, from)
-}
-
 func readJSON(path string, out any) error {
 	data, err := os.ReadFile(path)
 	if err != nil {
@@ -246,54 +227,6 @@ func readWeaknessReport(path string) (WeaknessReport, error) {
 	return report, readJSON(path, &report)
 }
 
-func readCandidate(path string) (Candidate, error) {
-	var candidate Candidate
-	if err := readJSON(path, &candidate); err != nil {
-		return Candidate{}, err
-	}
-	if err := validateCandidateMetadata(candidate); err != nil {
-		return Candidate{}, err
-	}
-	return candidate, nil
-}
-
-func validateCandidateMetadata(candidate Candidate) error {
-	var findings []string
-	if candidate.SchemaVersion != SchemaVersion {
-		findings = append(findings, fmt.Sprintf("schema_version must be %d", SchemaVersion))
-	}
-	requiredText := []struct {
-		name  string
-		value string
-	}{
-		{name: "id", value: candidate.ID},
-		{name: "target_cluster", value: candidate.TargetCluster},
-		{name: "summary", value: candidate.Summary},
-		{name: "expected_effect", value: candidate.ExpectedEffect},
-		{name: "rationale", value: candidate.Rationale},
-		{name: "rollback", value: candidate.Rollback},
-		{name: "status", value: candidate.Status},
-	}
-	for _, field := range requiredText {
-		if strings.TrimSpace(field.value) == "" {
-			findings = append(findings, field.name+" is required")
-		}
-	}
-	if len(candidate.EditableSurfaces) == 0 {
-		findings = append(findings, "editable_surfaces must not be empty")
-	}
-	if len(candidate.RegressionRisks) == 0 {
-		findings = append(findings, "regression_risks must not be empty")
-	}
-	if strings.TrimSpace(candidate.Status) != "" && candidate.Status != "proposed" {
-		findings = append(findings, "status must be proposed")
-	}
-	if len(findings) > 0 {
-		return fmt.Errorf("invalid candidate metadata: %s", strings.Join(findings, "; "))
-	}
-	return nil
-}
-
 func readRunManifest(path string) (RunManifest, error) {
 	var manifest RunManifest
 	return manifest, readJSON(path, &manifest)
@@ -0,0 +1,221 @@
+package improve
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/jamesonstone/kit/v3/internal/verify"
+)
+
+func evaluateAssertions(task Task, workspace string, results []verify.CommandResult, changed []string) []AssertionResult {
+	var out []AssertionResult
+	for _, assertion := range task.Assertions {
+		switch assertion.Type {
+		case "command_succeeds":
+			out = append(out, assertCommandSucceeds(assertion, results))
+		case "exit_code_equals":
+			out = append(out, assertExitCodeEquals(assertion, results))
+		case "stdout_contains", "stderr_contains":
+			out = append(out, assertStreamContains(assertion, results))
+		case "stdout_not_contains", "stderr_not_contains":
+			out = append(out, assertStreamNotContains(assertion, results))
+		case "stdout_nonempty", "stderr_nonempty":
+			out = append(out, assertStreamNonempty(assertion, results))
+		case "stderr_empty":
+			out = append(out, assertStreamEmpty(assertion, results))
+		case "stdout_matches", "stderr_matches":
+			out = append(out, assertStreamMatches(assertion, results))
+		case "stdout_lines_max", "stdout_words_max", "stdout_estimated_tokens_max":
+			out = append(out, assertStdoutMaximum(assertion, results))
+		case "json_path_exists", "json_path_equals":
+			out = append(out, assertJSONPath(assertion, results))
+		case "file_exists", "file_contains":
+			out = append(out, assertWorkspaceFile(assertion, workspace))
+		case "git_diff_empty":
+			if len(changed) == 0 {
+				out = append(out, AssertionResult{Type: assertion.Type, Status: "passed"})
+			} else {
+				out = append(out, AssertionResult{Type: assertion.Type, Status: "failed", Message: "changed files: " + strings.Join(changed, ", ")})
+			}
+		default:
+			out = append(out, AssertionResult{Type: assertion.Type, Status: "inconclusive", Message: "unsupported assertion type"})
+		}
+	}
+	return out
+}
+
+// assertionStream returns the stream an stdout_* or stderr_* assertion reads.
+func assertionStream(assertion Assertion, result verify.CommandResult) (string, string) {
+	if strings.HasPrefix(assertion.Type, "stderr_") {
+		return "stderr", result.Stderr
+	}
+	return "stdout", result.Stdout
+}
+
+func assertStreamContains(assertion Assertion, results []verify.CommandResult) AssertionResult {
+	result, failure := commandResult(assertion, results)
+	if failure != nil {
+		return *failure
+	}
+	name, text := assertionStream(assertion, result)
+	if strings.Contains(text, assertion.Value) {
+		return passedAssertion(assertion)
+	}
+	return failedAssertion(assertion, fmt.Sprintf("%s missing %q", name, assertion.Value))
+}
+
+func assertStreamNotContains(assertion Assertion, results []verify.CommandResult) AssertionResult {
+	result, failure := commandResult(assertion, results)
+	if failure != nil {
+		return *failure
+	}
+	name, text := assertionStream(assertion, result)
+	if !strings.Contains(text, assertion.Value) {
+		return passedAssertion(assertion)
+	}
+	return failedAssertion(assertion, fmt.Sprintf("%s unexpectedly contains %q", name, assertion.Value))
+}
+
+func assertStreamNonempty(assertion Assertion, results []verify.CommandResult) AssertionResult {
+	result, failure := commandResult(assertion, results)
+	if failure != nil {
+		return *failure
+	}
+	name, text := assertionStream(assertion, result)
+	if strings.TrimSpace(text) != "" {
+		return passedAssertion(assertion)
+	}
+	return failedAssertion(assertion, name+" is empty")
+}
+
+func assertStreamEmpty(assertion Assertion, results []verify.CommandResult) AssertionResult {
+	result, failure := commandResult(assertion, results)
+	if failure != nil {
+		return *failure
+	}
+	name, text := assertionStream(assertion, result)
+	if strings.TrimSpace(text) == "" {
+		return passedAssertion(assertion)
+	}
+	return failedAssertion(assertion, fmt.Sprintf("%s is not empty: %q", name, firstLine(redactOutput(text))))
+}
+
+func assertStreamMatches(assertion Assertion, results []verify.CommandResult) AssertionResult {
+	result, failure := commandResult(assertion, results)
+	if failure != nil {
+		return *failure
+	}
+	pattern, err := regexp.Compile(assertion.Value)
+	if err != nil {
+		return failedAssertion(assertion, fmt.Sprintf("invalid pattern %q: %v", assertion.Value, err))
+	}
+	name, text := assertionStream(assertion, result)
+	if pattern.MatchString(text) {
+		return passedAssertion(assertion)
+	}
+	return failedAssertion(assertion, fmt.Sprintf("%s does not match %q", name, assertion.Value))
+}
+
+func assertCommandSucceeds(assertion Assertion, results []verify.CommandResult) AssertionResult {
+	result, failure := commandResult(assertion, results)
+	if failure != nil {
+		return *failure
+	}
+	if result.Status == "pass" && result.ExitCode == 0 {
+		return passedAssertion(assertion)
+	}
+	message := fmt.Sprintf("command exited %d", result.ExitCode)
+	if result.TimedOut {
+		message = "command timed out"
+	} else if strings.TrimSpace(result.Error) != "" {
+		message += ": " + redactOutput(result.Error)
+	}
+	return failedAssertion(assertion, message)
+}
+
+func assertExitCodeEquals(assertion Assertion, results []verify.CommandResult) AssertionResult {
+	result, failure := commandResult(assertion, results)
+	if failure != nil {
+		return *failure
+	}
+	want, err := strconv.Atoi(strings.TrimSpace(assertion.Value))
+	if err != nil {
+		return failedAssertion(assertion, fmt.Sprintf("value %q is not an exit code", assertion.Value))
+	}
+	if result.TimedOut {
+		return failedAssertion(assertion, "command timed out")
+	}
+	if result.ExitCode == want {
+		return passedAssertion(assertion)
+	}
+	return failedAssertion(assertion, fmt.Sprintf("command exited %d, want %d", result.ExitCode, want))
+}
+
+func assertStdoutMaximum(assertion Assertion, results []verify.CommandResult) AssertionResult {
+	result, failure := commandResult(assertion, results)
+	if failure != nil {
+		return *failure
+	}
+	if result.StdoutTruncated {
+		return failedAssertion(assertion, truncatedStdoutMessage(result))
+	}
+	metrics := measureText(result.Stdout)
+	actual := 0
+	switch assertion.Type {
+	case "stdout_lines_max":
+		actual = metrics.Lines
+	case "stdout_words_max":
+		actual = metrics.Words
+	case "stdout_estimated_tokens_max":
+		actual = metrics.EstimatedTokens
+	}
+	if actual <= assertion.Max {
+		return passedAssertion(assertion)
+	}
+	return failedAssertion(assertion, fmt.Sprintf("stdout %s %d exceeds maximum %d", strings.TrimPrefix(assertion.Type, "stdout_"), actual, assertion.Max))
+}
+
+func truncatedStdoutMessage(result verify.CommandResult) string {
+	return fmt.Sprintf("stdout was truncated to %d of %d bytes; the assertion needs the complete output", len(result.Stdout), result.StdoutSize)
+}
+
+func commandResult(assertion Assertion, results []verify.CommandResult) (verify.CommandResult, *AssertionResult) {
+	if assertion.CommandIndex < 0 || assertion.CommandIndex >= len(results) {
+		failure := failedAssertion(assertion, "command_index out of range")
+		return verify.CommandResult{}, &failure
+	}
+	return results[assertion.CommandIndex], nil
+}
+
+func passedAssertion(assertion Assertion) AssertionResult {
+	return AssertionResult{Type: assertion.Type, CommandIndex: assertionCommandIndex(assertion), Status: "passed"}
+}
+
+func failedAssertion(assertion Assertion, message string) AssertionResult {
+	return AssertionResult{Type: assertion.Type, CommandIndex: assertionCommandIndex(assertion), Status: "failed", Message: message}
+}
+
+func assertionCommandIndex(assertion Assertion) *int {
+	if !assertionReadsCommand(assertion.Type) {
+		return nil
+	}
+	index := assertion.CommandIndex
+	return &index
+}
+
+// assertionReadsCommand reports whether an assertion type inspects one
+// command result selected by command_index.
+func assertionReadsCommand(assertionType string) bool {
+	switch assertionType {
+	case "git_diff_empty", "file_exists", "file_contains":
+		return false
+	}
+	return true
+}
+
+func firstLine(value string) string {
+	line, _, _ := strings.Cut(strings.TrimSpace(value), "\n")
+	return line
+}
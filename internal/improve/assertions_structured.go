@@ -0,0 +1,158 @@
+package improve
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/jamesonstone/kit/v3/internal/verify"
+)
+
+// jsonPathSegment is one step of a JSON path: an object key, or an array
+// index when index is non-negative.
+type jsonPathSegment struct {
+	key   string
+	index int
+}
+
+// parseJSONPath accepts dotted paths with bracketed array indexes, such as
+// $.evidence[0].path or evidence[0].path.
+func parseJSONPath(path string) ([]jsonPathSegment, error) {
+	trimmed := strings.TrimPrefix(strings.TrimSpace(path), "$")
+	trimmed = strings.TrimPrefix(trimmed, ".")
+	if trimmed == "" {
+		return nil, nil
+	}
+	var segments []jsonPathSegment
+	for _, part := range strings.Split(trimmed, ".") {
+		key, rest, _ := strings.Cut(part, "[")
+		if key == "" && rest == "" {
+			return nil, fmt.Errorf("json path %q has an empty segment", path)
+		}
+		if key != "" {
+			segments = append(segments, jsonPathSegment{key: key, index: -1})
+		}
+		for rest != "" {
+			digits, after, ok := strings.Cut(rest, "]")
+			index, err := strconv.Atoi(digits)
+			if !ok || err != nil || index < 0 {
+				return nil, fmt.Errorf("json path %q has an invalid array index", path)
+			}
+			segments = append(segments, jsonPathSegment{index: index})
+			if after != "" && !strings.HasPrefix(after, "[") {
+				return nil, fmt.Errorf("json path %q has text after an array index", path)
+			}
+			rest = strings.TrimPrefix(after, "[")
+		}
+	}
+	return segments, nil
+}
+
+func lookupJSONPath(document any, segments []jsonPathSegment) (any, bool) {
+	current := document
+	for _, segment := range segments {
+		if segment.index >= 0 {
+			values, ok := current.([]any)
+			if !ok || segment.index >= len(values) {
+				return nil, false
+			}
+			current = values[segment.index]
+			continue
+		}
+		object, ok := current.(map[string]any)
+		if !ok {
+			return nil, false
+		}
+		if current, ok = object[segment.key]; !ok {
+			return nil, false
+		}
+	}
+	return current, true
+}
+
+// assertJSONPath decodes a command's stdout as JSON. json_path_equals compares
+// strings directly and every other value by its compact JSON encoding.
+func assertJSONPath(assertion Assertion, results []verify.CommandResult) AssertionResult {
+	result, failure := commandResult(assertion, results)
+	if failure != nil {
+		return *failure
+	}
+	segments, err := parseJSONPath(assertion.Path)
+	if err != nil {
+		return failedAssertion(assertion, err.Error())
+	}
+	if result.StdoutTruncated {
+		return failedAssertion(assertion, truncatedStdoutMessage(result))
+	}
+	decoder := json.NewDecoder(strings.NewReader(result.Stdout))
+	decoder.UseNumber()
+	var document any
+	if err := decoder.Decode(&document); err != nil {
+		return failedAssertion(assertion, fmt.Sprintf("stdout is not JSON: %v", err))
+	}
+	value, found := lookupJSONPath(document, segments)
+	if !found {
+		return failedAssertion(assertion, fmt.Sprintf("json path %s not found", assertion.Path))
+	}
+	if assertion.Type == "json_path_exists" {
+		return passedAssertion(assertion)
+	}
+	actual, err := jsonValueText(value)
+	if err != nil {
+		return failedAssertion(assertion, err.Error())
+	}
+	if actual == assertion.Value {
+		return passedAssertion(assertion)
+	}
+	return failedAssertion(assertion, fmt.Sprintf("json path %s is %s, want %s", assertion.Path, redactOutput(actual), assertion.Value))
+}
+
+func jsonValueText(value any) (string, error) {
+	if text, ok := value.(string); ok {
+		return text, nil
+	}
+	var buffer bytes.Buffer
+	encoder := json.NewEncoder(&buffer)
+	encoder.SetEscapeHTML(false)
+	if err := encoder.Encode(value); err != nil {
+		return "", err
+	}
+	return strings.TrimSpace(buffer.String()), nil
+}
+
+func assertWorkspaceFile(assertion Assertion, workspace string) AssertionResult {
+	if err := validateWorkspacePath(assertion.Path); err != nil {
+		return failedAssertion(assertion, err.Error())
+	}
+	data, err := os.ReadFile(filepath.Join(workspace, filepath.FromSlash(assertion.Path)))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return failedAssertion(assertion, "file "+assertion.Path+" does not exist")
+		}
+		return failedAssertion(assertion, fmt.Sprintf("read %s: %v", assertion.Path, err))
+	}
+	if assertion.Type == "file_exists" || strings.Contains(string(data), assertion.Value) {
+		return passedAssertion(assertion)
+	}
+	return failedAssertion(assertion, fmt.Sprintf("file %s missing %q", assertion.Path, assertion.Value))
+}
+
+// validateWorkspacePath keeps file assertions inside the disposable task
+// workspace.
+func validateWorkspacePath(path string) error {
+	if strings.TrimSpace(path) == "" {
+		return fmt.Errorf("path is required")
+	}
+	if filepath.IsAbs(path) || strings.HasPrefix(path, "/") {
+		return fmt.Errorf("path %q must be relative to the task workspace", path)
+	}
+	clean := filepath.ToSlash(filepath.Clean(filepath.FromSlash(path)))
+	if clean == ".." || strings.HasPrefix(clean, "../") {
+		return fmt.Errorf("path %q escapes the task workspace", path)
+	}
+	return nil
+}
@@ -0,0 +1,99 @@
+package improve
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/jamesonstone/kit/v3/internal/verify"
+)
+
+func TestEvaluateAssertionsChecksStructureStreamsAndFiles(t *testing.T) {
+	workspace := t.TempDir()
+	if err := os.MkdirAll(filepath.Join(workspace, "docs"), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(workspace, "docs", "SPEC.md"), []byte("# SPEC\n\n## VALIDATION\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	results := []verify.CommandResult{
+		{Status: "pass", Stdout: `{"schema_version":"kit.context/v1","blocked":false,"evidence":[{"path":"docs/SPEC.md","tokens":12}]}`},
+		{Status: "fail", ExitCode: 2, Stderr: "error: kit project not initialized\n"},
+	}
+	task := Task{Assertions: []Assertion{
+		{Type: "json_path_equals", Path: "$.schema_version", Value: "kit.context/v1"},
+		{Type: "json_path_equals", Path: "evidence[0].tokens", Value: "12"},
+		{Type: "json_path_equals", Path: "blocked", Value: "false"},
+		{Type: "json_path_exists", Path: "evidence[0].path"},
+		{Type: "json_path_exists", Path: "evidence[1]"},
+		{Type: "stdout_matches", Value: `"tokens":\d+`},
+		{Type: "stderr_empty"},
+		{Type: "exit_code_equals", CommandIndex: 1, Value: "2"},
+		{Type: "stderr_contains", CommandIndex: 1, Value: "not initialized"},
+		{Type: "stderr_matches", CommandIndex: 1, Value: `^warning`},
+		{Type: "file_exists", Path: "docs/SPEC.md"},
+		{Type: "file_contains", Path: "docs/SPEC.md", Value: "## VALIDATION"},
+		{Type: "file_exists", Path: "docs/PLAN.md"},
+	}}
+	assertions := evaluateAssertions(task, workspace, results, nil)
+	failing := map[int]string{4: "json path evidence[1] not found", 9: `stderr does not match "^warning"`, 12: "file docs/PLAN.md does not exist"}
+	for index, assertion := range assertions {
+		want, shouldFail := failing[index]
+		if !shouldFail && assertion.Status != "passed" {
+			t.Fatalf("assertion %d (%s) = %#v, want passed", index, assertion.Type, assertion)
+		}
+		if shouldFail && (assertion.Status != "failed" || assertion.Message != want) {
+			t.Fatalf("assertion %d (%s) = %#v, want failure %q", index, assertion.Type, assertion, want)
+		}
+	}
+	if assertions[10].CommandIndex != nil {
+		t.Fatalf("file assertion carries a command index: %#v", assertions[10])
+	}
+}
+
+func TestValidateAssertionRejectsMalformedStructuredAssertions(t *testing.T) {
+	for _, tc := range []struct {
+		assertion Assertion
+		want      string
+	}{
+		{Assertion{Type: "json_path_exists"}, "path is required"},
+		{Assertion{Type: "json_path_equals", Path: "evidence[x]"}, "invalid array index"},
+		{Assertion{Type: "stdout_matches", Value: "("}, "valid regular expression"},
+		{Assertion{Type: "exit_code_equals", Value: "two"}, "integer exit code"},
+		{Assertion{Type: "file_contains", Path: "../outside.txt", Value: "x"}, "escapes the task workspace"},
+		{Assertion{Type: "file_exists", Path: "/etc/passwd"}, "relative to the task workspace"},
+		{Assertion{Type: "stderr_contains", CommandIndex: 3, Value: "x"}, "outside 1 commands"},
+	} {
+		err := validateAssertion(tc.assertion, 1)
+		if err == nil || !strings.Contains(err.Error(), tc.want) {
+			t.Fatalf("validateAssertion(%+v) = %v, want %q", tc.assertion, err, tc.want)
+		}
+	}
+	if err := validateAssertion(Assertion{Type: "file_exists", Path: "docs/SPEC.md"}, 0); err != nil {
+		t.Fatalf("file assertion without commands = %v", err)
+	}
+}
+
+func TestJSONPathFailureRedactsActualValue(t *testing.T) {
+	token := "ghp_" + strings.Repeat("a", 36)
+	results := []verify.CommandResult{{Status: "pass", Stdout: `{"token":"` + token + `"}`}}
+	task := Task{Assertions: []Assertion{{Type: "json_path_equals", Path: "token", Value: "expected"}}}
+	assertion := evaluateAssertions(task, t.TempDir(), results, nil)[0]
+	if assertion.Status != "failed" || strings.Contains(assertion.Message, token) || !strings.Contains(assertion.Message, "[REDACTED]") {
+		t.Fatalf("json path failure = %#v", assertion)
+	}
+}
+
+func TestStdoutAssertionsFailOnTruncatedOutput(t *testing.T) {
+	results := []verify.CommandResult{{Status: "pass", Stdout: `{"blocked":false}`, StdoutSize: verify.DefaultOutputLimit + 1, StdoutTruncated: true}}
+	task := Task{Assertions: []Assertion{
+		{Type: "json_path_equals", Path: "blocked", Value: "false"},
+		{Type: "stdout_estimated_tokens_max", Max: 1 << 30},
+	}}
+	for _, assertion := range evaluateAssertions(task, t.TempDir(), results, nil) {
+		if assertion.Status != "failed" || !strings.Contains(assertion.Message, "stdout was truncated") {
+			t.Fatalf("truncated stdout assertion = %#v", assertion)
+		}
+	}
+}
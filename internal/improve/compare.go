@@ -0,0 +1,254 @@
+package improve
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+// CompareThresholds bound how far a candidate run may regress from its
+// baseline. Rates and growth are fractions, so 0.1 allows a 10% change.
+type CompareThresholds struct {
+	MaxSuccessRateDrop float64 `json:"max_success_rate_drop"`
+	MaxDeterminismDrop float64 `json:"max_determinism_drop"`
+	MaxTokenGrowth     float64 `json:"max_token_growth"`
+	AllowNewFailures   bool    `json:"allow_new_failures"`
+}
+
+type Comparison struct {
+	SchemaVersion          int                   `json:"schema_version"`
+	Kind                   string                `json:"kind"`
+	BaselineRunID          string                `json:"baseline_run_id"`
+	CandidateRunID         string                `json:"candidate_run_id"`
+	Thresholds             CompareThresholds     `json:"thresholds"`
+	TaskSuccessRate        MetricDelta           `json:"task_success_rate"`
+	DeterminismRate        MetricDelta           `json:"determinism_rate"`
+	StdoutEstimatedTokens  MetricDelta           `json:"stdout_estimated_tokens"`
+	Tasks                  []TaskDelta           `json:"tasks"`
+	NewlyFailingAssertions []AssertionRegression `json:"newly_failing_assertions"`
+	Regressions            []string              `json:"regressions"`
+	Status                 string                `json:"status"`
+}
+
+type MetricDelta struct {
+	Baseline  float64 `json:"baseline"`
+	Candidate float64 `json:"candidate"`
+	Delta     float64 `json:"delta"`
+}
+
+// TaskDelta compares one task across both runs. The tokens max fields hold the
+// largest estimated stdout token count of any command in any repeat; growth is
+// a fraction of the baseline and stays 0 when the baseline printed nothing.
+type TaskDelta struct {
+	TaskID             string  `json:"task_id"`
+	Status             string  `json:"status"`
+	BaselinePassed     int     `json:"baseline_passed"`
+	BaselineRuns       int     `json:"baseline_runs"`
+	CandidatePassed    int     `json:"candidate_passed"`
+	CandidateRuns      int     `json:"candidate_runs"`
+	BaselineTokensMax  int     `json:"baseline_stdout_estimated_tokens_max"`
+	CandidateTokensMax int     `json:"candidate_stdout_estimated_tokens_max"`
+	TokenGrowth        float64 `json:"stdout_estimated_tokens_growth"`
+	DeterminismChange  string  `json:"determinism_change,omitempty"`
+}
+
+type AssertionRegression struct {
+	TaskID  string `json:"task_id"`
+	Index   int    `json:"index"`
+	Type    string `json:"type"`
+	Message string `json:"message,omitempty"`
+}
+
+type taskSummary struct {
+	runs, passed, tokensMax int
+	outputs                 map[string]struct{}
+	assertionTypes          map[int]string
+	assertionFailed         map[int]string
+}
+
+// ResolveRunDir maps a run reference to a run directory: an existing
+// directory path, "latest", or a run ID under .kit/improve/runs.
+func ResolveRunDir(projectRoot, ref string) string {
+	if strings.TrimSpace(ref) == "" || ref == "latest" {
+		return resolveArtifactDir(projectRoot, "")
+	}
+	if info, err := os.Stat(resolveArtifactDir(projectRoot, ref)); err == nil && info.IsDir() {
+		return resolveArtifactDir(projectRoot, ref)
+	}
+	return filepath.Join(artifactRoot(projectRoot), "runs", ref)
+}
+
+func Compare(projectRoot, baselineRef, candidateRef string, thresholds CompareThresholds) (Comparison, error) {
+	baseline, err := readRunManifest(filepath.Join(ResolveRunDir(projectRoot, baselineRef), "run.json"))
+	if err != nil {
+		return Comparison{}, fmt.Errorf("read baseline run: %w", err)
+	}
+	candidate, err := readRunManifest(filepath.Join(ResolveRunDir(projectRoot, candidateRef), "run.json"))
+	if err != nil {
+		return Comparison{}, fmt.Errorf("read candidate run: %w", err)
+	}
+	return CompareRuns(baseline, candidate, thresholds), nil
+}
+
+// CompareRuns reports per-task deltas between two run manifests and lists
+// every threshold the candidate breaks; Status is "regressed" when any do.
+func CompareRuns(baseline, candidate RunManifest, thresholds CompareThresholds) Comparison {
+	comparison := Comparison{
+		SchemaVersion:          SchemaVersion,
+		Kind:                   "improve_comparison",
+		BaselineRunID:          baseline.RunID,
+		CandidateRunID:         candidate.RunID,
+		Thresholds:             thresholds,
+		TaskSuccessRate:        metricDelta(baseline.Metrics.TaskSuccessRate, candidate.Metrics.TaskSuccessRate),
+		DeterminismRate:        metricDelta(baseline.Metrics.DeterminismRate, candidate.Metrics.DeterminismRate),
+		StdoutEstimatedTokens:  metricDelta(float64(baseline.Metrics.Stdout.EstimatedTokens), float64(candidate.Metrics.Stdout.EstimatedTokens)),
+		Tasks:                  []TaskDelta{},
+		NewlyFailingAssertions: []AssertionRegression{},
+		Regressions:            []string{},
+		Status:                 "pass",
+	}
+	before, after := summarizeTasks(baseline.Traces), summarizeTasks(candidate.Traces)
+	for _, taskID := range unionKeys(before, after) {
+		delta := compareTask(taskID, before[taskID], after[taskID])
+		comparison.Tasks = append(comparison.Tasks, delta)
+		if delta.Status == "regressed" && !thresholds.AllowNewFailures {
+			comparison.addRegression("task %s regressed: %d/%d passed, was %d/%d", taskID, delta.CandidatePassed, delta.CandidateRuns, delta.BaselinePassed, delta.BaselineRuns)
+		}
+		if delta.BaselineTokensMax > 0 && delta.CandidateRuns > 0 && delta.TokenGrowth > thresholds.MaxTokenGrowth {
+			comparison.addRegression("task %s stdout estimated tokens max grew %.1f%% (%d -> %d), over %.1f%%", taskID, delta.TokenGrowth*100, delta.BaselineTokensMax, delta.CandidateTokensMax, thresholds.MaxTokenGrowth*100)
+		}
+		if before[taskID] == nil || after[taskID] == nil {
+			continue
+		}
+		for _, index := range sortedIndexes(after[taskID].assertionFailed) {
+			if _, failedBefore := before[taskID].assertionFailed[index]; failedBefore {
+				continue
+			}
+			if _, existed := before[taskID].assertionTypes[index]; !existed {
+				continue
+			}
+			comparison.NewlyFailingAssertions = append(comparison.NewlyFailingAssertions, AssertionRegression{
+				TaskID:  taskID,
+				Index:   index,
+				Type:    after[taskID].assertionTypes[index],
+				Message: after[taskID].assertionFailed[index],
+			})
+		}
+	}
+	if count := len(comparison.NewlyFailingAssertions); count > 0 && !thresholds.AllowNewFailures {
+		comparison.addRegression("%d assertion(s) newly failing", count)
+	}
+	if drop := -comparison.TaskSuccessRate.Delta; drop > thresholds.MaxSuccessRateDrop {
+		comparison.addRegression("task success rate dropped %.3f, over %.3f", drop, thresholds.MaxSuccessRateDrop)
+	}
+	if drop := -comparison.DeterminismRate.Delta; drop > thresholds.MaxDeterminismDrop {
+		comparison.addRegression("determinism rate dropped %.3f, over %.3f", drop, thresholds.MaxDeterminismDrop)
+	}
+	return comparison
+}
+
+func (c *Comparison) addRegression(format string, args ...any) {
+	c.Regressions = append(c.Regressions, fmt.Sprintf(format, args...))
+	c.Status = "regressed"
+}
+
+func summarizeTasks(traces []Trace) map[string]*taskSummary {
+	summaries := map[string]*taskSummary{}
+	for _, trace := range traces {
+		summary := summaries[trace.TaskID]
+		if summary == nil {
+			summary = &taskSummary{outputs: map[string]struct{}{}, assertionTypes: map[int]string{}, assertionFailed: map[int]string{}}
+			summaries[trace.TaskID] = summary
+		}
+		summary.runs++
+		if trace.Status == "passed" {
+			summary.passed++
+		}
+		var hashes []string
+		for _, command := range trace.Commands {
+			summary.tokensMax = max(summary.tokensMax, command.Stdout.EstimatedTokens)
+			hashes = append(hashes, command.StdoutSHA256)
+		}
+		summary.outputs[strings.Join(hashes, ":")] = struct{}{}
+		for index, assertion := range trace.Assertions {
+			summary.assertionTypes[index] = assertion.Type
+			if assertion.Status != "passed" {
+				summary.assertionFailed[index] = assertion.Message
+			}
+		}
+	}
+	return summaries
+}
+
+func compareTask(taskID string, before, after *taskSummary) TaskDelta {
+	delta := TaskDelta{TaskID: taskID}
+	switch {
+	case before == nil:
+		delta.Status = "added"
+	case after == nil:
+		delta.Status = "removed"
+	}
+	if before != nil {
+		delta.BaselineRuns, delta.BaselinePassed, delta.BaselineTokensMax = before.runs, before.passed, before.tokensMax
+	}
+	if after != nil {
+		delta.CandidateRuns, delta.CandidatePassed, delta.CandidateTokensMax = after.runs, after.passed, after.tokensMax
+	}
+	if delta.Status != "" {
+		return delta
+	}
+	if delta.BaselineTokensMax > 0 {
+		delta.TokenGrowth = float64(delta.CandidateTokensMax-delta.BaselineTokensMax) / float64(delta.BaselineTokensMax)
+	}
+	beforeRate := float64(before.passed) / float64(before.runs)
+	afterRate := float64(after.passed) / float64(after.runs)
+	switch {
+	case afterRate < beforeRate:
+		delta.Status = "regressed"
+	case afterRate > beforeRate:
+		delta.Status = "improved"
+	default:
+		delta.Status = "unchanged"
+	}
+	if before.runs > 1 && after.runs > 1 {
+		stableBefore, stableAfter := len(before.outputs) == 1, len(after.outputs) == 1
+		switch {
+		case stableBefore && !stableAfter:
+			delta.DeterminismChange = "lost"
+		case !stableBefore && stableAfter:
+			delta.DeterminismChange = "gained"
+		}
+	}
+	return delta
+}
+
+func metricDelta(baseline, candidate float64) MetricDelta {
+	return MetricDelta{Baseline: baseline, Candidate: candidate, Delta: candidate - baseline}
+}
+
+func unionKeys(left, right map[string]*taskSummary) []string {
+	seen := map[string]struct{}{}
+	for key := range left {
+		seen[key] = struct{}{}
+	}
+	for key := range right {
+		seen[key] = struct{}{}
+	}
+	keys := make([]string, 0, len(seen))
+	for key := range seen {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+func sortedIndexes(values map[int]string) []int {
+	indexes := make([]int, 0, len(values))
+	for index := range values {
+		indexes = append(indexes, index)
+	}
+	sort.Ints(indexes)
+	return indexes
+}
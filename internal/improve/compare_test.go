@@ -0,0 +1,88 @@
+package improve
+
+import (
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestCompareRunsReportsTaskDeltasAndRegressions(t *testing.T) {
+	trace := func(task, status, hash string, tokens int, assertions ...AssertionResult) Trace {
+		return Trace{
+			TaskID:     task,
+			Status:     status,
+			Commands:   []CommandTrace{{StdoutSHA256: hash, Stdout: TextMetrics{EstimatedTokens: tokens}}},
+			Assertions: assertions,
+		}
+	}
+	passed := AssertionResult{Type: "stdout_contains", Status: "passed"}
+	failed := AssertionResult{Type: "stdout_contains", Status: "failed", Message: "stdout missing marker"}
+	baseline := RunManifest{RunID: "base", Traces: []Trace{
+		trace("alpha", "passed", "a", 100, passed),
+		trace("alpha", "passed", "a", 100, passed),
+		trace("beta", "passed", "b", 50),
+	}}
+	candidate := RunManifest{RunID: "cand", Traces: []Trace{
+		trace("alpha", "failed", "a", 100, failed),
+		trace("alpha", "passed", "c", 100, passed),
+		trace("beta", "passed", "b", 80),
+		trace("gamma", "passed", "g", 10),
+	}}
+	baseline.Metrics, candidate.Metrics = summarizeRun(baseline.Traces), summarizeRun(candidate.Traces)
+
+	comparison := CompareRuns(baseline, candidate, CompareThresholds{MaxTokenGrowth: 0.5})
+	if comparison.Status != "regressed" || len(comparison.Tasks) != 3 {
+		t.Fatalf("comparison = %+v", comparison)
+	}
+	alpha, beta, gamma := comparison.Tasks[0], comparison.Tasks[1], comparison.Tasks[2]
+	if alpha.Status != "regressed" || alpha.CandidatePassed != 1 || alpha.DeterminismChange != "lost" {
+		t.Fatalf("alpha delta = %+v", alpha)
+	}
+	if beta.Status != "unchanged" || beta.TokenGrowth != 0.6 {
+		t.Fatalf("beta delta = %+v", beta)
+	}
+	if gamma.Status != "added" {
+		t.Fatalf("gamma delta = %+v", gamma)
+	}
+	if len(comparison.NewlyFailingAssertions) != 1 || comparison.NewlyFailingAssertions[0].Message != "stdout missing marker" {
+		t.Fatalf("newly failing assertions = %+v", comparison.NewlyFailingAssertions)
+	}
+	joined := strings.Join(comparison.Regressions, "\n")
+	for _, want := range []string{"task alpha regressed", "task beta stdout estimated tokens max grew 60.0%", "1 assertion(s) newly failing", "determinism rate dropped"} {
+		if !strings.Contains(joined, want) {
+			t.Fatalf("regressions missing %q:\n%s", want, joined)
+		}
+	}
+
+	lenient := CompareRuns(baseline, candidate, CompareThresholds{MaxTokenGrowth: 1, MaxSuccessRateDrop: 1, MaxDeterminismDrop: 1, AllowNewFailures: true})
+	if lenient.Status != "pass" || len(lenient.Regressions) != 0 {
+		t.Fatalf("lenient comparison = %+v", lenient)
+	}
+}
+
+func TestCompareRunsSkipsTokenGrowthFromZeroBaseline(t *testing.T) {
+	trace := func(tokens int) Trace {
+		return Trace{TaskID: "quiet", Status: "passed", Commands: []CommandTrace{{StdoutSHA256: "q", Stdout: TextMetrics{EstimatedTokens: tokens}}}}
+	}
+	baseline := RunManifest{RunID: "base", Traces: []Trace{trace(0)}}
+	candidate := RunManifest{RunID: "cand", Traces: []Trace{trace(3)}}
+	baseline.Metrics, candidate.Metrics = summarizeRun(baseline.Traces), summarizeRun(candidate.Traces)
+
+	comparison := CompareRuns(baseline, candidate, CompareThresholds{MaxTokenGrowth: 0.1})
+	if comparison.Status != "pass" || comparison.Tasks[0].TokenGrowth != 0 || comparison.Tasks[0].CandidateTokensMax != 3 {
+		t.Fatalf("zero-baseline comparison = %+v", comparison)
+	}
+}
+
+func TestResolveRunDirAcceptsIDsAndPaths(t *testing.T) {
+	root := t.TempDir()
+	if got, want := ResolveRunDir(root, "run-1"), filepath.Join(root, ArtifactDir, "runs", "run-1"); got != want {
+		t.Fatalf("ResolveRunDir(id) = %q, want %q", got, want)
+	}
+	if got, want := ResolveRunDir(root, "latest"), filepath.Join(root, ArtifactDir, "latest"); got != want {
+		t.Fatalf("ResolveRunDir(latest) = %q, want %q", got, want)
+	}
+	if got := ResolveRunDir(root, root); got != root {
+		t.Fatalf("ResolveRunDir(dir) = %q, want %q", got, root)
+	}
+}
@@ -18,7 +18,7 @@ func TestEvaluateAssertionsReportsOutputMetricsAndActualCause(t *testing.T) {
 		{Type: "stdout_contains", CommandIndex: 0, Value: "missing"},
 		{Type: "stdout_words_max", CommandIndex: 0, Max: 3},
 	}}
-	assertions := evaluateAssertions(task, results, nil)
+	assertions := evaluateAssertions(task, "", results, nil)
 	if assertions[1].Status != "failed" {
 		t.Fatalf("missing output assertion = %#v", assertions[1])
 	}
@@ -121,7 +121,7 @@ func TestAssertionResultPersistsZeroCommandIndex(t *testing.T) {
 
 func TestLoadPromptSystemSuiteExercisesAgentContractSurfaces(t *testing.T) {
 	root := fixtureProjectRoot(t)
-	suite, tasks, err := LoadSuite(root, "prompt-system")
+	suite, tasks, err := LoadSuite(root, "prompt-system", SplitHeldIn)
 	if err != nil {
 		t.Fatalf("LoadSuite(prompt-system) error = %v", err)
 	}
@@ -174,6 +174,8 @@ func fakeKitBinary(t *testing.T) string {
 	return path
 }
 
+// fixtureProjectRoot copies the repository's eval suites, tasks, and fixtures
+// into a temporary project so run artifacts never land in the source tree.
 func fixtureProjectRoot(t *testing.T) string {
 	t.Helper()
 	wd, err := os.Getwd()
@@ -182,7 +184,7 @@ func fixtureProjectRoot(t *testing.T) string {
 	}
 	for {
 		if _, err := os.Stat(filepath.Join(wd, "go.mod")); err == nil {
-			return wd
+			break
 		}
 		next := filepath.Dir(wd)
 		if next == wd {
@@ -190,4 +192,9 @@ func fixtureProjectRoot(t *testing.T) string {
 		}
 		wd = next
 	}
+	root := t.TempDir()
+	if err := copyDir(filepath.Join(wd, EvalDir), filepath.Join(root, EvalDir)); err != nil {
+		t.Fatalf("copy eval fixtures: %v", err)
+	}
+	return root
 }
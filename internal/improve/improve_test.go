@@ -10,7 +10,7 @@ import (
 
 func TestLoadSuiteSelectsDefaultTasks(t *testing.T) {
 	root := fixtureProjectRoot(t)
-	suite, tasks, err := LoadSuite(root, "default")
+	suite, tasks, err := LoadSuite(root, "default", "")
 	if err != nil {
 		t.Fatalf("LoadSuite() error = %v", err)
 	}
@@ -34,7 +34,7 @@ func TestSelectTasksExcludesHeldOutWhenTagsOverlap(t *testing.T) {
 		{ID: "held-in", RegressionTags: []string{"held-in"}},
 		{ID: "overlap", RegressionTags: []string{"shared"}},
 	}
-	selected := selectTasks(suite, tasks)
+	selected := selectTasks(suite, tasks, SplitHeldIn)
 	if len(selected) != 1 || selected[0].ID != "held-in" {
 		t.Fatalf("selected = %#v, want only held-in", selected)
 	}
@@ -51,7 +51,7 @@ func TestSelectTasksWithEmptyHeldInStillExcludesHeldOut(t *testing.T) {
 		{ID: "candidate", RegressionTags: []string{"review-loop"}},
 		{ID: "hidden", RegressionTags: []string{"held-out"}},
 	}
-	selected := selectTasks(suite, tasks)
+	selected := selectTasks(suite, tasks, SplitHeldIn)
 	if len(selected) != 1 || selected[0].ID != "candidate" {
 		t.Fatalf("selected = %#v, want only candidate", selected)
 	}
@@ -131,7 +131,7 @@ func TestMineProposeValidateReport(t *testing.T) {
 	if len(candidates) != 1 || candidates[0].Status != "proposed" {
 		t.Fatalf("unexpected candidates: %#v", candidates)
 	}
-	scorecard, err := Validate(root, filepath.Join(manifest.RunDir, "candidates", "candidate-001", "candidate.json"))
+	scorecard, err := Validate(root, filepath.Join(manifest.RunDir, "candidates", "candidate-001", "candidate.json"), "")
 	if err != nil {
 		t.Fatalf("Validate() error = %v", err)
 	}
@@ -162,7 +162,7 @@ func TestValidateRejectsIncompleteCandidateMetadata(t *testing.T) {
 	if err := os.WriteFile(path, []byte(`{"status":"proposed"}`), 0o644); err != nil {
 		t.Fatalf("write candidate: %v", err)
 	}
-	_, err := Validate(t.TempDir(), path)
+	_, err := Validate(t.TempDir(), path, "")
 	if err == nil {
 		t.Fatal("Validate() error = nil, want incomplete metadata error")
 	}
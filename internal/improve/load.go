@@ -5,7 +5,9 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"regexp"
 	"sort"
+	"strconv"
 	"strings"
 
 	"gopkg.in/yaml.v3"
@@ -16,7 +18,10 @@ const (
 	ArtifactDir = ".kit/improve"
 )
 
-func LoadSuite(projectRoot, name string) (Suite, []Task, error) {
+// LoadSuite loads a suite and the tasks in the requested split, which
+// defaults to held-in. minimum_tasks applies to the held-in and all splits; a
+// held-out run needs at least one eligible task.
+func LoadSuite(projectRoot, name, split string) (Suite, []Task, error) {
 	if strings.TrimSpace(name) == "" {
 		name = "default"
 	}
@@ -36,8 +41,15 @@ func LoadSuite(projectRoot, name string) (Suite, []Task, error) {
 	if err != nil {
 		return Suite{}, nil, err
 	}
-	selected := selectTasks(suite, tasks)
-	if len(selected) < suite.MinimumTasks {
+	if err := ValidateSplit(split); err != nil {
+		return Suite{}, nil, err
+	}
+	selected := selectTasks(suite, tasks, split)
+	if normalizeSplit(split) == SplitHeldOut {
+		if len(selected) == 0 {
+			return Suite{}, nil, fmt.Errorf("suite %q has no held-out eligible tasks", suite.ID)
+		}
+	} else if len(selected) < suite.MinimumTasks {
 		return Suite{}, nil, fmt.Errorf("suite %q selected %d tasks, minimum is %d", suite.ID, len(selected), suite.MinimumTasks)
 	}
 	return suite, selected, nil
@@ -126,28 +138,58 @@ func validateTask(task Task) error {
 func validateAssertion(assertion Assertion, commandCount int) error {
 	supported := map[string]bool{
 		"command_succeeds":            true,
+		"exit_code_equals":            true,
 		"git_diff_empty":              true,
 		"stdout_contains":             true,
 		"stdout_not_contains":         true,
 		"stdout_nonempty":             true,
+		"stdout_matches":              true,
 		"stdout_lines_max":            true,
 		"stdout_words_max":            true,
 		"stdout_estimated_tokens_max": true,
+		"stderr_contains":             true,
+		"stderr_not_contains":         true,
+		"stderr_nonempty":             true,
+		"stderr_empty":                true,
+		"stderr_matches":              true,
+		"json_path_exists":            true,
+		"json_path_equals":            true,
+		"file_exists":                 true,
+		"file_contains":               true,
 	}
 	if !supported[assertion.Type] {
 		return fmt.Errorf("unsupported assertion type %q", assertion.Type)
 	}
-	if assertion.Type == "git_diff_empty" {
-		return nil
-	}
-	if assertion.CommandIndex < 0 || assertion.CommandIndex >= commandCount {
+	if assertionReadsCommand(assertion.Type) && (assertion.CommandIndex < 0 || assertion.CommandIndex >= commandCount) {
 		return fmt.Errorf("command_index %d is outside %d commands", assertion.CommandIndex, commandCount)
 	}
 	switch assertion.Type {
-	case "stdout_contains", "stdout_not_contains":
+	case "stdout_contains", "stdout_not_contains", "stderr_contains", "stderr_not_contains":
 		if assertion.Value == "" {
 			return fmt.Errorf("value is required for %s", assertion.Type)
 		}
+	case "stdout_matches", "stderr_matches":
+		if _, err := regexp.Compile(assertion.Value); assertion.Value == "" || err != nil {
+			return fmt.Errorf("value must be a valid regular expression for %s", assertion.Type)
+		}
+	case "exit_code_equals":
+		if _, err := strconv.Atoi(strings.TrimSpace(assertion.Value)); err != nil {
+			return fmt.Errorf("value must be an integer exit code for %s", assertion.Type)
+		}
+	case "json_path_exists", "json_path_equals":
+		if strings.TrimSpace(assertion.Path) == "" {
+			return fmt.Errorf("path is required for %s", assertion.Type)
+		}
+		if _, err := parseJSONPath(assertion.Path); err != nil {
+			return err
+		}
+	case "file_exists", "file_contains":
+		if err := validateWorkspacePath(assertion.Path); err != nil {
+			return fmt.Errorf("%s: %w", assertion.Type, err)
+		}
+		if assertion.Type == "file_contains" && assertion.Value == "" {
+			return fmt.Errorf("value is required for %s", assertion.Type)
+		}
 	case "stdout_lines_max", "stdout_words_max", "stdout_estimated_tokens_max":
 		if assertion.Max <= 0 {
 			return fmt.Errorf("max must be positive for %s", assertion.Type)
@@ -156,13 +198,12 @@ func validateAssertion(assertion Assertion, commandCount int) error {
 	return nil
 }
 
-func selectTasks(suite Suite, tasks []Task) []Task {
+func selectTasks(suite Suite, tasks []Task, split string) []Task {
+	split = normalizeSplit(split)
 	var selected []Task
 	for _, task := range tasks {
-		if taskMatchesSelector(task, suite.HeldOut) {
-			continue
-		}
-		if len(suite.HeldIn.IncludeTags) > 0 && !taskMatchesSelector(task, suite.HeldIn) {
+		taskSplit := suiteTaskSplit(suite, task)
+		if taskSplit == "" || (split != SplitAll && split != taskSplit) {
 			continue
 		}
 		selected = append(selected, task)
@@ -189,3 +230,27 @@ func taskMatchesSelector(task Task, selector TaskSelector) bool {
 func artifactRoot(projectRoot string) string {
 	return filepath.Join(projectRoot, ArtifactDir)
 }
+
+func resolveArtifactDir(projectRoot, from string) string {
+	if strings.TrimSpace(from) == "" {
+		return filepath.Join(artifactRoot(projectRoot), "latest")
+	}
+	if filepath.IsAbs(from) {
+		return from
+	}
+	return filepath.Join(projectRoot, from)
+}
+
+// CandidatePath resolves a candidate reference to its candidate.json. A
+// reference naming a JSON file or containing a path separator is used as a
+// project-relative or absolute path; anything else is a candidate ID written
+// by Propose under the from artifact directory.
+func CandidatePath(projectRoot, from, candidate string) string {
+	if strings.HasSuffix(candidate, ".json") || strings.ContainsAny(candidate, `/\`) {
+		if filepath.IsAbs(candidate) {
+			return candidate
+		}
+		return filepath.Join(projectRoot, candidate)
+	}
+	return filepath.Join(resolveArtifactDir(projectRoot, from), "candidates", candidate, "candidate.json")
+}
@@ -0,0 +1,59 @@
+package improve
+
+import "strings"
+
+func summarizeRun(traces []Trace) RunMetrics {
+	metrics := RunMetrics{TaskRuns: len(traces)}
+	outputsByTask := map[string]map[string]struct{}{}
+	for _, trace := range traces {
+		if trace.Status == "passed" {
+			metrics.PassedTaskRuns++
+		} else {
+			metrics.FailedTaskRuns++
+		}
+		for _, assertion := range trace.Assertions {
+			metrics.Assertions++
+			if assertion.Status == "passed" {
+				metrics.PassedAssertions++
+			} else {
+				metrics.FailedAssertions++
+			}
+		}
+		var traceOutputHashes []string
+		for _, command := range trace.Commands {
+			metrics.CommandDurationMS += command.DurationMS
+			metrics.Stdout.Lines += command.Stdout.Lines
+			metrics.Stdout.Words += command.Stdout.Words
+			metrics.Stdout.Bytes += command.Stdout.Bytes
+			metrics.Stdout.EstimatedTokens += command.Stdout.EstimatedTokens
+			traceOutputHashes = append(traceOutputHashes, command.StdoutSHA256)
+		}
+		if outputsByTask[trace.TaskID] == nil {
+			outputsByTask[trace.TaskID] = map[string]struct{}{}
+		}
+		outputsByTask[trace.TaskID][strings.Join(traceOutputHashes, ":")] = struct{}{}
+	}
+	repeatsByTask := map[string]int{}
+	for _, trace := range traces {
+		repeatsByTask[trace.TaskID]++
+	}
+	for taskID, repeats := range repeatsByTask {
+		if repeats < 2 {
+			continue
+		}
+		metrics.RepeatedTasks++
+		if len(outputsByTask[taskID]) == 1 {
+			metrics.StableRepeatedTasks++
+		}
+	}
+	if metrics.TaskRuns > 0 {
+		metrics.TaskSuccessRate = float64(metrics.PassedTaskRuns) / float64(metrics.TaskRuns)
+	}
+	if metrics.Assertions > 0 {
+		metrics.OutputCompleteness = float64(metrics.PassedAssertions) / float64(metrics.Assertions)
+	}
+	if metrics.RepeatedTasks > 0 {
+		metrics.DeterminismRate = float64(metrics.StableRepeatedTasks) / float64(metrics.RepeatedTasks)
+	}
+	return metrics
+}
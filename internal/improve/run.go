@@ -2,10 +2,12 @@ package improve
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"os"
 	"path/filepath"
 	"strings"
+	"sync"
 	"time"
 
 	"github.com/jamesonstone/kit/v3/internal/verify"
@@ -19,10 +21,15 @@ type RunOptions struct {
 	KitBinary    string
 	KitVersion   string
 	GitCommit    string
+	// Parallel bounds concurrently running task repeats; values below 1 run
+	// serially. Traces keep suite order either way.
+	Parallel int
+	// Split selects held-in, held-out, or all suite tasks; empty is held-in.
+	Split string
 }
 
 func Run(ctx context.Context, opts RunOptions) (RunManifest, error) {
-	suite, tasks, err := LoadSuite(opts.ProjectRoot, opts.SuiteName)
+	suite, tasks, err := LoadSuite(opts.ProjectRoot, opts.SuiteName, opts.Split)
 	if err != nil {
 		return RunManifest{}, err
 	}
@@ -42,6 +49,8 @@ func Run(ctx context.Context, opts RunOptions) (RunManifest, error) {
 		StartedAt:     start,
 		Status:        "pass",
 		RunDir:        runDir,
+		Split:         normalizeSplit(opts.Split),
+		Parallel:      opts.Parallel,
 		Provenance:    provenance,
 	}
 	if opts.DryRun {
@@ -52,18 +61,16 @@ func Run(ctx context.Context, opts RunOptions) (RunManifest, error) {
 	if err := os.MkdirAll(filepath.Join(runDir, "traces"), 0o755); err != nil {
 		return RunManifest{}, err
 	}
-	for repeat := 1; repeat <= suite.Repeat; repeat++ {
-		for _, task := range tasks {
-			trace, err := runTask(ctx, opts, suite.ID, runDir, task, repeat)
-			if err != nil {
-				return RunManifest{}, err
-			}
-			manifest.Traces = append(manifest.Traces, trace)
-			if trace.Status != "passed" {
-				manifest.Status = "failed"
-			}
+	traces, err := runTasks(ctx, opts, suite, runDir, tasks)
+	if err != nil {
+		return RunManifest{}, err
+	}
+	for _, trace := range traces {
+		if trace.Status != "passed" {
+			manifest.Status = "failed"
 		}
 	}
+	manifest.Traces = traces
 	manifest.EndedAt = time.Now().UTC()
 	manifest.Metrics = summarizeRun(manifest.Traces)
 	if err := writeJSON(filepath.Join(runDir, "run.json"), manifest); err != nil {
@@ -73,7 +80,51 @@ func Run(ctx context.Context, opts RunOptions) (RunManifest, error) {
 	return manifest, nil
 }
 
-func runTask(ctx context.Context, opts RunOptions, suiteID, runDir string, task Task, repeat int) (Trace, error) {
+type taskJob struct {
+	task   Task
+	repeat int
+	split  string
+	hidden bool
+}
+
+// runTasks runs every repeat of every task on up to opts.Parallel workers.
+// Each job owns its workspace, output, and trace paths, so jobs share no
+// mutable state; results are stored by job index to keep suite order.
+func runTasks(ctx context.Context, opts RunOptions, suite Suite, runDir string, tasks []Task) ([]Trace, error) {
+	var jobs []taskJob
+	for repeat := 1; repeat <= suite.Repeat; repeat++ {
+		for _, task := range tasks {
+			split := suiteTaskSplit(suite, task)
+			jobs = append(jobs, taskJob{task: task, repeat: repeat, split: split, hidden: hiddenFromProposer(suite, split)})
+		}
+	}
+	traces := make([]Trace, len(jobs))
+	errs := make([]error, len(jobs))
+	workers := min(max(opts.Parallel, 1), max(len(jobs), 1))
+	indexes := make(chan int)
+	var wg sync.WaitGroup
+	for range workers {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for index := range indexes {
+				traces[index], errs[index] = runTask(ctx, opts, suite.ID, runDir, jobs[index])
+			}
+		}()
+	}
+	for index := range jobs {
+		indexes <- index
+	}
+	close(indexes)
+	wg.Wait()
+	if err := errors.Join(errs...); err != nil {
+		return nil, err
+	}
+	return traces, nil
+}
+
+func runTask(ctx context.Context, opts RunOptions, suiteID, runDir string, job taskJob) (Trace, error) {
+	task, repeat := job.task, job.repeat
 	start := time.Now().UTC()
 	workspace := filepath.Join(runDir, "workspaces", fmt.Sprintf("%s-%d", task.ID, repeat))
 	if err := copyDir(filepath.Join(opts.ProjectRoot, task.Fixture), workspace); err != nil {
@@ -87,13 +138,20 @@ func runTask(ctx context.Context, opts RunOptions, suiteID, runDir string, task
 	if err != nil {
 		return Trace{}, err
 	}
-	timeout := time.Duration(task.TimeoutSeconds) * time.Second
-	run := verify.ExecuteRun(ctx, verify.RunOptions{
+	// The task timeout bounds every command together, so a slow early command
+	// leaves later ones skipped rather than each getting a fresh budget.
+	taskCtx := ctx
+	if task.TimeoutSeconds > 0 {
+		var cancel context.CancelFunc
+		cause := fmt.Errorf("the task timeout of %ds expired", task.TimeoutSeconds)
+		taskCtx, cancel = context.WithTimeoutCause(ctx, time.Duration(task.TimeoutSeconds)*time.Second, cause)
+		defer cancel()
+	}
+	run := verify.ExecuteRun(taskCtx, verify.RunOptions{
 		ProjectRoot: workspace,
 		Feature:     verify.FeatureRef{ID: task.ID, Slug: task.ID, DirName: task.ID, Path: workspace},
 		TaskIDs:     []string{task.ID},
 		Commands:    commands,
-		Timeout:     timeout,
 	})
 	commandTraces, err := writeCommandOutput(runDir, task.ID, repeat, run.Results)
 	if err != nil {
@@ -105,7 +163,7 @@ func runTask(ctx context.Context, opts RunOptions, suiteID, runDir string, task
 	}
 	changed := changedFiles(before, after)
 	violations := allowedSurfaceViolations(changed, task.AllowedSurfaces)
-	assertions := evaluateAssertions(task, run.Results, changed)
+	assertions := evaluateAssertions(task, workspace, run.Results, changed)
 	status := "passed"
 	var failed []string
 	for index, result := range run.Results {
@@ -142,6 +200,8 @@ func runTask(ctx context.Context, opts RunOptions, suiteID, runDir string, task
 		Status:                   status,
 		WorkspacePath:            workspace,
 		RepeatIndex:              repeat,
+		Split:                    job.split,
+		HiddenFromProposer:       job.hidden,
 		Seed:                     "default",
 		Commands:                 commandTraces,
 		Assertions:               assertions,
@@ -160,62 +220,6 @@ func runTask(ctx context.Context, opts RunOptions, suiteID, runDir string, task
 	return trace, nil
 }
 
-func summarizeRun(traces []Trace) RunMetrics {
-	metrics := RunMetrics{TaskRuns: len(traces)}
-	outputsByTask := map[string]map[string]struct{}{}
-	for _, trace := range traces {
-		if trace.Status == "passed" {
-			metrics.PassedTaskRuns++
-		} else {
-			metrics.FailedTaskRuns++
-		}
-		for _, assertion := range trace.Assertions {
-			metrics.Assertions++
-			if assertion.Status == "passed" {
-				metrics.PassedAssertions++
-			} else {
-				metrics.FailedAssertions++
-			}
-		}
-		var traceOutputHashes []string
-		for _, command := range trace.Commands {
-			metrics.CommandDurationMS += command.DurationMS
-			metrics.Stdout.Lines += command.Stdout.Lines
-			metrics.Stdout.Words += command.Stdout.Words
-			metrics.Stdout.Bytes += command.Stdout.Bytes
-			metrics.Stdout.EstimatedTokens += command.Stdout.EstimatedTokens
-			traceOutputHashes = append(traceOutputHashes, command.StdoutSHA256)
-		}
-		if outputsByTask[trace.TaskID] == nil {
-			outputsByTask[trace.TaskID] = map[string]struct{}{}
-		}
-		outputsByTask[trace.TaskID][strings.Join(traceOutputHashes, ":")] = struct{}{}
-	}
-	repeatsByTask := map[string]int{}
-	for _, trace := range traces {
-		repeatsByTask[trace.TaskID]++
-	}
-	for taskID, repeats := range repeatsByTask {
-		if repeats < 2 {
-			continue
-		}
-		metrics.RepeatedTasks++
-		if len(outputsByTask[taskID]) == 1 {
-			metrics.StableRepeatedTasks++
-		}
-	}
-	if metrics.TaskRuns > 0 {
-		metrics.TaskSuccessRate = float64(metrics.PassedTaskRuns) / float64(metrics.TaskRuns)
-	}
-	if metrics.Assertions > 0 {
-		metrics.OutputCompleteness = float64(metrics.PassedAssertions) / float64(metrics.Assertions)
-	}
-	if metrics.RepeatedTasks > 0 {
-		metrics.DeterminismRate = float64(metrics.StableRepeatedTasks) / float64(metrics.RepeatedTasks)
-	}
-	return metrics
-}
-
 func parseCommands(task Task, kitBinary string) ([]verify.Command, error) {
 	commands := make([]verify.Command, 0, len(task.Commands))
 	for i, raw := range task.Commands {
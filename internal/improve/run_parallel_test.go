@@ -0,0 +1,117 @@
+package improve
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestRunParallelKeepsSuiteOrderAndIsolatesWorkspaces(t *testing.T) {
+	root := writeTimedSuite(t, map[string]string{"alpha": "0.4", "beta": "0.4"}, 0)
+	manifest, err := Run(context.Background(), RunOptions{ProjectRoot: root, SuiteName: "timed", KitBinary: sleepyKitBinary(t), Parallel: 4})
+	if err != nil {
+		t.Fatalf("Run() error = %v", err)
+	}
+	// With four workers every repeat is in flight at once: the last one to
+	// start begins before the first one to finish ends.
+	var lastStart, firstEnd time.Time
+	for index, trace := range manifest.Traces {
+		end := trace.StartedAt.Add(time.Duration(trace.DurationMS) * time.Millisecond)
+		if index == 0 || trace.StartedAt.After(lastStart) {
+			lastStart = trace.StartedAt
+		}
+		if index == 0 || end.Before(firstEnd) {
+			firstEnd = end
+		}
+	}
+	if !lastStart.Before(firstEnd) {
+		t.Fatalf("repeats did not overlap: last start %s, first end %s", lastStart, firstEnd)
+	}
+	if manifest.Status != "pass" || manifest.Parallel != 4 || manifest.Metrics.TaskRuns != 4 {
+		t.Fatalf("manifest = %+v", manifest)
+	}
+	want := []string{"alpha#1", "beta#1", "alpha#2", "beta#2"}
+	for index, trace := range manifest.Traces {
+		if got := fmt.Sprintf("%s#%d", trace.TaskID, trace.RepeatIndex); got != want[index] {
+			t.Fatalf("trace %d = %s, want %s", index, got, want[index])
+		}
+		if _, err := os.Stat(filepath.Join(manifest.RunDir, "workspaces", fmt.Sprintf("%s-%d", trace.TaskID, trace.RepeatIndex), "README.md")); err != nil {
+			t.Fatalf("workspace for %s#%d: %v", trace.TaskID, trace.RepeatIndex, err)
+		}
+	}
+}
+
+func TestRunTaskTimeoutBoundsAllCommands(t *testing.T) {
+	root := writeTimedSuite(t, map[string]string{"slow": "5"}, 1)
+	start := time.Now()
+	manifest, err := Run(context.Background(), RunOptions{ProjectRoot: root, SuiteName: "timed", KitBinary: sleepyKitBinary(t)})
+	if err != nil {
+		t.Fatalf("Run() error = %v", err)
+	}
+	if elapsed := time.Since(start); elapsed > 4*time.Second {
+		t.Fatalf("timed-out run took %s", elapsed)
+	}
+	trace := manifest.Traces[0]
+	if manifest.Status != "failed" || trace.Status != "failed" || len(trace.Commands) != 2 {
+		t.Fatalf("trace = %+v", trace)
+	}
+	if !trace.Commands[0].TimedOut || trace.Commands[1].Status != "skipped" || trace.Commands[1].Error != "skipped after the task timeout of 1s expired" {
+		t.Fatalf("commands = %+v", trace.Commands)
+	}
+}
+
+// writeTimedSuite builds a project whose tasks each sleep for the given
+// duration twice through the {{kit}} placeholder.
+func writeTimedSuite(t *testing.T, tasks map[string]string, timeoutSeconds int) string {
+	t.Helper()
+	root := t.TempDir()
+	files := map[string]string{
+		"fixture/README.md":            "# fixture\n",
+		EvalDir + "/suites/timed.yaml": "schema_version: 1\nid: timed\nrepeat: 2\nminimum_tasks: 1\n",
+	}
+	for id, seconds := range tasks {
+		files[EvalDir+"/tasks/"+id+".yaml"] = fmt.Sprintf(`schema_version: 1
+id: %s
+title: %s sleeps
+category: timing
+fixture: fixture
+timeout_seconds: %d
+expected_behavior: Both commands finish.
+oracle: deterministic-cli
+mutation_policy: fixture-only
+commands:
+  - "{{kit}} %s"
+  - "{{kit}} 0"
+assertions:
+  - type: command_succeeds
+    command_index: 1
+`, id, id, timeoutSeconds, seconds)
+	}
+	writeProjectFiles(t, root, files)
+	return root
+}
+
+func writeProjectFiles(t *testing.T, root string, files map[string]string) {
+	t.Helper()
+	for name, content := range files {
+		path := filepath.Join(root, filepath.FromSlash(name))
+		if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+}
+
+func sleepyKitBinary(t *testing.T) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "kit")
+	if err := os.WriteFile(path, []byte("#!/bin/sh\nexec sleep \"$1\"\n"), 0o755); err != nil {
+		t.Fatalf("write sleepy kit binary: %v", err)
+	}
+	return path
+}
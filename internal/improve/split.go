@@ -0,0 +1,85 @@
+package improve
+
+import (
+	"fmt"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+// Suite splits. Held-in tasks are the proposer's training signal; held-out
+// tasks are reserved for scoring candidates the proposer produced.
+const (
+	SplitHeldIn  = "held-in"
+	SplitHeldOut = "held-out"
+	SplitAll     = "all"
+)
+
+// ValidateSplit accepts a split name; empty selects held-in.
+func ValidateSplit(split string) error {
+	switch strings.TrimSpace(split) {
+	case "", SplitHeldIn, SplitHeldOut, SplitAll:
+		return nil
+	}
+	return fmt.Errorf("unsupported split %q; use %s, %s, or %s", split, SplitHeldIn, SplitHeldOut, SplitAll)
+}
+
+func normalizeSplit(split string) string {
+	if strings.TrimSpace(split) == "" {
+		return SplitHeldIn
+	}
+	return strings.TrimSpace(split)
+}
+
+// suiteTaskSplit places a task in one split of the suite, or none. The
+// held-out selector wins when tags overlap, and a held-out task must also be
+// held_out_eligible; ineligible matches join neither split so they can never
+// leak into proposer-visible runs.
+func suiteTaskSplit(suite Suite, task Task) string {
+	if taskMatchesSelector(task, suite.HeldOut) {
+		if task.HeldOutEligible {
+			return SplitHeldOut
+		}
+		return ""
+	}
+	if len(suite.HeldIn.IncludeTags) > 0 && !taskMatchesSelector(task, suite.HeldIn) {
+		return ""
+	}
+	return SplitHeldIn
+}
+
+func hiddenFromProposer(suite Suite, taskSplit string) bool {
+	if taskSplit == SplitHeldOut {
+		return suite.HeldOut.HiddenFromProposer
+	}
+	return suite.HeldIn.HiddenFromProposer
+}
+
+// proposerVisibleTraces drops traces of tasks the suite hides from the
+// proposer.
+func proposerVisibleTraces(traces []Trace) []Trace {
+	var visible []Trace
+	for _, trace := range traces {
+		if !trace.HiddenFromProposer {
+			visible = append(visible, trace)
+		}
+	}
+	return visible
+}
+
+func sortedTaskIDs(traces []Trace) []string {
+	ids := uniqueTaskIDs(traces)
+	sort.Strings(ids)
+	return ids
+}
+
+// minedSplit reports the split of the run in sourceDir. Runs recorded before
+// splits existed always ran held-in tasks; a trace directory without a run
+// manifest has no known split.
+func minedSplit(sourceDir string) string {
+	manifest, err := readRunManifest(filepath.Join(sourceDir, "run.json"))
+	if err != nil {
+		return ""
+	}
+	return normalizeSplit(manifest.Split)
+}
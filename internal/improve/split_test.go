@@ -0,0 +1,92 @@
+package improve
+
+import (
+	"context"
+	"fmt"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestSelectTasksHonorsSplitAndHeldOutEligibility(t *testing.T) {
+	suite := Suite{
+		HeldIn:  TaskSelector{IncludeTags: []string{"train"}},
+		HeldOut: TaskSelector{IncludeTags: []string{"exam"}, HiddenFromProposer: true},
+	}
+	tasks := []Task{
+		{ID: "train", RegressionTags: []string{"train"}},
+		{ID: "exam", RegressionTags: []string{"exam"}, HeldOutEligible: true},
+		{ID: "ineligible", RegressionTags: []string{"exam", "train"}},
+		{ID: "untagged"},
+	}
+	for split, want := range map[string][]string{
+		"":           {"train"},
+		SplitHeldIn:  {"train"},
+		SplitHeldOut: {"exam"},
+		SplitAll:     {"train", "exam"},
+	} {
+		var got []string
+		for _, task := range selectTasks(suite, tasks, split) {
+			got = append(got, task.ID)
+		}
+		if !reflect.DeepEqual(got, want) {
+			t.Fatalf("selectTasks(%q) = %v, want %v", split, got, want)
+		}
+	}
+	if err := ValidateSplit("test"); err == nil || !strings.Contains(err.Error(), "unsupported split") {
+		t.Fatalf("ValidateSplit(test) = %v", err)
+	}
+}
+
+func TestValidateRefusesToScoreCandidateOnSeenTasks(t *testing.T) {
+	root := t.TempDir()
+	files := map[string]string{
+		"fixture/README.md":            "# fixture\n",
+		EvalDir + "/suites/split.yaml": "schema_version: 1\nid: split\nheld_in:\n  include_tags: [train]\nheld_out:\n  include_tags: [exam]\n  hidden_from_proposer: true\nrepeat: 1\nminimum_tasks: 1\n",
+	}
+	for id, assertion := range map[string]string{"train": "stdout_contains\n    value: never", "exam": "command_succeeds"} {
+		files[EvalDir+"/tasks/"+id+".yaml"] = fmt.Sprintf("schema_version: 1\nid: %s\ntitle: %s\ncategory: split\nfixture: fixture\n"+
+			"expected_behavior: Exits.\noracle: deterministic-cli\nmutation_policy: fixture-only\ncommands:\n  - \"{{kit}} 0\"\n"+
+			"assertions:\n  - type: %s\nregression_tags: [%s]\nheld_out_eligible: true\n", id, id, assertion, id)
+	}
+	writeProjectFiles(t, root, files)
+	kitBinary := sleepyKitBinary(t)
+	run := func(split string) RunManifest {
+		t.Helper()
+		manifest, err := Run(context.Background(), RunOptions{ProjectRoot: root, SuiteName: "split", KitBinary: kitBinary, Split: split})
+		if err != nil {
+			t.Fatalf("Run(%s) error = %v", split, err)
+		}
+		return manifest
+	}
+
+	all := run(SplitAll)
+	if all.Split != SplitAll || len(all.Traces) != 2 || !all.Traces[0].HiddenFromProposer || all.Traces[0].Split != SplitHeldOut {
+		t.Fatalf("all-split manifest = %+v", all)
+	}
+	report, err := Mine(root, all.RunDir)
+	if err != nil {
+		t.Fatalf("Mine() error = %v", err)
+	}
+	if report.Split != SplitAll || !reflect.DeepEqual(report.SeenTasks, []string{"train"}) || report.HiddenTraces != 1 || len(report.Clusters) != 1 {
+		t.Fatalf("weakness report = %+v", report)
+	}
+	candidates, err := Propose(root, all.RunDir, 1)
+	if err != nil || len(candidates) != 1 || candidates[0].Split != SplitAll {
+		t.Fatalf("Propose() = %+v, %v", candidates, err)
+	}
+	candidatePath := filepath.Join(all.RunDir, "candidates", "candidate-001", "candidate.json")
+
+	if _, err := Validate(root, candidatePath, all.RunDir); err == nil || !strings.Contains(err.Error(), "tasks its proposer saw: train") {
+		t.Fatalf("Validate(seen run) error = %v", err)
+	}
+	heldOut := run(SplitHeldOut)
+	scorecard, err := Validate(root, candidatePath, heldOut.RunID)
+	if err != nil {
+		t.Fatalf("Validate(held-out run) error = %v", err)
+	}
+	if scorecard.Acceptance != "held-out-pass" || scorecard.Score != 100 {
+		t.Fatalf("scorecard = %+v", scorecard)
+	}
+}
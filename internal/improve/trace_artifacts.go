@@ -7,7 +7,6 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
-	"regexp"
 	"strings"
 
 	"github.com/jamesonstone/kit/v3/internal/verify"
@@ -32,140 +31,22 @@ func writeCommandOutput(runDir, taskID string, repeat int, results []verify.Comm
 			return nil, err
 		}
 		traces = append(traces, CommandTrace{
-			Argv:         append([]string(nil), result.Argv...),
-			ExitCode:     result.ExitCode,
-			Status:       result.Status,
-			DurationMS:   result.DurationMS,
-			Error:        commandError,
-			TimedOut:     result.TimedOut,
-			Stdout:       measureText(stdout),
-			StdoutSHA256: hashText(stdout),
-			StdoutPath:   stdoutPath,
-			StderrPath:   stderrPath,
+			Argv:            append([]string(nil), result.Argv...),
+			ExitCode:        result.ExitCode,
+			Status:          result.Status,
+			DurationMS:      result.DurationMS,
+			Error:           commandError,
+			TimedOut:        result.TimedOut,
+			Stdout:          measureText(stdout),
+			StdoutTruncated: result.StdoutTruncated,
+			StdoutSHA256:    hashText(stdout),
+			StdoutPath:      stdoutPath,
+			StderrPath:      stderrPath,
 		})
 	}
 	return traces, nil
 }
 
-func evaluateAssertions(task Task, results []verify.CommandResult, changed []string) []AssertionResult {
-	var out []AssertionResult
-	for _, assertion := range task.Assertions {
-		switch assertion.Type {
-		case "command_succeeds":
-			out = append(out, assertCommandSucceeds(assertion, results))
-		case "stdout_contains":
-			out = append(out, assertStdoutContains(assertion, results))
-		case "stdout_not_contains":
-			out = append(out, assertStdoutNotContains(assertion, results))
-		case "stdout_nonempty":
-			out = append(out, assertStdoutNonempty(assertion, results))
-		case "stdout_lines_max", "stdout_words_max", "stdout_estimated_tokens_max":
-			out = append(out, assertStdoutMaximum(assertion, results))
-		case "git_diff_empty":
-			if len(changed) == 0 {
-				out = append(out, AssertionResult{Type: assertion.Type, Status: "passed"})
-			} else {
-				out = append(out, AssertionResult{Type: assertion.Type, Status: "failed", Message: "changed files: " + strings.Join(changed, ", ")})
-			}
-		default:
-			out = append(out, AssertionResult{Type: assertion.Type, Status: "inconclusive", Message: "unsupported assertion type"})
-		}
-	}
-	return out
-}
-
-func assertStdoutContains(assertion Assertion, results []verify.CommandResult) AssertionResult {
-	result, failure := commandResult(assertion, results)
-	if failure != nil {
-		return *failure
-	}
-	if strings.Contains(result.Stdout, assertion.Value) {
-		return passedAssertion(assertion)
-	}
-	return failedAssertion(assertion, fmt.Sprintf("stdout missing %q", assertion.Value))
-}
-
-func assertStdoutNotContains(assertion Assertion, results []verify.CommandResult) AssertionResult {
-	result, failure := commandResult(assertion, results)
-	if failure != nil {
-		return *failure
-	}
-	if !strings.Contains(result.Stdout, assertion.Value) {
-		return passedAssertion(assertion)
-	}
-	return failedAssertion(assertion, fmt.Sprintf("stdout unexpectedly contains %q", assertion.Value))
-}
-
-func assertStdoutNonempty(assertion Assertion, results []verify.CommandResult) AssertionResult {
-	result, failure := commandResult(assertion, results)
-	if failure != nil {
-		return *failure
-	}
-	if strings.TrimSpace(result.Stdout) != "" {
-		return passedAssertion(assertion)
-	}
-	return failedAssertion(assertion, "stdout is empty")
-}
-
-func assertCommandSucceeds(assertion Assertion, results []verify.CommandResult) AssertionResult {
-	result, failure := commandResult(assertion, results)
-	if failure != nil {
-		return *failure
-	}
-	if result.Status == "pass" && result.ExitCode == 0 {
-		return passedAssertion(assertion)
-	}
-	message := fmt.Sprintf("command exited %d", result.ExitCode)
-	if result.TimedOut {
-		message = "command timed out"
-	} else if strings.TrimSpace(result.Error) != "" {
-		message += ": " + redactOutput(result.Error)
-	}
-	return failedAssertion(assertion, message)
-}
-
-func assertStdoutMaximum(assertion Assertion, results []verify.CommandResult) AssertionResult {
-	result, failure := commandResult(assertion, results)
-	if failure != nil {
-		return *failure
-	}
-	metrics := measureText(result.Stdout)
-	actual := 0
-	switch assertion.Type {
-	case "stdout_lines_max":
-		actual = metrics.Lines
-	case "stdout_words_max":
-		actual = metrics.Words
-	case "stdout_estimated_tokens_max":
-		actual = metrics.EstimatedTokens
-	}
-	if actual <= assertion.Max {
-		return passedAssertion(assertion)
-	}
-	return failedAssertion(assertion, fmt.Sprintf("stdout %s %d exceeds maximum %d", strings.TrimPrefix(assertion.Type, "stdout_"), actual, assertion.Max))
-}
-
-func commandResult(assertion Assertion, results []verify.CommandResult) (verify.CommandResult, *AssertionResult) {
-	if assertion.CommandIndex < 0 || assertion.CommandIndex >= len(results) {
-		failure := failedAssertion(assertion, "command_index out of range")
-		return verify.CommandResult{}, &failure
-	}
-	return results[assertion.CommandIndex], nil
-}
-
-func passedAssertion(assertion Assertion) AssertionResult {
-	return AssertionResult{Type: assertion.Type, CommandIndex: assertionCommandIndex(assertion), Status: "passed"}
-}
-
-func failedAssertion(assertion Assertion, message string) AssertionResult {
-	return AssertionResult{Type: assertion.Type, CommandIndex: assertionCommandIndex(assertion), Status: "failed", Message: message}
-}
-
-func assertionCommandIndex(assertion Assertion) *int {
-	index := assertion.CommandIndex
-	return &index
-}
-
 func measureText(value string) TextMetrics {
 	metrics := TextMetrics{
 		Words: len(strings.Fields(value)),
@@ -235,18 +116,8 @@ func limitLines(value string, max int) string {
 	return strings.Join(lines[:max], "\n") + "\n[truncated]\n"
 }
 
-var secretPatterns = []*regexp.Regexp{
-	regexp.MustCompile(`gh[pousr]_[A-Za-z0-9_]{20,}`),
-	regexp.MustCompile(`(?i)(api[_-]?key|token|secret|password)=\S+`),
-	regexp.MustCompile(`-----BEGIN [A-Z ]*PRIVATE KEY-----[\s\S]*?-----END [A-Z ]*PRIVATE KEY-----`),
-}
-
 func redactOutput(value string) string {
-	out := value
-	for _, pattern := range secretPatterns {
-		out = pattern.ReplaceAllString(out, "[REDACTED]")
-	}
-	return out
+	return verify.Redact(value)
 }
 
 func updateLatest(root, runDir string) {
@@ -45,6 +45,7 @@ type Assertion struct {
 	Type         string `json:"type" yaml:"type"`
 	CommandIndex int    `json:"command_index,omitempty" yaml:"command_index,omitempty"`
 	Value        string `json:"value,omitempty" yaml:"value,omitempty"`
+	Path         string `json:"path,omitempty" yaml:"path,omitempty"`
 	Max          int    `json:"max,omitempty" yaml:"max,omitempty"`
 }
 
@@ -57,6 +58,8 @@ type RunManifest struct {
 	EndedAt       time.Time           `json:"ended_at"`
 	Status        string              `json:"status"`
 	RunDir        string              `json:"run_dir"`
+	Split         string              `json:"split,omitempty"`
+	Parallel      int                 `json:"parallel,omitempty"`
 	Provenance    BenchmarkProvenance `json:"provenance"`
 	Metrics       RunMetrics          `json:"metrics"`
 	Traces        []Trace             `json:"traces"`
@@ -105,8 +108,9 @@ type Trace struct {
 	DurationMS               int64             `json:"duration_ms"`
 	Status                   string            `json:"status"`
 	WorkspacePath            string            `json:"workspace_path"`
-	BaselineTraceID          string            `json:"baseline_trace_id,omitempty"`
 	RepeatIndex              int               `json:"repeat_index"`
+	Split                    string            `json:"split,omitempty"`
+	HiddenFromProposer       bool              `json:"hidden_from_proposer,omitempty"`
 	Seed                     string            `json:"seed"`
 	Commands                 []CommandTrace    `json:"commands"`
 	Assertions               []AssertionResult `json:"assertions"`
@@ -117,16 +121,19 @@ type Trace struct {
 }
 
 type CommandTrace struct {
-	Argv         []string    `json:"argv"`
-	ExitCode     int         `json:"exit_code"`
-	Status       string      `json:"status"`
-	DurationMS   int64       `json:"duration_ms"`
-	Error        string      `json:"error,omitempty"`
-	TimedOut     bool        `json:"timed_out,omitempty"`
-	Stdout       TextMetrics `json:"stdout"`
-	StdoutSHA256 string      `json:"stdout_sha256"`
-	StdoutPath   string      `json:"stdout_path,omitempty"`
-	StderrPath   string      `json:"stderr_path,omitempty"`
+	Argv       []string    `json:"argv"`
+	ExitCode   int         `json:"exit_code"`
+	Status     string      `json:"status"`
+	DurationMS int64       `json:"duration_ms"`
+	Error      string      `json:"error,omitempty"`
+	TimedOut   bool        `json:"timed_out,omitempty"`
+	Stdout     TextMetrics `json:"stdout"`
+	// StdoutTruncated marks Stdout metrics measured on output cut at the
+	// verify output limit.
+	StdoutTruncated bool   `json:"stdout_truncated,omitempty"`
+	StdoutSHA256    string `json:"stdout_sha256"`
+	StdoutPath      string `json:"stdout_path,omitempty"`
+	StderrPath      string `json:"stderr_path,omitempty"`
 }
 
 type AssertionResult struct {
@@ -146,6 +153,9 @@ type WeaknessReport struct {
 	SchemaVersion int               `json:"schema_version"`
 	Kind          string            `json:"kind"`
 	SourceDir     string            `json:"source_dir"`
+	Split         string            `json:"split,omitempty"`
+	SeenTasks     []string          `json:"seen_tasks"`
+	HiddenTraces  int               `json:"hidden_traces,omitempty"`
 	Clusters      []WeaknessCluster `json:"clusters"`
 }
 
@@ -176,6 +186,8 @@ type Candidate struct {
 	RegressionRisks  []string `json:"regression_risks"`
 	Rollback         string   `json:"rollback"`
 	Status           string   `json:"status"`
+	Split            string   `json:"split,omitempty"`
+	SeenTasks        []string `json:"seen_tasks,omitempty"`
 }
 
 type Scorecard struct {
@@ -0,0 +1,124 @@
+package improve
+
+import (
+	"fmt"
+	"math"
+	"path/filepath"
+	"strings"
+)
+
+// Validate checks candidate metadata. With runRef naming a benchmark run, it
+// also scores the candidate on that run, refusing when the run contains any
+// task the candidate's proposer saw.
+func Validate(projectRoot, candidatePath, runRef string) (Scorecard, error) {
+	candidate, err := readCandidate(candidatePath)
+	if err != nil {
+		return Scorecard{}, err
+	}
+	scorecard := Scorecard{
+		SchemaVersion:      SchemaVersion,
+		CandidateID:        candidate.ID,
+		Score:              0,
+		Acceptance:         "inconclusive",
+		Reasons:            []string{"This command validates candidate metadata only; it does not run or compare benchmark behavior."},
+		ValidationCommands: []string{"kit improve validate --candidate " + candidatePath + " --json"},
+	}
+	if candidate.Status == "proposed" {
+		scorecard.Acceptance = "metadata-only"
+		scorecard.Reasons = []string{"candidate metadata is well-formed", "score 0 is not a task-quality judgment", "run identical benchmark suites separately before review"}
+	}
+	if strings.TrimSpace(runRef) == "" {
+		return scorecard, nil
+	}
+	return scoreOnUnseenTasks(projectRoot, candidatePath, candidate, runRef)
+}
+
+func scoreOnUnseenTasks(projectRoot, candidatePath string, candidate Candidate, runRef string) (Scorecard, error) {
+	if strings.TrimSpace(candidate.Split) == "" {
+		return Scorecard{}, fmt.Errorf("candidate %s does not record the split it was mined from; regenerate it with kit improve propose", candidate.ID)
+	}
+	manifest, err := readRunManifest(filepath.Join(ResolveRunDir(projectRoot, runRef), "run.json"))
+	if err != nil {
+		return Scorecard{}, err
+	}
+	if len(manifest.Traces) == 0 {
+		return Scorecard{}, fmt.Errorf("run %s has no task traces to score", manifest.RunID)
+	}
+	seen := map[string]struct{}{}
+	for _, id := range candidate.SeenTasks {
+		seen[id] = struct{}{}
+	}
+	var overlap []string
+	for _, id := range sortedTaskIDs(manifest.Traces) {
+		if _, ok := seen[id]; ok {
+			overlap = append(overlap, id)
+		}
+	}
+	if len(overlap) > 0 {
+		return Scorecard{}, fmt.Errorf("refusing to score candidate %s on tasks its proposer saw: %s", candidate.ID, strings.Join(overlap, ", "))
+	}
+	acceptance := "held-out-fail"
+	if manifest.Status == "pass" {
+		acceptance = "held-out-pass"
+	}
+	return Scorecard{
+		SchemaVersion: SchemaVersion,
+		CandidateID:   candidate.ID,
+		Score:         int(math.Round(100 * manifest.Metrics.TaskSuccessRate)),
+		Acceptance:    acceptance,
+		Reasons: []string{
+			fmt.Sprintf("candidate was mined from the %s split and its proposer saw %d tasks", candidate.Split, len(candidate.SeenTasks)),
+			fmt.Sprintf("run %s (%s split) passed %d/%d runs of %d tasks the proposer did not see", manifest.RunID, normalizeSplit(manifest.Split), manifest.Metrics.PassedTaskRuns, manifest.Metrics.TaskRuns, len(uniqueTaskIDs(manifest.Traces))),
+			"score is the unseen-task success rate as a percentage",
+		},
+		ValidationCommands: []string{"kit improve validate --candidate " + candidatePath + " --run " + manifest.RunID + " --json"},
+	}, nil
+}
+
+func readCandidate(path string) (Candidate, error) {
+	var candidate Candidate
+	if err := readJSON(path, &candidate); err != nil {
+		return Candidate{}, err
+	}
+	if err := validateCandidateMetadata(candidate); err != nil {
+		return Candidate{}, err
+	}
+	return candidate, nil
+}
+
+func validateCandidateMetadata(candidate Candidate) error {
+	var findings []string
+	if candidate.SchemaVersion != SchemaVersion {
+		findings = append(findings, fmt.Sprintf("schema_version must be %d", SchemaVersion))
+	}
+	requiredText := []struct {
+		name  string
+		value string
+	}{
+		{name: "id", value: candidate.ID},
+		{name: "target_cluster", value: candidate.TargetCluster},
+		{name: "summary", value: candidate.Summary},
+		{name: "expected_effect", value: candidate.ExpectedEffect},
+		{name: "rationale", value: candidate.Rationale},
+		{name: "rollback", value: candidate.Rollback},
+		{name: "status", value: candidate.Status},
+	}
+	for _, field := range requiredText {
+		if strings.TrimSpace(field.value) == "" {
+			findings = append(findings, field.name+" is required")
+		}
+	}
+	if len(candidate.EditableSurfaces) == 0 {
+		findings = append(findings, "editable_surfaces must not be empty")
+	}
+	if len(candidate.RegressionRisks) == 0 {
+		findings = append(findings, "regression_risks must not be empty")
+	}
+	if strings.TrimSpace(candidate.Status) != "" && candidate.Status != "proposed" {
+		findings = append(findings, "status must be proposed")
+	}
+	if len(findings) > 0 {
+		return fmt.Errorf("invalid candidate metadata: %s", strings.Join(findings, "; "))
+	}
+	return nil
+}
@@ -87,7 +87,7 @@ const Spec = `# SPEC
 
 ## VALIDATION
 
-<!-- TODO: record the checks run and their outcomes; use exact commands or evidence when useful -->
+<!-- TODO: record the checks run and their outcomes; declare exact commands in a kit-validation block to run them with kit check --run-validation -->
 
 ## OUTCOME
 
@@ -0,0 +1,161 @@
+package usage
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"time"
+)
+
+// Advice kinds a maintainer can act on.
+const (
+	AdviceRemoveDeprecated = "remove_deprecated"
+	AdviceFailing          = "failing"
+	AdviceUnused           = "unused"
+	AdviceDeprecatedInUse  = "deprecated_in_use"
+)
+
+// CatalogCommand is one recorded command as the capabilities catalog
+// documents it. Flags are bare names without leading dashes.
+type CatalogCommand struct {
+	Command         string
+	Deprecated      bool
+	DeprecationNote string
+	Flags           []string
+}
+
+type AdviseOptions struct {
+	MinCalls    int
+	FailureRate float64
+}
+
+type Advice struct {
+	Rank            int     `json:"rank"`
+	Score           int     `json:"score"`
+	Kind            string  `json:"kind"`
+	Command         string  `json:"command"`
+	Flag            string  `json:"flag,omitempty"`
+	Calls           int     `json:"calls"`
+	Failures        int     `json:"failures"`
+	FailureRate     float64 `json:"failure_rate"`
+	Deprecated      bool    `json:"deprecated"`
+	DeprecationNote string  `json:"deprecation_note,omitempty"`
+	Recommendation  string  `json:"recommendation"`
+}
+
+type AdviceReport struct {
+	SchemaVersion        string       `json:"schema_version"`
+	GeneratedAt          time.Time    `json:"generated_at"`
+	Since                time.Time    `json:"since"`
+	CoverageStart        *time.Time   `json:"coverage_start,omitempty"`
+	CoverageEnd          *time.Time   `json:"coverage_end,omitempty"`
+	TotalCalls           int          `json:"total_calls"`
+	MinCalls             int          `json:"min_calls"`
+	FailureRateThreshold float64      `json:"failure_rate_threshold"`
+	Advice               []Advice     `json:"advice"`
+	Diagnostics          []Diagnostic `json:"diagnostics"`
+}
+
+// Advise joins a usage report with the catalog and ranks commands and flags
+// that are unused over the window or mostly failing. Scores order the work:
+// a deprecated command with no use is 100, failing is 50 plus 40 times the
+// failure rate, an unused command is 60, an unused flag is 40, and a
+// deprecated command still in use is 20. Flag advice needs MinCalls calls
+// recorded with flag names, so events from before flag collection never make
+// a flag look unused.
+func Advise(report Report, catalog []CatalogCommand, opts AdviseOptions) AdviceReport {
+	result := AdviceReport{
+		SchemaVersion: SchemaVersion, GeneratedAt: time.Now().UTC(), Since: report.Since,
+		CoverageStart: report.CoverageStart, CoverageEnd: report.CoverageEnd,
+		TotalCalls: report.TotalCalls, MinCalls: opts.MinCalls, FailureRateThreshold: opts.FailureRate,
+		Advice: []Advice{}, Diagnostics: append([]Diagnostic{}, report.Diagnostics...),
+	}
+	if report.TotalCalls == 0 {
+		result.Diagnostics = append(result.Diagnostics, Diagnostic{Level: "warning", Message: "no usage recorded in the window; unused-command advice needs observed usage"})
+	}
+	commands := map[string]CommandSummary{}
+	for _, summary := range report.Commands {
+		commands[summary.Command] = summary
+	}
+	thinFlagEvidence := 0
+	for _, entry := range catalog {
+		summary := commands[entry.Command]
+		base := Advice{Command: entry.Command, Deprecated: entry.Deprecated, DeprecationNote: entry.DeprecationNote}
+		if summary.Calls == 0 {
+			if report.TotalCalls == 0 {
+				continue
+			}
+			if entry.Deprecated {
+				result.Advice = append(result.Advice, withAdvice(base, AdviceRemoveDeprecated, 100, "deprecated with no observed use; candidate for removal"))
+			} else {
+				result.Advice = append(result.Advice, withAdvice(base, AdviceUnused, 60, "no observed use; consider deprecating"))
+			}
+			continue
+		}
+		base.Calls, base.Failures = summary.Calls, summary.Failures
+		base.FailureRate = float64(summary.Failures) / float64(summary.Calls)
+		if entry.Deprecated {
+			result.Advice = append(result.Advice, withAdvice(base, AdviceDeprecatedInUse, 20, fmt.Sprintf("deprecated but still called %d times; keep migration guidance until use stops", summary.Calls)))
+		}
+		if failing(base.Calls, base.FailureRate, opts) {
+			result.Advice = append(result.Advice, withAdvice(base, AdviceFailing, failingScore(base.FailureRate), fmt.Sprintf("fails %.0f%% of %d calls; investigate before changing its surface", 100*base.FailureRate, base.Calls)))
+		}
+		if len(entry.Flags) == 0 {
+			continue
+		}
+		if summary.FlagRecordedCalls < opts.MinCalls {
+			thinFlagEvidence++
+			continue
+		}
+		result.Advice = append(result.Advice, flagAdvice(entry, summary, opts)...)
+	}
+	if thinFlagEvidence > 0 {
+		result.Diagnostics = append(result.Diagnostics, Diagnostic{Level: "info", Message: fmt.Sprintf("%d used commands have fewer than %d calls recorded with flag names; their flags are not advised on", thinFlagEvidence, opts.MinCalls)})
+	}
+	sort.SliceStable(result.Advice, func(i, j int) bool {
+		left, right := result.Advice[i], result.Advice[j]
+		if left.Score != right.Score {
+			return left.Score > right.Score
+		}
+		if left.Command != right.Command {
+			return left.Command < right.Command
+		}
+		return left.Flag < right.Flag
+	})
+	for index := range result.Advice {
+		result.Advice[index].Rank = index + 1
+	}
+	return result
+}
+
+func flagAdvice(entry CatalogCommand, summary CommandSummary, opts AdviseOptions) []Advice {
+	observed := map[string]FlagSummary{}
+	for _, flag := range summary.Flags {
+		observed[flag.Flag] = flag
+	}
+	var advice []Advice
+	for _, name := range entry.Flags {
+		flag := observed[name]
+		base := Advice{Command: entry.Command, Flag: name, Calls: flag.Calls, Failures: flag.Failures, FailureRate: flag.FailureRate}
+		switch {
+		case flag.Calls == 0:
+			advice = append(advice, withAdvice(base, AdviceUnused, 40, fmt.Sprintf("never set across %d calls; consider removing or folding into a default", summary.FlagRecordedCalls)))
+		case failing(flag.Calls, flag.FailureRate, opts):
+			advice = append(advice, withAdvice(base, AdviceFailing, failingScore(flag.FailureRate), fmt.Sprintf("calls setting it fail %.0f%% of %d times; investigate the flag path", 100*flag.FailureRate, flag.Calls)))
+		}
+	}
+	return advice
+}
+
+func withAdvice(base Advice, kind string, score int, recommendation string) Advice {
+	base.Kind, base.Score, base.Recommendation = kind, score, recommendation
+	return base
+}
+
+func failing(calls int, failureRate float64, opts AdviseOptions) bool {
+	return calls >= opts.MinCalls && failureRate >= opts.FailureRate
+}
+
+func failingScore(failureRate float64) int {
+	return 50 + int(math.Round(40*failureRate))
+}
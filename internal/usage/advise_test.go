@@ -0,0 +1,95 @@
+package usage
+
+import (
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestAdviseRanksUnusedAndFailingCommandsAndFlags(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	dir, _ := Directory()
+	if err := ensurePrivateDirectory(dir); err != nil {
+		t.Fatal(err)
+	}
+	now := time.Now().UTC()
+	var events []Event
+	for index := range 6 {
+		flags := []string{"json"}
+		if index == 0 {
+			flags = nil
+		}
+		events = append(events,
+			Event{SchemaVersion: SchemaVersion, Timestamp: now, Command: "status", Success: true, Flags: flags},
+			Event{SchemaVersion: SchemaVersion, Timestamp: now, Command: "health", ExitCode: 1, Flags: []string{}},
+			Event{SchemaVersion: SchemaVersion, Timestamp: now, Command: "legacy", Success: true, Flags: []string{}},
+		)
+	}
+	if err := writeEvents(filepath.Join(dir, now.Format("2006-01")+"-0001.jsonl"), events); err != nil {
+		t.Fatal(err)
+	}
+	report, err := BuildReport(Filter{Since: now.Add(-time.Hour)})
+	if err != nil {
+		t.Fatal(err)
+	}
+	catalog := []CatalogCommand{
+		{Command: "status", Flags: []string{"json", "all"}},
+		{Command: "health", Flags: []string{"dry-run"}},
+		{Command: "legacy", Deprecated: true, DeprecationNote: "use status"},
+		{Command: "retired", Deprecated: true},
+		{Command: "check"},
+	}
+	advice := Advise(report, catalog, AdviseOptions{MinCalls: 5, FailureRate: 0.5})
+
+	type key struct{ kind, command, flag string }
+	var got []key
+	for _, item := range advice.Advice {
+		got = append(got, key{item.Kind, item.Command, item.Flag})
+	}
+	want := []key{
+		{AdviceRemoveDeprecated, "retired", ""},
+		{AdviceFailing, "health", ""},
+		{AdviceUnused, "check", ""},
+		{AdviceUnused, "health", "dry-run"},
+		{AdviceUnused, "status", "all"},
+		{AdviceDeprecatedInUse, "legacy", ""},
+	}
+	if len(got) != len(want) {
+		t.Fatalf("advice = %+v", advice.Advice)
+	}
+	for index := range want {
+		if got[index] != want[index] || advice.Advice[index].Rank != index+1 {
+			t.Fatalf("advice[%d] = %+v; want %+v", index, advice.Advice[index], want[index])
+		}
+	}
+	if advice.Advice[1].Score != 90 || advice.Advice[5].DeprecationNote != "use status" {
+		t.Fatalf("advice detail = %+v", advice.Advice)
+	}
+
+	empty := Advise(Report{}, catalog, AdviseOptions{MinCalls: 5, FailureRate: 0.5})
+	if len(empty.Advice) != 0 || len(empty.Diagnostics) != 1 {
+		t.Fatalf("advice without usage = %+v", empty)
+	}
+}
+
+func TestRecordKeepsFlagNamesOnly(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	if err := Record(RecordInput{Command: "status", Flags: []string{"json", "--all", "json", "out=/secret/path", "Token Value"}}); err != nil {
+		t.Fatal(err)
+	}
+	dir, _ := Directory()
+	snapshot, err := snapshotStore(dir)
+	if err != nil || len(snapshot.paths) != 1 {
+		t.Fatalf("store snapshot = %+v, %v", snapshot, err)
+	}
+	var flags []string
+	if err := snapshot.read(snapshot.paths[0], func(event Event) error {
+		flags = event.Flags
+		return nil
+	}); err != nil {
+		t.Fatal(err)
+	}
+	if len(flags) != 2 || flags[0] != "all" || flags[1] != "json" {
+		t.Fatalf("recorded flags = %q", flags)
+	}
+}
@@ -0,0 +1,73 @@
+package usage
+
+import "sort"
+
+// ErrorClass is a stable failure code from a closed set. Commands attach a
+// class at their error returns; the message itself is never recorded.
+type ErrorClass string
+
+const (
+	ErrorClassUnclassified    ErrorClass = "unclassified"
+	ErrorClassInvalidInput    ErrorClass = "invalid_input"
+	ErrorClassNoProject       ErrorClass = "no_project"
+	ErrorClassConfigInvalid   ErrorClass = "config_invalid"
+	ErrorClassNotFound        ErrorClass = "not_found"
+	ErrorClassPermission      ErrorClass = "permission"
+	ErrorClassTimeout         ErrorClass = "timeout"
+	ErrorClassInterrupted     ErrorClass = "interrupted"
+	ErrorClassExternalCommand ErrorClass = "external_command"
+	ErrorClassNetwork         ErrorClass = "network"
+	ErrorClassEvidenceBlocked ErrorClass = "evidence_blocked"
+	ErrorClassEvidenceDrift   ErrorClass = "evidence_drift"
+	ErrorClassCheckFailed     ErrorClass = "check_failed"
+)
+
+// maxFailureClasses bounds the failure classes reported per command.
+const maxFailureClasses = 5
+
+var errorClasses = map[ErrorClass]bool{
+	ErrorClassUnclassified: true, ErrorClassInvalidInput: true, ErrorClassNoProject: true,
+	ErrorClassConfigInvalid: true, ErrorClassNotFound: true, ErrorClassPermission: true,
+	ErrorClassTimeout: true, ErrorClassInterrupted: true, ErrorClassExternalCommand: true,
+	ErrorClassNetwork: true, ErrorClassEvidenceBlocked: true, ErrorClassEvidenceDrift: true,
+	ErrorClassCheckFailed: true,
+}
+
+func ValidErrorClass(class ErrorClass) bool {
+	return errorClasses[class]
+}
+
+// recordedErrorClass keeps successful events classless and maps anything
+// outside the closed set to unclassified so free text can never be stored.
+func recordedErrorClass(exitCode int, class ErrorClass) ErrorClass {
+	if exitCode == 0 {
+		return ""
+	}
+	if !ValidErrorClass(class) {
+		return ErrorClassUnclassified
+	}
+	return class
+}
+
+type FailureClassSummary struct {
+	Class    ErrorClass `json:"class"`
+	Failures int        `json:"failures"`
+}
+
+// topFailureClasses returns the most frequent classes, most failures first.
+func topFailureClasses(counts map[ErrorClass]int) []FailureClassSummary {
+	summaries := []FailureClassSummary{}
+	for class, failures := range counts {
+		summaries = append(summaries, FailureClassSummary{Class: class, Failures: failures})
+	}
+	sort.Slice(summaries, func(i, j int) bool {
+		if summaries[i].Failures != summaries[j].Failures {
+			return summaries[i].Failures > summaries[j].Failures
+		}
+		return summaries[i].Class < summaries[j].Class
+	})
+	if len(summaries) > maxFailureClasses {
+		summaries = summaries[:maxFailureClasses]
+	}
+	return summaries
+}
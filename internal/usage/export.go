@@ -0,0 +1,149 @@
+package usage
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"path/filepath"
+	"time"
+)
+
+const ExportSchemaVersion = "kit.usage.export/v1"
+
+// Export time coarsening levels.
+const (
+	CoarsenNone = "none"
+	CoarsenHour = "hour"
+	CoarsenDay  = "day"
+)
+
+// ExportHeader is the first line of an export file; every later line is an
+// Event.
+type ExportHeader struct {
+	SchemaVersion      string    `json:"schema_version"`
+	Kind               string    `json:"kind"`
+	EventSchemaVersion string    `json:"event_schema_version"`
+	ExportedAt         time.Time `json:"exported_at"`
+	Since              time.Time `json:"since"`
+	Coarsen            string    `json:"coarsen"`
+	Events             int       `json:"events"`
+}
+
+type ExportOptions struct {
+	Since   time.Time
+	Coarsen string
+}
+
+func ValidateCoarsen(value string) error {
+	switch value {
+	case CoarsenNone, CoarsenHour, CoarsenDay:
+		return nil
+	}
+	return fmt.Errorf("unsupported coarsening %q; use %s, %s, or %s", value, CoarsenNone, CoarsenHour, CoarsenDay)
+}
+
+// Export writes retained local events to w. Project IDs are re-hashed with a
+// salt generated for this export and then discarded, so exports cannot be
+// joined to the local store or to each other by project. Timestamps are
+// truncated to the requested coarsening.
+func Export(w io.Writer, opts ExportOptions) (ExportHeader, error) {
+	if opts.Coarsen == "" {
+		opts.Coarsen = CoarsenNone
+	}
+	if err := ValidateCoarsen(opts.Coarsen); err != nil {
+		return ExportHeader{}, err
+	}
+	dir, err := Directory()
+	if err != nil {
+		return ExportHeader{}, err
+	}
+	salt := make([]byte, 32)
+	if _, err := rand.Read(salt); err != nil {
+		return ExportHeader{}, err
+	}
+	snapshot, err := snapshotStore(dir)
+	if err != nil {
+		return ExportHeader{}, err
+	}
+	var events []Event
+	for _, path := range snapshot.paths {
+		if err := snapshot.read(path, func(event Event) error {
+			if event.Timestamp.Before(opts.Since) {
+				return nil
+			}
+			event.Timestamp = coarsenTime(event.Timestamp, opts.Coarsen)
+			event.ProjectID = saltProjectID(salt, event.ProjectID)
+			events = append(events, event)
+			return nil
+		}); err != nil {
+			return ExportHeader{}, fmt.Errorf("%w; run kit usage refresh before exporting", err)
+		}
+	}
+	header := ExportHeader{
+		SchemaVersion:      ExportSchemaVersion,
+		Kind:               "usage_export",
+		EventSchemaVersion: SchemaVersion,
+		ExportedAt:         coarsenTime(time.Now().UTC(), opts.Coarsen),
+		Since:              opts.Since,
+		Coarsen:            opts.Coarsen,
+		Events:             len(events),
+	}
+	encoder := json.NewEncoder(w)
+	if err := encoder.Encode(header); err != nil {
+		return ExportHeader{}, err
+	}
+	for _, event := range events {
+		if err := encoder.Encode(event); err != nil {
+			return ExportHeader{}, err
+		}
+	}
+	return header, nil
+}
+
+// readExport visits the events of one export file after checking its header.
+func readExport(path string, visit func(Event) error) error {
+	headerRead := false
+	err := readLines(path, func(line []byte) error {
+		if !headerRead {
+			headerRead = true
+			var header ExportHeader
+			if err := json.Unmarshal(line, &header); err != nil {
+				return fmt.Errorf("invalid usage export header in %s: %w", filepath.Base(path), err)
+			}
+			if header.SchemaVersion != ExportSchemaVersion || header.EventSchemaVersion != SchemaVersion {
+				return fmt.Errorf("%s is not a %s usage export", filepath.Base(path), ExportSchemaVersion)
+			}
+			return nil
+		}
+		event, err := decodeEvent(path, line)
+		if err != nil {
+			return err
+		}
+		return visit(event)
+	})
+	if err == nil && !headerRead {
+		return fmt.Errorf("%s is an empty usage export", filepath.Base(path))
+	}
+	return err
+}
+
+func coarsenTime(timestamp time.Time, coarsen string) time.Time {
+	switch coarsen {
+	case CoarsenHour:
+		return timestamp.UTC().Truncate(time.Hour)
+	case CoarsenDay:
+		return timestamp.UTC().Truncate(24 * time.Hour)
+	}
+	return timestamp.UTC()
+}
+
+func saltProjectID(salt []byte, projectID string) string {
+	if projectID == "" {
+		return ""
+	}
+	digest := sha256.Sum256(append(append([]byte{}, salt...), []byte("\x00"+projectID)...))
+	return hex.EncodeToString(digest[:16])
+}
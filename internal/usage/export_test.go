@@ -0,0 +1,101 @@
+package usage
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestExportSaltsProjectsCoarsensTimeAndMergesOffline(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	dir, _ := Directory()
+	if err := ensurePrivateDirectory(dir); err != nil {
+		t.Fatal(err)
+	}
+	recorded := time.Date(2026, 10, 14, 15, 42, 7, 0, time.UTC)
+	events := []Event{
+		{SchemaVersion: SchemaVersion, Timestamp: recorded, Command: "status", Version: "v3.0.0", Success: true, ElapsedMS: 12, ProjectID: "local-project"},
+		{SchemaVersion: SchemaVersion, Timestamp: recorded.Add(time.Minute), Command: "health", Version: "v3.0.0", ExitCode: 1, ElapsedMS: 40, ProjectID: "local-project"},
+	}
+	if err := writeEvents(filepath.Join(dir, "2026-10-0001.jsonl"), events); err != nil {
+		t.Fatal(err)
+	}
+
+	exportDir := t.TempDir()
+	var projects []string
+	var paths []string
+	for _, name := range []string{"a.jsonl", "b.jsonl"} {
+		var buffer bytes.Buffer
+		header, err := Export(&buffer, ExportOptions{Coarsen: CoarsenDay})
+		if err != nil {
+			t.Fatalf("Export() error = %v", err)
+		}
+		if header.SchemaVersion != ExportSchemaVersion || header.Events != 2 || header.Coarsen != CoarsenDay {
+			t.Fatalf("export header = %+v", header)
+		}
+		scanner := bufio.NewScanner(bytes.NewReader(buffer.Bytes()))
+		scanner.Scan()
+		scanner.Scan()
+		var event Event
+		if err := json.Unmarshal(scanner.Bytes(), &event); err != nil {
+			t.Fatal(err)
+		}
+		if !event.Timestamp.Equal(time.Date(2026, 10, 14, 0, 0, 0, 0, time.UTC)) || event.ProjectID == "" || event.ProjectID == "local-project" {
+			t.Fatalf("exported event = %+v", event)
+		}
+		projects = append(projects, event.ProjectID)
+		path := filepath.Join(exportDir, name)
+		if err := os.WriteFile(path, buffer.Bytes(), 0o600); err != nil {
+			t.Fatal(err)
+		}
+		paths = append(paths, path)
+	}
+	if projects[0] == projects[1] {
+		t.Fatalf("exports share a project ID %q; want a fresh salt per export", projects[0])
+	}
+
+	report, err := BuildReportFromExports(Filter{Since: recorded.Add(-48 * time.Hour)}, paths)
+	if err != nil {
+		t.Fatalf("BuildReportFromExports() error = %v", err)
+	}
+	if report.Inputs != 2 || report.TotalCalls != 4 || report.Failures != 2 || len(report.Projects) != 2 {
+		t.Fatalf("merged report = %+v", report)
+	}
+	shards, err := listShards(dir)
+	if err != nil || len(shards) != 1 {
+		t.Fatalf("local store changed after merging exports: %v, %v", shards, err)
+	}
+
+	if _, err := BuildReportFromExports(Filter{}, []string{filepath.Join(dir, "2026-10-0001.jsonl")}); err == nil || !strings.Contains(err.Error(), "is not a kit.usage.export/v1 usage export") {
+		t.Fatalf("BuildReportFromExports(local shard) error = %v", err)
+	}
+}
+
+func TestReadingExportsDropsFreeTextClassesAndFlagValues(t *testing.T) {
+	recorded := time.Date(2026, 10, 14, 15, 0, 0, 0, time.UTC)
+	header, _ := json.Marshal(ExportHeader{SchemaVersion: ExportSchemaVersion, Kind: "usage_export", EventSchemaVersion: SchemaVersion, Coarsen: CoarsenNone, Events: 2})
+	failed, _ := json.Marshal(Event{SchemaVersion: SchemaVersion, Timestamp: recorded, Command: "health", ExitCode: 1, ErrorClass: "open /home/alice/secret: denied", Flags: []string{"json", "--out=/home/alice/x", "Token Value"}})
+	passed, _ := json.Marshal(Event{SchemaVersion: SchemaVersion, Timestamp: recorded, Command: "status", Success: true})
+	path := filepath.Join(t.TempDir(), "edited.jsonl")
+	if err := os.WriteFile(path, []byte(string(header)+"\n"+string(failed)+"\n"+string(passed)+"\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	var events []Event
+	if err := readExport(path, func(event Event) error {
+		events = append(events, event)
+		return nil
+	}); err != nil {
+		t.Fatal(err)
+	}
+	if len(events) != 2 || events[0].ErrorClass != ErrorClassUnclassified || len(events[0].Flags) != 1 || events[0].Flags[0] != "json" {
+		t.Fatalf("sanitized events = %+v", events)
+	}
+	if events[1].ErrorClass != "" || events[1].Flags != nil {
+		t.Fatalf("clean event changed on read = %+v", events[1])
+	}
+}
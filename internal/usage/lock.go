@@ -10,6 +10,9 @@ import (
 
 const staleLockAge = 5 * time.Minute
 
+// errStoreBusy reports that another process holds the store lock.
+var errStoreBusy = errors.New("usage store is busy")
+
 func withStoreLock(dir string, action func() error) error {
 	if err := ensurePrivateDirectory(dir); err != nil {
 		return err
@@ -21,13 +24,13 @@ func withStoreLock(dir string, action func() error) error {
 		}
 		info, statErr := os.Stat(lockPath)
 		if statErr != nil || time.Since(info.ModTime()) <= staleLockAge {
-			return fmt.Errorf("usage store is busy")
+			return errStoreBusy
 		}
 		if err := os.Remove(lockPath); err != nil {
 			return fmt.Errorf("usage store has a stale lock: %w", err)
 		}
 		if err := os.Mkdir(lockPath, 0o700); err != nil {
-			return fmt.Errorf("usage store is busy")
+			return errStoreBusy
 		}
 	}
 	defer func() { _ = os.Remove(lockPath) }()
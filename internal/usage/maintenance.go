@@ -40,6 +40,9 @@ func Refresh(dryRun bool) (RefreshResult, error) {
 		return result, nil
 	}
 	err = withStoreLock(dir, func() error {
+		if err := compactSpool(dir); err != nil {
+			return err
+		}
 		if err := pruneStore(dir, time.Now().UTC(), false, &result.Status); err != nil {
 			return err
 		}
@@ -173,6 +176,9 @@ func Clear(filter Filter, all bool) (int, error) {
 	}
 	removed := 0
 	err = withStoreLock(dir, func() error {
+		if err := compactSpool(dir); err != nil {
+			return err
+		}
 		shards, err := listShards(dir)
 		if err != nil {
 			return err
@@ -2,6 +2,8 @@ package usage
 
 import (
 	"fmt"
+	"os"
+	"path/filepath"
 	"sort"
 	"strings"
 	"time"
@@ -12,21 +14,46 @@ func BuildReport(filter Filter) (Report, error) {
 	if err != nil {
 		return Report{}, err
 	}
-	report := Report{SchemaVersion: SchemaVersion, GeneratedAt: time.Now().UTC(), Since: filter.Since, Diagnostics: []Diagnostic{}}
-	commandCounts := map[string]*CommandSummary{}
-	projectCounts := map[string]int{}
-	shards, err := listShards(dir)
+	snapshot, err := snapshotStore(dir)
 	if err != nil {
-		return report, err
+		return newReport(filter), err
 	}
-	for _, item := range shards {
-		readErr := readEvents(item.path, func(event Event) error {
+	return buildReport(filter, snapshot.paths, snapshot.read, false)
+}
+
+// BuildReportFromExports aggregates usage export files offline. Exports are
+// only read; nothing is written into the local store, and an unreadable or
+// invalid export fails the report instead of becoming a diagnostic.
+func BuildReportFromExports(filter Filter, paths []string) (Report, error) {
+	report, err := buildReport(filter, paths, readExport, true)
+	if err != nil {
+		return Report{}, err
+	}
+	report.Inputs = len(paths)
+	return report, nil
+}
+
+func newReport(filter Filter) Report {
+	return Report{SchemaVersion: SchemaVersion, GeneratedAt: time.Now().UTC(), Since: filter.Since, Diagnostics: []Diagnostic{}}
+}
+
+func buildReport(filter Filter, paths []string, read func(string, func(Event) error) error, strict bool) (Report, error) {
+	report := newReport(filter)
+	commandCounts := map[string]*CommandSummary{}
+	projectCounts := map[string]int{}
+	stats := newReportStats()
+	for _, path := range paths {
+		readErr := read(path, func(event Event) error {
 			if event.Timestamp.Before(filter.Since) || !matchesFilter(event, filter) {
 				return nil
 			}
 			includeEvent(&report, commandCounts, projectCounts, event)
+			stats.add(event)
 			return nil
 		})
+		if readErr != nil && strict {
+			return report, readErr
+		}
 		if readErr != nil {
 			report.Diagnostics = append(report.Diagnostics, Diagnostic{Level: "error", Message: readErr.Error()})
 		}
@@ -40,6 +67,7 @@ func BuildReport(filter Filter) (Report, error) {
 		}
 		return report.Commands[i].Command < report.Commands[j].Command
 	})
+	stats.apply(&report)
 	for projectID, calls := range projectCounts {
 		report.Projects = append(report.Projects, ProjectSummary{ProjectID: projectID, Calls: calls})
 	}
@@ -122,12 +150,20 @@ func Status(projectRoot string) (StorageStatus, error) {
 		return status, err
 	}
 	status.ShardCount = len(shards)
-	for _, item := range shards {
-		status.TotalBytes += item.size
-		if item.size > MaxShardBytes {
-			status.Diagnostics = append(status.Diagnostics, Diagnostic{Level: "warning", Message: fmt.Sprintf("%s exceeds the shard bound", item.name)})
-		}
-		if err := readEvents(item.path, func(event Event) error {
+	spooled, err := listSpool(dir)
+	if err != nil {
+		return status, err
+	}
+	status.SpooledEvents = len(spooled)
+	corrupt, err := listCorruptSpool(dir)
+	if err != nil {
+		return status, err
+	}
+	if len(corrupt) > 0 {
+		status.Diagnostics = append(status.Diagnostics, Diagnostic{Level: "warning", Message: fmt.Sprintf("%d unparseable spool files were set aside as %s* in %s; inspect and remove them", len(corrupt), corruptSpoolPrefix, filepath.Join(dir, spoolDirName))})
+	}
+	coverage := func(path string) {
+		if err := readEvents(path, func(event Event) error {
 			if status.CoverageStart == nil || event.Timestamp.Before(*status.CoverageStart) {
 				value := event.Timestamp
 				status.CoverageStart = &value
@@ -141,6 +177,18 @@ func Status(projectRoot string) (StorageStatus, error) {
 			status.Diagnostics = append(status.Diagnostics, Diagnostic{Level: "error", Message: err.Error()})
 		}
 	}
+	for _, item := range shards {
+		status.TotalBytes += item.size
+		if item.size > MaxShardBytes {
+			status.Diagnostics = append(status.Diagnostics, Diagnostic{Level: "warning", Message: fmt.Sprintf("%s exceeds the shard bound", item.name)})
+		}
+		coverage(item.path)
+	}
+	for _, path := range spooled {
+		if _, err := os.Stat(path); err == nil {
+			coverage(path)
+		}
+	}
 	if status.TotalBytes > MaxTotalBytes {
 		status.Diagnostics = append(status.Diagnostics, Diagnostic{Level: "warning", Message: "usage storage exceeds the total bound; run kit usage refresh"})
 	}
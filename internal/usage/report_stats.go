@@ -0,0 +1,218 @@
+package usage
+
+import (
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// outcomeStats accumulates call outcomes and elapsed times for one slice of
+// the report: a command, a version, or a week.
+type outcomeStats struct {
+	calls     int
+	successes int
+	failures  int
+	elapsedMS []int64
+}
+
+func (s *outcomeStats) add(event Event) {
+	s.calls++
+	if event.Success {
+		s.successes++
+	} else {
+		s.failures++
+	}
+	s.elapsedMS = append(s.elapsedMS, max(event.ElapsedMS, 0))
+}
+
+func (s *outcomeStats) failureRate() float64 {
+	if s.calls == 0 {
+		return 0
+	}
+	return float64(s.failures) / float64(s.calls)
+}
+
+func (s *outcomeStats) latency() LatencySummary {
+	if len(s.elapsedMS) == 0 {
+		return LatencySummary{}
+	}
+	sorted := append([]int64(nil), s.elapsedMS...)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i] < sorted[j] })
+	return LatencySummary{
+		P50MS: percentile(sorted, 50),
+		P90MS: percentile(sorted, 90),
+		P99MS: percentile(sorted, 99),
+		MaxMS: sorted[len(sorted)-1],
+	}
+}
+
+// percentile returns the nearest-rank percentile of sorted values.
+func percentile(sorted []int64, p int) int64 {
+	rank := (p*len(sorted) + 99) / 100
+	return sorted[min(max(rank, 1), len(sorted))-1]
+}
+
+// reportStats holds the per-command, per-version, and weekly breakdowns
+// gathered while BuildReport scans shards.
+type reportStats struct {
+	total           outcomeStats
+	commands        map[string]*outcomeStats
+	commandVersions map[string]map[string]*outcomeStats
+	versions        map[string]*outcomeStats
+	weeks           map[time.Time]*outcomeStats
+	flagRecorded    map[string]int
+	commandFlags    map[string]map[string]*outcomeStats
+	commandClasses  map[string]map[ErrorClass]int
+}
+
+func newReportStats() *reportStats {
+	return &reportStats{
+		commands:        map[string]*outcomeStats{},
+		commandVersions: map[string]map[string]*outcomeStats{},
+		versions:        map[string]*outcomeStats{},
+		weeks:           map[time.Time]*outcomeStats{},
+		flagRecorded:    map[string]int{},
+		commandFlags:    map[string]map[string]*outcomeStats{},
+		commandClasses:  map[string]map[ErrorClass]int{},
+	}
+}
+
+func (r *reportStats) add(event Event) {
+	version := strings.TrimSpace(event.Version)
+	if version == "" {
+		version = "unknown"
+	}
+	r.total.add(event)
+	statsFor(r.commands, event.Command).add(event)
+	if r.commandVersions[event.Command] == nil {
+		r.commandVersions[event.Command] = map[string]*outcomeStats{}
+	}
+	statsFor(r.commandVersions[event.Command], version).add(event)
+	statsFor(r.versions, version).add(event)
+	statsFor(r.weeks, weekStart(event.Timestamp)).add(event)
+	if !event.Success && event.ErrorClass != "" {
+		if r.commandClasses[event.Command] == nil {
+			r.commandClasses[event.Command] = map[ErrorClass]int{}
+		}
+		r.commandClasses[event.Command][event.ErrorClass]++
+	}
+	if event.Flags == nil {
+		return
+	}
+	r.flagRecorded[event.Command]++
+	if r.commandFlags[event.Command] == nil {
+		r.commandFlags[event.Command] = map[string]*outcomeStats{}
+	}
+	for _, flag := range event.Flags {
+		statsFor(r.commandFlags[event.Command], flag).add(event)
+	}
+}
+
+func (r *reportStats) apply(report *Report) {
+	report.Latency = r.total.latency()
+	for index := range report.Commands {
+		command := report.Commands[index].Command
+		report.Commands[index].Latency = r.commands[command].latency()
+		report.Commands[index].Versions = versionSummaries(r.commandVersions[command])
+		report.Commands[index].FlagRecordedCalls = r.flagRecorded[command]
+		report.Commands[index].Flags = flagSummaries(r.commandFlags[command])
+		report.Commands[index].FailureClasses = topFailureClasses(r.commandClasses[command])
+	}
+	report.Versions = versionSummaries(r.versions)
+	report.Weekly = []TrendBucket{}
+	for week, stats := range r.weeks {
+		report.Weekly = append(report.Weekly, TrendBucket{
+			WeekStart: week.Format("2006-01-02"),
+			Calls:     stats.calls, Successes: stats.successes, Failures: stats.failures,
+			FailureRate: stats.failureRate(), Latency: stats.latency(),
+		})
+	}
+	sort.Slice(report.Weekly, func(i, j int) bool { return report.Weekly[i].WeekStart < report.Weekly[j].WeekStart })
+}
+
+func versionSummaries(versions map[string]*outcomeStats) []VersionSummary {
+	summaries := []VersionSummary{}
+	for version, stats := range versions {
+		summaries = append(summaries, VersionSummary{
+			Version: version,
+			Calls:   stats.calls, Successes: stats.successes, Failures: stats.failures,
+			FailureRate: stats.failureRate(), Latency: stats.latency(),
+		})
+	}
+	sort.Slice(summaries, func(i, j int) bool { return versionLess(summaries[i].Version, summaries[j].Version) })
+	return summaries
+}
+
+// versionLess orders versions by semantic version, with versions that do not
+// parse after every release in plain string order and "unknown" last.
+func versionLess(left, right string) bool {
+	leftCore, leftPre, leftOK := parseSemver(left)
+	rightCore, rightPre, rightOK := parseSemver(right)
+	switch {
+	case leftOK != rightOK:
+		return leftOK
+	case !leftOK:
+		if (left == "unknown") != (right == "unknown") {
+			return right == "unknown"
+		}
+		return left < right
+	}
+	for index := range leftCore {
+		if leftCore[index] != rightCore[index] {
+			return leftCore[index] < rightCore[index]
+		}
+	}
+	if (leftPre == "") != (rightPre == "") {
+		return leftPre != ""
+	}
+	if leftPre != rightPre {
+		return leftPre < rightPre
+	}
+	return left < right
+}
+
+// parseSemver splits vMAJOR.MINOR.PATCH[-PRERELEASE][+BUILD]; the build
+// suffix is ignored for ordering.
+func parseSemver(version string) ([3]int, string, bool) {
+	var core [3]int
+	rest, _, _ := strings.Cut(strings.TrimPrefix(version, "v"), "+")
+	rest, prerelease, _ := strings.Cut(rest, "-")
+	parts := strings.Split(rest, ".")
+	if len(parts) != len(core) {
+		return core, "", false
+	}
+	for index, part := range parts {
+		value, err := strconv.Atoi(part)
+		if err != nil || value < 0 {
+			return core, "", false
+		}
+		core[index] = value
+	}
+	return core, prerelease, true
+}
+
+func flagSummaries(flags map[string]*outcomeStats) []FlagSummary {
+	summaries := []FlagSummary{}
+	for flag, stats := range flags {
+		summaries = append(summaries, FlagSummary{Flag: flag, Calls: stats.calls, Failures: stats.failures, FailureRate: stats.failureRate()})
+	}
+	sort.Slice(summaries, func(i, j int) bool { return summaries[i].Flag < summaries[j].Flag })
+	return summaries
+}
+
+func statsFor[K comparable](values map[K]*outcomeStats, key K) *outcomeStats {
+	stats := values[key]
+	if stats == nil {
+		stats = &outcomeStats{}
+		values[key] = stats
+	}
+	return stats
+}
+
+// weekStart truncates a timestamp to 00:00 UTC on the Monday of its week.
+func weekStart(timestamp time.Time) time.Time {
+	day := timestamp.UTC().Truncate(24 * time.Hour)
+	offset := (int(day.Weekday()) + 6) % 7
+	return day.AddDate(0, 0, -offset)
+}
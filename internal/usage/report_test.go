@@ -0,0 +1,110 @@
+package usage
+
+import (
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestBuildReportSummarizesLatencyVersionsAndWeeks(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	dir, _ := Directory()
+	if err := ensurePrivateDirectory(dir); err != nil {
+		t.Fatal(err)
+	}
+	// Wednesday of one week and Monday of the next.
+	week1 := time.Date(2026, 9, 30, 12, 0, 0, 0, time.UTC)
+	week2 := time.Date(2026, 10, 5, 9, 0, 0, 0, time.UTC)
+	var events []Event
+	for elapsed := int64(1); elapsed <= 10; elapsed++ {
+		events = append(events, Event{SchemaVersion: SchemaVersion, Timestamp: week1, Command: "context resolve", Version: "v3.0.0", Success: true, ElapsedMS: elapsed * 10})
+	}
+	events = append(events,
+		Event{SchemaVersion: SchemaVersion, Timestamp: week2, Command: "context resolve", Version: "v3.1.0", ElapsedMS: 900, ExitCode: 1},
+		Event{SchemaVersion: SchemaVersion, Timestamp: week2, Command: "context resolve", Version: "v3.1.0", Success: true, ElapsedMS: 700},
+		Event{SchemaVersion: SchemaVersion, Timestamp: week2, Command: "status", Success: true, ElapsedMS: 5},
+	)
+	if err := writeEvents(filepath.Join(dir, "2026-10-0001.jsonl"), events); err != nil {
+		t.Fatal(err)
+	}
+
+	report, err := BuildReport(Filter{Since: week1.Add(-time.Hour)})
+	if err != nil {
+		t.Fatalf("BuildReport() error = %v", err)
+	}
+	resolve := report.Commands[0]
+	if resolve.Command != "context resolve" || resolve.Latency != (LatencySummary{P50MS: 60, P90MS: 700, P99MS: 900, MaxMS: 900}) {
+		t.Fatalf("context resolve summary = %+v", resolve)
+	}
+	if len(resolve.Versions) != 2 {
+		t.Fatalf("context resolve versions = %+v", resolve.Versions)
+	}
+	upgraded := resolve.Versions[1]
+	if upgraded.Version != "v3.1.0" || upgraded.Calls != 2 || upgraded.FailureRate != 0.5 || upgraded.Latency.P50MS != 700 {
+		t.Fatalf("v3.1.0 summary = %+v", upgraded)
+	}
+	if len(report.Versions) != 3 || report.Versions[1].Version != "v3.1.0" || report.Versions[2].Version != "unknown" {
+		t.Fatalf("report versions = %+v", report.Versions)
+	}
+	if len(report.Weekly) != 2 || report.Weekly[0].WeekStart != "2026-09-28" || report.Weekly[0].Calls != 10 {
+		t.Fatalf("weekly = %+v", report.Weekly)
+	}
+	if week := report.Weekly[1]; week.WeekStart != "2026-10-05" || week.Calls != 3 || week.Failures != 1 || week.Latency.MaxMS != 900 {
+		t.Fatalf("second week = %+v", week)
+	}
+}
+
+func TestVersionSummariesSortBySemanticVersion(t *testing.T) {
+	versions := map[string]*outcomeStats{}
+	for _, version := range []string{"unknown", "v3.10.0", "dev", "v3.2.0", "v3.10.0-rc.1", "v3.9.1", "3.9.2"} {
+		versions[version] = &outcomeStats{calls: 1}
+	}
+	var got []string
+	for _, summary := range versionSummaries(versions) {
+		got = append(got, summary.Version)
+	}
+	want := []string{"v3.2.0", "v3.9.1", "3.9.2", "v3.10.0-rc.1", "v3.10.0", "dev", "unknown"}
+	if strings.Join(got, ",") != strings.Join(want, ",") {
+		t.Fatalf("version order = %q, want %q", got, want)
+	}
+}
+
+func TestPercentileUsesNearestRank(t *testing.T) {
+	sorted := []int64{1, 2, 3, 4}
+	for p, want := range map[int]int64{1: 1, 25: 1, 50: 2, 51: 3, 99: 4, 100: 4} {
+		if got := percentile(sorted, p); got != want {
+			t.Fatalf("percentile(%d) = %d, want %d", p, got, want)
+		}
+	}
+}
+
+func TestBuildReportRanksFailureClassesPerCommand(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	inputs := []RecordInput{
+		{Command: "check", ExitCode: 1, ErrorClass: ErrorClassCheckFailed},
+		{Command: "check", ExitCode: 1, ErrorClass: ErrorClassCheckFailed},
+		{Command: "check", ExitCode: 1, ErrorClass: ErrorClassInvalidInput},
+		{Command: "check", ExitCode: 1, ErrorClass: ErrorClass("feature 'secret' not found")},
+		{Command: "check", ErrorClass: ErrorClassCheckFailed},
+	}
+	for _, input := range inputs {
+		if err := Record(input); err != nil {
+			t.Fatal(err)
+		}
+	}
+	report, err := BuildReport(Filter{Since: time.Now().Add(-time.Hour)})
+	if err != nil || len(report.Commands) != 1 {
+		t.Fatalf("BuildReport() = %+v, %v", report, err)
+	}
+	want := []FailureClassSummary{{ErrorClassCheckFailed, 2}, {ErrorClassInvalidInput, 1}, {ErrorClassUnclassified, 1}}
+	got := report.Commands[0].FailureClasses
+	if len(got) != len(want) {
+		t.Fatalf("failure classes = %+v", got)
+	}
+	for index := range want {
+		if got[index] != want[index] {
+			t.Fatalf("failure classes = %+v; want %+v", got, want)
+		}
+	}
+}
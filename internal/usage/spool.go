@@ -0,0 +1,258 @@
+package usage
+
+import (
+	"bytes"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+)
+
+const (
+	spoolDirName = "spool"
+	// corruptSpoolPrefix marks spool files that compaction could not parse.
+	// They are hidden from readers and kept for inspection.
+	corruptSpoolPrefix = ".corrupt-"
+	// maxEventBytes keeps one framed event within PIPE_BUF so every event is
+	// written with a single write call.
+	maxEventBytes = 4096
+)
+
+// writeSpool publishes one framed event as its own spool file without taking
+// the store lock. The event is written to a hidden temporary file and renamed
+// into place, so readers observe either no file or a complete event, and
+// concurrent recorders never share a file.
+func writeSpool(dir string, now time.Time, line []byte) error {
+	if len(line) > maxEventBytes {
+		return fmt.Errorf("usage event exceeds %d bytes", maxEventBytes)
+	}
+	spoolDir := filepath.Join(dir, spoolDirName)
+	if err := os.MkdirAll(spoolDir, 0o700); err != nil {
+		return err
+	}
+	suffix := make([]byte, 6)
+	if _, err := rand.Read(suffix); err != nil {
+		return err
+	}
+	name := fmt.Sprintf("%s-%d-%s.jsonl", now.UTC().Format("20060102T150405.000000000Z"), os.Getpid(), hex.EncodeToString(suffix))
+	temp := filepath.Join(spoolDir, ".tmp-"+name)
+	file, err := os.OpenFile(temp, os.O_CREATE|os.O_EXCL|os.O_APPEND|os.O_WRONLY, 0o600)
+	if err != nil {
+		return err
+	}
+	if _, err := file.Write(line); err != nil {
+		_ = file.Close()
+		_ = os.Remove(temp)
+		return err
+	}
+	if err := file.Close(); err != nil {
+		_ = os.Remove(temp)
+		return err
+	}
+	return os.Rename(temp, filepath.Join(spoolDir, name))
+}
+
+// listSpool returns published spool files in recording order.
+func listSpool(dir string) ([]string, error) {
+	spoolDir := filepath.Join(dir, spoolDirName)
+	entries, err := os.ReadDir(spoolDir)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	var paths []string
+	for _, entry := range entries {
+		if entry.IsDir() || strings.HasPrefix(entry.Name(), ".") || !strings.HasSuffix(entry.Name(), ".jsonl") {
+			continue
+		}
+		paths = append(paths, filepath.Join(spoolDir, entry.Name()))
+	}
+	sort.Strings(paths)
+	return paths, nil
+}
+
+// storeSnapshot reads the local store without the store lock. The spool is
+// listed and read before the shards, so an event compacted in between is
+// found in a shard instead of lost, and a spooled line that already appears
+// in a shard is skipped instead of counted twice. Shards rewritten by prune
+// or clear while a snapshot is read are not covered.
+type storeSnapshot struct {
+	// paths lists shards, then spool files, in the order they must be read.
+	paths   []string
+	spooled map[string][]byte
+	// inShard counts how often each spooled line has been seen in a shard.
+	inShard map[string]int
+}
+
+func snapshotStore(dir string) (*storeSnapshot, error) {
+	spooled, err := listSpool(dir)
+	if err != nil {
+		return nil, err
+	}
+	snapshot := &storeSnapshot{spooled: map[string][]byte{}, inShard: map[string]int{}}
+	for _, path := range spooled {
+		data, err := os.ReadFile(path)
+		if errors.Is(err, os.ErrNotExist) {
+			// Compacted since listing; the shards listed below hold it.
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+		snapshot.spooled[path] = data
+		for _, line := range bytes.SplitAfter(data, []byte("\n")) {
+			snapshot.inShard[string(line)] = 0
+		}
+	}
+	shards, err := listShards(dir)
+	if err != nil {
+		return nil, err
+	}
+	for _, item := range shards {
+		snapshot.paths = append(snapshot.paths, item.path)
+	}
+	for _, path := range spooled {
+		if _, ok := snapshot.spooled[path]; ok {
+			snapshot.paths = append(snapshot.paths, path)
+		}
+	}
+	return snapshot, nil
+}
+
+// read visits the events of one snapshot path. Shards must be read before
+// spool files for duplicates to be skipped.
+func (s *storeSnapshot) read(path string, visit func(Event) error) error {
+	data, spooled := s.spooled[path]
+	if !spooled {
+		return readLines(path, func(line []byte) error {
+			if count, ok := s.inShard[string(line)]; ok {
+				s.inShard[string(line)] = count + 1
+			}
+			event, err := decodeEvent(path, line)
+			if err != nil {
+				return err
+			}
+			return visit(event)
+		})
+	}
+	for _, line := range bytes.SplitAfter(data, []byte("\n")) {
+		if len(bytes.TrimSpace(line)) == 0 {
+			continue
+		}
+		if s.inShard[string(line)] > 0 {
+			s.inShard[string(line)]--
+			continue
+		}
+		event, err := decodeEvent(path, line)
+		if err != nil {
+			return err
+		}
+		if err := visit(event); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// compactSpool moves spooled events into shards. Callers hold the store lock.
+// Each spool file is removed only after its event is appended, so a crash can
+// duplicate an event but never lose one. A spool file that does not parse is
+// renamed aside with corruptSpoolPrefix so it cannot block later compactions;
+// Status reports it.
+func compactSpool(dir string) error {
+	spooled, err := listSpool(dir)
+	if err != nil {
+		return err
+	}
+	for _, path := range spooled {
+		data, err := os.ReadFile(path)
+		if errors.Is(err, os.ErrNotExist) {
+			continue
+		}
+		if err != nil {
+			return err
+		}
+		var timestamp time.Time
+		events := 0
+		if err := readEvents(path, func(event Event) error {
+			timestamp = event.Timestamp
+			events++
+			return nil
+		}); err != nil {
+			if err := os.Rename(path, filepath.Join(filepath.Dir(path), corruptSpoolPrefix+filepath.Base(path))); err != nil && !errors.Is(err, os.ErrNotExist) {
+				return err
+			}
+			continue
+		}
+		if events > 0 {
+			if err := appendToShard(dir, timestamp, data); err != nil {
+				return err
+			}
+		}
+		if err := os.Remove(path); err != nil && !errors.Is(err, os.ErrNotExist) {
+			return err
+		}
+	}
+	return removeStaleSpoolTemps(dir)
+}
+
+// listCorruptSpool returns spool files compaction set aside as unparseable.
+func listCorruptSpool(dir string) ([]string, error) {
+	spoolDir := filepath.Join(dir, spoolDirName)
+	entries, err := os.ReadDir(spoolDir)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	var paths []string
+	for _, entry := range entries {
+		if !entry.IsDir() && strings.HasPrefix(entry.Name(), corruptSpoolPrefix) {
+			paths = append(paths, filepath.Join(spoolDir, entry.Name()))
+		}
+	}
+	return paths, nil
+}
+
+func appendToShard(dir string, timestamp time.Time, data []byte) error {
+	path, err := writableShard(dir, timestamp, int64(len(data)))
+	if err != nil {
+		return err
+	}
+	file, err := os.OpenFile(path, os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0o600)
+	if err != nil {
+		return err
+	}
+	if _, err := file.Write(data); err != nil {
+		_ = file.Close()
+		return err
+	}
+	return file.Close()
+}
+
+// removeStaleSpoolTemps clears temporary files left by recorders that died
+// before publishing their event.
+func removeStaleSpoolTemps(dir string) error {
+	spoolDir := filepath.Join(dir, spoolDirName)
+	entries, err := os.ReadDir(spoolDir)
+	if err != nil {
+		return nil
+	}
+	for _, entry := range entries {
+		if !strings.HasPrefix(entry.Name(), ".tmp-") {
+			continue
+		}
+		if info, err := entry.Info(); err == nil && time.Since(info.ModTime()) > staleLockAge {
+			_ = os.Remove(filepath.Join(spoolDir, entry.Name()))
+		}
+	}
+	return nil
+}
@@ -0,0 +1,168 @@
+package usage
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/jamesonstone/kit/v3/internal/config"
+)
+
+func TestConcurrentRecordLosesAndTearsNoEvents(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	projectRoot := t.TempDir()
+	if err := config.Save(projectRoot, config.Default()); err != nil {
+		t.Fatal(err)
+	}
+	const workers, perWorker = 16, 40
+	var wg sync.WaitGroup
+	errs := make(chan error, workers*perWorker)
+	for worker := range workers {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for index := range perWorker {
+				elapsed := time.Duration(worker*perWorker+index) * time.Millisecond
+				errs <- Record(RecordInput{Command: "context resolve", Version: "v3.0.0", Elapsed: elapsed, ProjectRoot: projectRoot})
+			}
+		}()
+	}
+	wg.Wait()
+	close(errs)
+	for err := range errs {
+		if err != nil {
+			t.Fatalf("Record() error = %v", err)
+		}
+	}
+	if _, err := Refresh(false); err != nil {
+		t.Fatalf("Refresh() error = %v", err)
+	}
+
+	dir, _ := Directory()
+	if spooled, err := listSpool(dir); err != nil || len(spooled) != 0 {
+		t.Fatalf("spool after refresh = %v, %v", spooled, err)
+	}
+	shards, err := listShards(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	seen := map[int64]int{}
+	projects := map[string]struct{}{}
+	for _, item := range shards {
+		if err := readEvents(item.path, func(event Event) error {
+			seen[event.ElapsedMS]++
+			projects[event.ProjectID] = struct{}{}
+			return nil
+		}); err != nil {
+			t.Fatalf("torn usage event: %v", err)
+		}
+	}
+	for elapsed := range int64(workers * perWorker) {
+		if seen[elapsed] != 1 {
+			t.Fatalf("event %d recorded %d times", elapsed, seen[elapsed])
+		}
+	}
+	if len(seen) != workers*perWorker || len(projects) != 1 {
+		t.Fatalf("recorded %d distinct events across %d project IDs", len(seen), len(projects))
+	}
+}
+
+func TestRecordSpoolsWhileStoreIsLocked(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	dir, _ := Directory()
+	if err := ensurePrivateDirectory(dir); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.Mkdir(filepath.Join(dir, ".lock"), 0o700); err != nil {
+		t.Fatal(err)
+	}
+	if err := Record(RecordInput{Command: "status", Version: "v3.0.0"}); err != nil {
+		t.Fatalf("Record() with a held lock error = %v", err)
+	}
+	report, err := BuildReport(Filter{Since: time.Now().Add(-time.Hour)})
+	if err != nil || report.TotalCalls != 1 {
+		t.Fatalf("report while locked = %+v, %v", report, err)
+	}
+	status, err := Status("")
+	if err != nil || status.SpooledEvents != 1 || status.ShardCount != 0 {
+		t.Fatalf("status while locked = %+v, %v", status, err)
+	}
+
+	if err := os.Remove(filepath.Join(dir, ".lock")); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := Refresh(false); err != nil {
+		t.Fatalf("Refresh() error = %v", err)
+	}
+	status, err = Status("")
+	if err != nil || status.SpooledEvents != 0 || status.ShardCount != 1 {
+		t.Fatalf("status after compaction = %+v, %v", status, err)
+	}
+}
+
+func TestCompactionSetsAsideUnparseableSpoolFiles(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	dir, _ := Directory()
+	if err := os.MkdirAll(filepath.Join(dir, spoolDirName), 0o700); err != nil {
+		t.Fatal(err)
+	}
+	torn := filepath.Join(dir, spoolDirName, "20260101T000000.000000000Z-1-torn.jsonl")
+	if err := os.WriteFile(torn, []byte("{torn\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	for range 2 {
+		if err := Record(RecordInput{Command: "status", Version: "v3.0.0"}); err != nil {
+			t.Fatalf("Record() with a torn spool file error = %v", err)
+		}
+	}
+	if _, err := os.Stat(filepath.Join(dir, spoolDirName, corruptSpoolPrefix+filepath.Base(torn))); err != nil {
+		t.Fatalf("torn spool file was not set aside: %v", err)
+	}
+	report, err := BuildReport(Filter{Since: time.Now().Add(-time.Hour)})
+	if err != nil || report.TotalCalls != 2 || len(report.Diagnostics) != 0 {
+		t.Fatalf("report after setting aside = %+v, %v", report, err)
+	}
+	status, err := Status("")
+	if err != nil || status.SpooledEvents != 0 || status.ShardCount != 1 || len(status.Diagnostics) != 1 || status.Diagnostics[0].Level != "warning" {
+		t.Fatalf("status after setting aside = %+v, %v", status, err)
+	}
+}
+
+func TestReadersCountAnEventCompactedMidReadOnce(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	dir, _ := Directory()
+	if err := ensurePrivateDirectory(dir); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.Mkdir(filepath.Join(dir, ".lock"), 0o700); err != nil {
+		t.Fatal(err)
+	}
+	if err := Record(RecordInput{Command: "status", Version: "v3.0.0"}); err != nil {
+		t.Fatal(err)
+	}
+	spooled, err := listSpool(dir)
+	if err != nil || len(spooled) != 1 {
+		t.Fatalf("spool = %v, %v", spooled, err)
+	}
+	// A compaction racing a reader appends the event to a shard before it
+	// removes the spool file; readers see both copies in between.
+	data, err := os.ReadFile(spooled[0])
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := appendToShard(dir, time.Now().UTC(), data); err != nil {
+		t.Fatal(err)
+	}
+	report, err := BuildReport(Filter{Since: time.Now().Add(-time.Hour)})
+	if err != nil || report.TotalCalls != 1 {
+		t.Fatalf("report mid-compaction = %+v, %v", report, err)
+	}
+	var export bytes.Buffer
+	header, err := Export(&export, ExportOptions{})
+	if err != nil || header.Events != 1 {
+		t.Fatalf("export mid-compaction = %+v, %v", header, err)
+	}
+}
@@ -18,7 +18,10 @@ import (
 	"time"
 )
 
-var shardPattern = regexp.MustCompile(`^(\d{4}-\d{2})-(\d{4})\.jsonl$`)
+var (
+	shardPattern    = regexp.MustCompile(`^(\d{4}-\d{2})-(\d{4})\.jsonl$`)
+	flagNamePattern = regexp.MustCompile(`^[a-z0-9][a-z0-9-]*$`)
+)
 
 type shard struct {
 	path string
@@ -35,51 +38,50 @@ func Record(input RecordInput) error {
 	if err != nil {
 		return err
 	}
-	return withStoreLock(dir, func() error {
-		if err := ensurePrivateDirectory(dir); err != nil {
-			return err
-		}
-		projectID, err := projectIdentifier(dir, input.ProjectRoot, true)
-		if err != nil {
-			return err
-		}
-		now := time.Now().UTC()
-		event := Event{
-			SchemaVersion: SchemaVersion,
-			Timestamp:     now,
-			Command:       strings.TrimSpace(input.Command),
-			Version:       strings.TrimSpace(input.Version),
-			ExitCode:      input.ExitCode,
-			Success:       input.ExitCode == 0,
-			ElapsedMS:     max(input.Elapsed.Milliseconds(), 0),
-			ProjectID:     projectID,
-			Interactive:   input.Interactive,
-		}
-		line, err := json.Marshal(event)
-		if err != nil {
+	if err := ensurePrivateDirectory(dir); err != nil {
+		return err
+	}
+	projectID, err := projectIdentifier(dir, input.ProjectRoot, true)
+	if err != nil {
+		return err
+	}
+	now := time.Now().UTC()
+	event := Event{
+		SchemaVersion: SchemaVersion,
+		Timestamp:     now,
+		Command:       strings.TrimSpace(input.Command),
+		Version:       strings.TrimSpace(input.Version),
+		ExitCode:      input.ExitCode,
+		Success:       input.ExitCode == 0,
+		ElapsedMS:     max(input.Elapsed.Milliseconds(), 0),
+		ProjectID:     projectID,
+		Interactive:   input.Interactive,
+		Flags:         flagNames(input.Flags),
+		ErrorClass:    recordedErrorClass(input.ExitCode, input.ErrorClass),
+	}
+	line, err := json.Marshal(event)
+	if err != nil {
+		return err
+	}
+	// Recording never waits on the store lock: the event is durable in the
+	// spool first, and compaction with maintenance is skipped while another
+	// process holds the lock.
+	if err := writeSpool(dir, now, append(line, '\n')); err != nil {
+		return err
+	}
+	err = withStoreLock(dir, func() error {
+		if err := compactSpool(dir); err != nil {
 			return err
 		}
-		line = append(line, '\n')
 		if err := automaticMaintenance(dir, now); err != nil {
 			return err
 		}
-		path, err := writableShard(dir, now, int64(len(line)))
-		if err != nil {
-			return err
-		}
-		file, err := os.OpenFile(path, os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0o600)
-		if err != nil {
-			return err
-		}
-		if _, err := file.Write(line); err != nil {
-			_ = file.Close()
-			return err
-		}
-		if err := file.Close(); err != nil {
-			return err
-		}
 		return pruneTotalBytes(dir, false, nil)
 	})
+	if errors.Is(err, errStoreBusy) {
+		return nil
+	}
+	return err
 }
 
 func ensurePrivateDirectory(dir string) error {
@@ -147,6 +149,42 @@ func listShards(dir string) ([]shard, error) {
 }
 
 func readEvents(path string, visit func(Event) error) error {
+	return readLines(path, func(line []byte) error {
+		event, err := decodeEvent(path, line)
+		if err != nil {
+			return err
+		}
+		return visit(event)
+	})
+}
+
+func decodeEvent(path string, line []byte) (Event, error) {
+	var event Event
+	if err := json.Unmarshal(line, &event); err != nil {
+		return Event{}, fmt.Errorf("invalid usage event in %s: %w", filepath.Base(path), err)
+	}
+	if event.SchemaVersion != SchemaVersion {
+		return Event{}, fmt.Errorf("unsupported usage event schema %q in %s", event.SchemaVersion, filepath.Base(path))
+	}
+	// Shards and exports can be edited by hand, so the recording rules are
+	// applied again: no free-text error classes and no flag values.
+	if event.ErrorClass != "" && !ValidErrorClass(event.ErrorClass) {
+		event.ErrorClass = ErrorClassUnclassified
+	}
+	if event.Flags != nil {
+		flags := []string{}
+		for _, flag := range event.Flags {
+			if flagNamePattern.MatchString(flag) {
+				flags = append(flags, flag)
+			}
+		}
+		event.Flags = flags
+	}
+	return event, nil
+}
+
+// readLines visits each non-blank JSONL line in path.
+func readLines(path string, visit func([]byte) error) error {
 	file, err := os.Open(path)
 	if err != nil {
 		return err
@@ -156,14 +194,7 @@ func readEvents(path string, visit func(Event) error) error {
 	for {
 		line, readErr := reader.ReadBytes('\n')
 		if len(strings.TrimSpace(string(line))) > 0 {
-			var event Event
-			if err := json.Unmarshal(line, &event); err != nil {
-				return fmt.Errorf("invalid usage event in %s: %w", filepath.Base(path), err)
-			}
-			if event.SchemaVersion != SchemaVersion {
-				return fmt.Errorf("unsupported usage event schema %q in %s", event.SchemaVersion, filepath.Base(path))
-			}
-			if err := visit(event); err != nil {
+			if err := visit(line); err != nil {
 				return err
 			}
 		}
@@ -183,11 +214,7 @@ func projectIdentifier(dir, root string, create bool) (string, error) {
 	identityPath := filepath.Join(dir, ".identity")
 	data, err := os.ReadFile(identityPath)
 	if errors.Is(err, os.ErrNotExist) && create {
-		data = make([]byte, 32)
-		if _, err := rand.Read(data); err != nil {
-			return "", err
-		}
-		if err := os.WriteFile(identityPath, []byte(hex.EncodeToString(data)), 0o600); err != nil {
+		if data, err = createIdentity(dir, identityPath); err != nil {
 			return "", err
 		}
 	} else if errors.Is(err, os.ErrNotExist) {
@@ -201,3 +228,49 @@ func projectIdentifier(dir, root string, create bool) (string, error) {
 	digest := sha256.Sum256(append(append([]byte{}, data...), []byte("\x00"+filepath.Clean(root))...))
 	return hex.EncodeToString(digest[:16]), nil
 }
+
+// createIdentity publishes a new identity key with a hard link so concurrent
+// first recorders agree on one key; a recorder that loses the race reads the
+// winner's key.
+func createIdentity(dir, identityPath string) ([]byte, error) {
+	key := make([]byte, 32)
+	if _, err := rand.Read(key); err != nil {
+		return nil, err
+	}
+	temp, err := os.CreateTemp(dir, ".identity-*.tmp")
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = os.Remove(temp.Name()) }()
+	_, writeErr := temp.WriteString(hex.EncodeToString(key))
+	if closeErr := temp.Close(); writeErr == nil {
+		writeErr = closeErr
+	}
+	if writeErr != nil {
+		return nil, writeErr
+	}
+	if err := os.Chmod(temp.Name(), 0o600); err != nil {
+		return nil, err
+	}
+	if err := os.Link(temp.Name(), identityPath); err != nil && !errors.Is(err, os.ErrExist) {
+		return nil, err
+	}
+	return os.ReadFile(identityPath)
+}
+
+// flagNames returns the sorted, unique flag names worth recording. Anything
+// that is not a plain flag name is dropped so a value can never be stored.
+func flagNames(flags []string) []string {
+	names := []string{}
+	seen := map[string]bool{}
+	for _, flag := range flags {
+		name := strings.TrimLeft(strings.TrimSpace(flag), "-")
+		if seen[name] || !flagNamePattern.MatchString(name) {
+			continue
+		}
+		seen[name] = true
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
@@ -9,16 +9,22 @@ const (
 	MaxShardBytes    = int64(2 * 1024 * 1024)
 )
 
+// Event is one recorded command call. Flags holds the sorted names of flags
+// set on the call, never their values; it is nil for events recorded before
+// flag names were collected and empty when no flag was set. ErrorClass is set
+// only on failures.
 type Event struct {
-	SchemaVersion string    `json:"schema_version"`
-	Timestamp     time.Time `json:"timestamp"`
-	Command       string    `json:"command"`
-	Version       string    `json:"version"`
-	ExitCode      int       `json:"exit_code"`
-	Success       bool      `json:"success"`
-	ElapsedMS     int64     `json:"elapsed_ms"`
-	ProjectID     string    `json:"project_id,omitempty"`
-	Interactive   bool      `json:"interactive"`
+	SchemaVersion string     `json:"schema_version"`
+	Timestamp     time.Time  `json:"timestamp"`
+	Command       string     `json:"command"`
+	Version       string     `json:"version"`
+	ExitCode      int        `json:"exit_code"`
+	Success       bool       `json:"success"`
+	ElapsedMS     int64      `json:"elapsed_ms"`
+	ProjectID     string     `json:"project_id,omitempty"`
+	Interactive   bool       `json:"interactive"`
+	Flags         []string   `json:"flags"`
+	ErrorClass    ErrorClass `json:"error_class,omitempty"`
 }
 
 type RecordInput struct {
@@ -28,6 +34,8 @@ type RecordInput struct {
 	Elapsed     time.Duration
 	ProjectRoot string
 	Interactive bool
+	Flags       []string
+	ErrorClass  ErrorClass
 }
 
 type Settings struct {
@@ -49,6 +57,7 @@ type StorageStatus struct {
 	ProjectState  string       `json:"project_state"`
 	Directory     string       `json:"directory"`
 	ShardCount    int          `json:"shard_count"`
+	SpooledEvents int          `json:"spooled_events,omitempty"`
 	TotalBytes    int64        `json:"total_bytes"`
 	RetentionDays int          `json:"retention_days"`
 	MaxTotalBytes int64        `json:"max_total_bytes"`
@@ -61,12 +70,55 @@ type StorageStatus struct {
 }
 
 type CommandSummary struct {
-	Command        string `json:"command"`
-	Calls          int    `json:"calls"`
-	Successes      int    `json:"successes"`
-	Failures       int    `json:"failures"`
-	Interactive    int    `json:"interactive"`
-	NonInteractive int    `json:"non_interactive"`
+	Command        string           `json:"command"`
+	Calls          int              `json:"calls"`
+	Successes      int              `json:"successes"`
+	Failures       int              `json:"failures"`
+	Interactive    int              `json:"interactive"`
+	NonInteractive int              `json:"non_interactive"`
+	Latency        LatencySummary   `json:"latency"`
+	Versions       []VersionSummary `json:"versions"`
+	// FlagRecordedCalls counts calls whose events carry flag names; only
+	// those calls are evidence for Flags.
+	FlagRecordedCalls int           `json:"flag_recorded_calls"`
+	Flags             []FlagSummary `json:"flags"`
+	// FailureClasses lists the top classified failures; failures recorded
+	// before error classes were collected are omitted.
+	FailureClasses []FailureClassSummary `json:"failure_classes"`
+}
+
+type FlagSummary struct {
+	Flag        string  `json:"flag"`
+	Calls       int     `json:"calls"`
+	Failures    int     `json:"failures"`
+	FailureRate float64 `json:"failure_rate"`
+}
+
+// LatencySummary holds nearest-rank elapsed-time percentiles in milliseconds.
+type LatencySummary struct {
+	P50MS int64 `json:"p50_ms"`
+	P90MS int64 `json:"p90_ms"`
+	P99MS int64 `json:"p99_ms"`
+	MaxMS int64 `json:"max_ms"`
+}
+
+type VersionSummary struct {
+	Version     string         `json:"version"`
+	Calls       int            `json:"calls"`
+	Successes   int            `json:"successes"`
+	Failures    int            `json:"failures"`
+	FailureRate float64        `json:"failure_rate"`
+	Latency     LatencySummary `json:"latency"`
+}
+
+// TrendBucket aggregates one UTC week starting on Monday.
+type TrendBucket struct {
+	WeekStart   string         `json:"week_start"`
+	Calls       int            `json:"calls"`
+	Successes   int            `json:"successes"`
+	Failures    int            `json:"failures"`
+	FailureRate float64        `json:"failure_rate"`
+	Latency     LatencySummary `json:"latency"`
 }
 
 type ProjectSummary struct {
@@ -78,6 +130,7 @@ type Report struct {
 	SchemaVersion   string           `json:"schema_version"`
 	GeneratedAt     time.Time        `json:"generated_at"`
 	Since           time.Time        `json:"since"`
+	Inputs          int              `json:"inputs,omitempty"`
 	CoverageStart   *time.Time       `json:"coverage_start,omitempty"`
 	CoverageEnd     *time.Time       `json:"coverage_end,omitempty"`
 	TotalCalls      int              `json:"total_calls"`
@@ -85,7 +138,10 @@ type Report struct {
 	Failures        int              `json:"failures"`
 	Interactive     int              `json:"interactive"`
 	NonInteractive  int              `json:"non_interactive"`
+	Latency         LatencySummary   `json:"latency"`
 	Commands        []CommandSummary `json:"commands"`
+	Versions        []VersionSummary `json:"versions"`
+	Weekly          []TrendBucket    `json:"weekly"`
 	Projects        []ProjectSummary `json:"projects"`
 	ZeroUseCommands []string         `json:"zero_use_commands"`
 	Diagnostics     []Diagnostic     `json:"diagnostics"`
@@ -0,0 +1,222 @@
+package verify
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+)
+
+// outputWaitDelay bounds how long a killed command's descendants may hold its
+// output pipes open before the run moves on.
+const outputWaitDelay = 2 * time.Second
+
+// executeCommand runs one declared command, retrying failed attempts up to
+// command.Retries. The result describes the final attempt; its start time and
+// duration span every attempt.
+func executeCommand(ctx context.Context, opts RunOptions, command Command) CommandResult {
+	result := newCommandResult(opts.ProjectRoot, command)
+	if len(command.Argv) == 0 {
+		result.Error = "command argv is empty"
+		result.EndedAt = time.Now().UTC()
+		result.DurationMS = result.EndedAt.Sub(result.StartedAt).Milliseconds()
+		return result
+	}
+	env, keys, inherited := commandEnvironment(command, os.Environ())
+	result.EnvKeys, result.EnvInherited = keys, inherited
+	attempts := 1 + max(command.Retries, 0)
+	for attempt := 1; attempt <= attempts; attempt++ {
+		if attempt > 1 && ctx.Err() != nil {
+			break
+		}
+		result = runAttempt(ctx, opts, command, result, env, attempt)
+		if result.Status == "pass" {
+			break
+		}
+	}
+	return result
+}
+
+func runAttempt(ctx context.Context, opts RunOptions, command Command, result CommandResult, env []string, attempt int) CommandResult {
+	result.Attempts = attempt
+	result.Status, result.Error, result.TimedOut, result.ExitCode, result.Missing = "fail", "", false, -1, nil
+	name := command.ID
+	if attempt > 1 {
+		name = fmt.Sprintf("%s.attempt-%d", command.ID, attempt)
+	}
+	stdout, err := openCommandStream(opts, name, "stdout")
+	if err != nil {
+		return finishWithError(result, "open command log: "+err.Error())
+	}
+	stderr, err := openCommandStream(opts, name, "stderr")
+	if err != nil {
+		_ = stdout.Close()
+		return finishWithError(result, "open command log: "+err.Error())
+	}
+
+	commandCtx := ctx
+	cancel := func() {}
+	if timeout := commandTimeout(opts, command); timeout > 0 {
+		commandCtx, cancel = context.WithTimeout(ctx, timeout)
+	}
+	defer cancel()
+
+	cmd := exec.CommandContext(commandCtx, command.Argv[0], command.Argv[1:]...)
+	cmd.Dir = result.CWD
+	cmd.Env = env
+	cmd.Stdout = stdout
+	cmd.Stderr = stderr
+	cmd.WaitDelay = outputWaitDelay
+
+	err = cmd.Run()
+	closeErr := errors.Join(stdout.Close(), stderr.Close())
+	result.EndedAt = time.Now().UTC()
+	result.DurationMS = result.EndedAt.Sub(result.StartedAt).Milliseconds()
+	result.Stdout = stdout.memory.String()
+	result.Stderr = stderr.memory.String()
+	result.StdoutPath = stdout.path
+	result.StderrPath = stderr.path
+	result.StdoutSize = stdout.capture.total
+	result.StderrSize = stderr.capture.total
+	result.StdoutTruncated = stdout.capture.truncated()
+	result.Truncated = result.StdoutTruncated || stderr.capture.truncated()
+	result.Redacted = stdout.redacted() || stderr.redacted()
+	if commandCtx.Err() != nil && errors.Is(commandCtx.Err(), context.DeadlineExceeded) {
+		result.TimedOut = true
+		result.Error = "command timed out"
+		return result
+	}
+	result.ExitCode = 0
+	if err != nil {
+		result.ExitCode = exitCode(err)
+		result.Error = err.Error()
+		if result.ExitCode < 0 {
+			return result
+		}
+	}
+	if !exitExpected(command, result.ExitCode) {
+		if result.Error == "" {
+			result.Error = fmt.Sprintf("exit code %d not in expect_exit %v", result.ExitCode, command.ExpectExit)
+		}
+		return result
+	}
+	result.Error = ""
+	if closeErr != nil {
+		result.Error = "write command log: " + closeErr.Error()
+		return result
+	}
+	if result.Missing = missingFiles(result.CWD, command.ExpectedFiles); len(result.Missing) > 0 {
+		result.Error = "expected files missing: " + strings.Join(result.Missing, ", ")
+		return result
+	}
+	result.Status = "pass"
+	return result
+}
+
+func finishWithError(result CommandResult, message string) CommandResult {
+	result.Error = message
+	result.EndedAt = time.Now().UTC()
+	result.DurationMS = result.EndedAt.Sub(result.StartedAt).Milliseconds()
+	return result
+}
+
+func exitCode(err error) int {
+	var exitErr *exec.ExitError
+	if errors.As(err, &exitErr) {
+		return exitErr.ExitCode()
+	}
+	return -1
+}
+
+func commandTimeout(opts RunOptions, command Command) time.Duration {
+	if command.TimeoutSeconds > 0 {
+		return time.Duration(command.TimeoutSeconds) * time.Second
+	}
+	return opts.Timeout
+}
+
+func exitExpected(command Command, code int) bool {
+	if len(command.ExpectExit) == 0 {
+		return code == 0
+	}
+	for _, expected := range command.ExpectExit {
+		if expected == code {
+			return true
+		}
+	}
+	return false
+}
+
+func missingFiles(cwd string, expected []string) []string {
+	var missing []string
+	for _, path := range expected {
+		if _, err := os.Stat(filepath.Join(cwd, filepath.FromSlash(path))); err != nil {
+			missing = append(missing, path)
+		}
+	}
+	return missing
+}
+
+// commandEnvironment applies a command's environment policy to the inherited
+// environment. Without a policy the environment is inherited unchanged and no
+// keys are reported. With an allow list the sorted names of every variable
+// passed are returned; without one only the env: names are, and inheritance
+// of the rest is reported instead of their names.
+func commandEnvironment(command Command, inherited []string) ([]string, []string, bool) {
+	if len(command.Env) == 0 && len(command.EnvAllow) == 0 && len(command.EnvDeny) == 0 {
+		return nil, nil, false
+	}
+	values := make(map[string]string)
+	for _, entry := range inherited {
+		key, value, ok := strings.Cut(entry, "=")
+		if !ok {
+			continue
+		}
+		if len(command.EnvAllow) > 0 && !matchEnvKey(command.EnvAllow, key) {
+			continue
+		}
+		if matchEnvKey(command.EnvDeny, key) {
+			continue
+		}
+		values[key] = value
+	}
+	for key, value := range command.Env {
+		values[key] = value
+	}
+	keys := make([]string, 0, len(values))
+	for key := range values {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	env := make([]string, 0, len(keys))
+	for _, key := range keys {
+		env = append(env, key+"="+values[key])
+	}
+	if len(command.EnvAllow) > 0 {
+		return env, keys, false
+	}
+	reported := make([]string, 0, len(command.Env))
+	for key := range command.Env {
+		reported = append(reported, key)
+	}
+	sort.Strings(reported)
+	return env, reported, true
+}
+
+func matchEnvKey(patterns []string, key string) bool {
+	for _, pattern := range patterns {
+		pattern = strings.TrimSpace(pattern)
+		if prefix, ok := strings.CutSuffix(pattern, "*"); ok && strings.HasPrefix(key, prefix) {
+			return true
+		}
+		if pattern == key {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,83 @@
+package verify
+
+import (
+	"context"
+	"reflect"
+	"testing"
+)
+
+func shellCommand(id, script string) Command {
+	return Command{ID: id, TaskID: "T001", Raw: script, Argv: []string{"sh", "-c", script}, Shell: true}
+}
+
+func TestExecuteRunAppliesEnvironmentPolicy(t *testing.T) {
+	t.Setenv("KIT_TEST_KEEP", "kept")
+	t.Setenv("KIT_TEST_SECRET", "hidden")
+	command := shellCommand("T001-001", `echo "$KIT_TEST_KEEP:$KIT_TEST_SECRET:$EXTRA:$HOME"`)
+	command.EnvAllow = []string{"KIT_TEST_*"}
+	command.EnvDeny = []string{"KIT_TEST_SECRET"}
+	command.Env = map[string]string{"EXTRA": "set"}
+
+	run := ExecuteRun(context.Background(), RunOptions{ProjectRoot: t.TempDir(), Commands: []Command{command}})
+	result := run.Results[0]
+	if result.Status != "pass" || result.Stdout != "kept::set:\n" {
+		t.Fatalf("result = %#v", result)
+	}
+	if !reflect.DeepEqual(result.EnvKeys, []string{"EXTRA", "KIT_TEST_KEEP"}) {
+		t.Fatalf("EnvKeys = %#v", result.EnvKeys)
+	}
+}
+
+func TestExecuteRunRecordsOnlyDeclaredKeysWithoutAllowList(t *testing.T) {
+	t.Setenv("KIT_TEST_INHERITED", "kept")
+	command := shellCommand("T001-001", `echo "$KIT_TEST_INHERITED:$EXTRA"`)
+	command.Env = map[string]string{"EXTRA": "set"}
+
+	run := ExecuteRun(context.Background(), RunOptions{ProjectRoot: t.TempDir(), Commands: []Command{command}})
+	result := run.Results[0]
+	if result.Status != "pass" || result.Stdout != "kept:set\n" {
+		t.Fatalf("result = %#v", result)
+	}
+	if !reflect.DeepEqual(result.EnvKeys, []string{"EXTRA"}) || !result.EnvInherited {
+		t.Fatalf("EnvKeys = %#v, EnvInherited = %v", result.EnvKeys, result.EnvInherited)
+	}
+}
+
+func TestExecuteRunRetriesAndRecordsAttempts(t *testing.T) {
+	command := shellCommand("T001-001", "[ -f marker ] && exit 0; touch marker; exit 1")
+	command.Retries = 2
+	run := ExecuteRun(context.Background(), RunOptions{ProjectRoot: t.TempDir(), Commands: []Command{command}})
+	if result := run.Results[0]; result.Status != "pass" || result.Attempts != 2 || result.Error != "" {
+		t.Fatalf("result = %#v", result)
+	}
+}
+
+func TestExecuteRunChecksExpectedExitCodesAndFiles(t *testing.T) {
+	accepted := shellCommand("T001-001", "touch built.txt; exit 3")
+	accepted.ExpectExit = []int{0, 3}
+	accepted.ExpectedFiles = []string{"built.txt"}
+	unexpected := shellCommand("T001-002", "exit 0")
+	unexpected.ExpectExit = []int{3}
+	missing := shellCommand("T001-003", "true")
+	missing.ExpectedFiles = []string{"absent.txt"}
+
+	run := ExecuteRun(context.Background(), RunOptions{ProjectRoot: t.TempDir(), Commands: []Command{accepted, unexpected, missing}})
+	if result := run.Results[0]; result.Status != "pass" || result.ExitCode != 3 {
+		t.Fatalf("accepted result = %#v", result)
+	}
+	if result := run.Results[1]; result.Status != "fail" || result.Error != "exit code 0 not in expect_exit [3]" {
+		t.Fatalf("unexpected exit result = %#v", result)
+	}
+	if result := run.Results[2]; result.Status != "fail" || !reflect.DeepEqual(result.Missing, []string{"absent.txt"}) {
+		t.Fatalf("missing file result = %#v", result)
+	}
+}
+
+func TestExecuteRunUsesPerCommandTimeout(t *testing.T) {
+	command := shellCommand("T001-001", "exec sleep 5")
+	command.TimeoutSeconds = 1
+	run := ExecuteRun(context.Background(), RunOptions{ProjectRoot: t.TempDir(), Commands: []Command{command}})
+	if result := run.Results[0]; !result.TimedOut || result.Status != "fail" {
+		t.Fatalf("result = %#v", result)
+	}
+}
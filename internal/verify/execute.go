@@ -1,15 +1,15 @@
 package verify
 
 import (
-	"bytes"
 	"context"
 	"crypto/rand"
 	"encoding/hex"
-	"errors"
 	"fmt"
-	"os/exec"
+	"io"
+	"os"
 	"path/filepath"
 	"strings"
+	"sync"
 	"time"
 )
 
@@ -38,9 +38,21 @@ type CommandResult struct {
 	TimedOut   bool      `json:"timed_out,omitempty"`
 	StdoutPath string    `json:"stdout_path,omitempty"`
 	StderrPath string    `json:"stderr_path,omitempty"`
+	StdoutSize int64     `json:"stdout_bytes,omitempty"`
+	StderrSize int64     `json:"stderr_bytes,omitempty"`
+	Truncated  bool      `json:"truncated,omitempty"`
 	Redacted   bool      `json:"redacted,omitempty"`
-	Stdout     string    `json:"-"`
-	Stderr     string    `json:"-"`
+	Attempts   int       `json:"attempts,omitempty"`
+	EnvKeys    []string  `json:"env_keys,omitempty"`
+	// EnvInherited marks a policy without an allow list: EnvKeys then lists
+	// only the env: names, not the inherited ones.
+	EnvInherited bool     `json:"env_inherited,omitempty"`
+	Missing      []string `json:"missing_files,omitempty"`
+	// Stdout and Stderr hold at most the output limit of each stream;
+	// StdoutTruncated reports that Stdout is missing later bytes.
+	Stdout          string `json:"-"`
+	Stderr          string `json:"-"`
+	StdoutTruncated bool   `json:"-"`
 }
 
 type Run struct {
@@ -56,6 +68,8 @@ type Run struct {
 	StartedAt     time.Time       `json:"started_at"`
 	EndedAt       time.Time       `json:"ended_at"`
 	ArtifactDir   string          `json:"artifact_dir,omitempty"`
+	Workers       int             `json:"workers,omitempty"`
+	FailFast      bool            `json:"fail_fast,omitempty"`
 }
 
 type RunOptions struct {
@@ -67,6 +81,23 @@ type RunOptions struct {
 	DryRun        bool
 	Timeout       time.Duration
 	ParentRunID   string
+	// Dependencies maps a task ID to the task IDs that must pass first.
+	Dependencies map[string][]string
+	// Workers bounds concurrently running tasks; values below 1 run serially.
+	Workers int
+	// FailFast cancels running tasks and skips pending ones after a failure.
+	FailFast bool
+	// ArtifactRoot is a project-relative directory; when set, the run writes
+	// redacted per-command logs under ArtifactRoot/<run id>/logs/.
+	ArtifactRoot string
+	// OutputLimit caps bytes kept per stream in memory and in each log;
+	// values below 1 use DefaultOutputLimit.
+	OutputLimit int64
+	// Tee receives redacted output live, one command-prefixed line at a time.
+	Tee io.Writer
+
+	logDir  string
+	teeLock *sync.Mutex
 }
 
 func NewRunID(now time.Time) string {
@@ -92,6 +123,8 @@ func ExecuteRun(ctx context.Context, opts RunOptions) Run {
 		ExpectedFiles: append([]string(nil), opts.ExpectedFiles...),
 		Commands:      append([]Command(nil), opts.Commands...),
 		StartedAt:     startedAt,
+		Workers:       opts.Workers,
+		FailFast:      opts.FailFast,
 	}
 
 	if opts.DryRun {
@@ -105,89 +138,28 @@ func ExecuteRun(ctx context.Context, opts RunOptions) Run {
 		return run
 	}
 
+	if opts.ArtifactRoot != "" {
+		run.ArtifactDir = filepath.ToSlash(filepath.Join(opts.ArtifactRoot, run.RunID))
+		opts.logDir = run.ArtifactDir + "/logs"
+		// A failure here surfaces as a per-command log error below.
+		_ = os.MkdirAll(filepath.Join(opts.ProjectRoot, filepath.FromSlash(opts.logDir)), 0o755)
+	}
+	if opts.Tee != nil {
+		opts.teeLock = &sync.Mutex{}
+	}
+
 	status := RunStatusPass
-	for _, command := range opts.Commands {
-		result := executeCommand(ctx, opts.ProjectRoot, command, opts.Timeout)
+	run.Results = scheduleCommands(ctx, opts)
+	for _, result := range run.Results {
 		if result.Status != "pass" {
 			status = RunStatusFail
 		}
-		run.Results = append(run.Results, result)
 	}
 	run.Status = status
 	run.EndedAt = time.Now().UTC()
 	return run
 }
 
-func executeCommand(ctx context.Context, projectRoot string, command Command, timeout time.Duration) CommandResult {
-	startedAt := time.Now().UTC()
-	result := CommandResult{
-		CommandID: command.ID,
-		TaskID:    command.TaskID,
-		Argv:      append([]string(nil), command.Argv...),
-		Raw:       command.Raw,
-		Shell:     command.Shell,
-		CWD:       command.CWD,
-		StartedAt: startedAt,
-		ExitCode:  -1,
-		Status:    "fail",
-	}
-	if result.CWD == "" {
-		result.CWD = projectRoot
-	}
-	if !filepath.IsAbs(result.CWD) {
-		result.CWD = filepath.Join(projectRoot, result.CWD)
-	}
-
-	if len(command.Argv) == 0 {
-		result.Error = "command argv is empty"
-		result.EndedAt = time.Now().UTC()
-		result.DurationMS = result.EndedAt.Sub(result.StartedAt).Milliseconds()
-		return result
-	}
-
-	commandCtx := ctx
-	cancel := func() {}
-	if timeout > 0 {
-		commandCtx, cancel = context.WithTimeout(ctx, timeout)
-	}
-	defer cancel()
-
-	cmd := exec.CommandContext(commandCtx, command.Argv[0], command.Argv[1:]...)
-	cmd.Dir = result.CWD
-	var stdout bytes.Buffer
-	var stderr bytes.Buffer
-	cmd.Stdout = &stdout
-	cmd.Stderr = &stderr
-
-	err := cmd.Run()
-	result.EndedAt = time.Now().UTC()
-	result.DurationMS = result.EndedAt.Sub(result.StartedAt).Milliseconds()
-	result.Stdout = stdout.String()
-	result.Stderr = stderr.String()
-	if commandCtx.Err() != nil && errors.Is(commandCtx.Err(), context.DeadlineExceeded) {
-		result.TimedOut = true
-		result.Error = "command timed out"
-		return result
-	}
-	if err != nil {
-		result.ExitCode = exitCode(err)
-		result.Error = err.Error()
-		return result
-	}
-
-	result.ExitCode = 0
-	result.Status = "pass"
-	return result
-}
-
-func exitCode(err error) int {
-	var exitErr *exec.ExitError
-	if errors.As(err, &exitErr) {
-		return exitErr.ExitCode()
-	}
-	return -1
-}
-
 func RunSummary(run Run) string {
 	var builder strings.Builder
 	fmt.Fprintf(&builder, "run %s: %s", run.RunID, run.Status)
@@ -0,0 +1,262 @@
+package verify
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sync"
+)
+
+// DefaultOutputLimit caps each command stream kept in memory and in its log.
+const DefaultOutputLimit int64 = 1 << 20
+
+// maxPendingLine bounds how much of an unterminated line the redactor holds
+// before treating it as a complete line.
+const maxPendingLine = 64 << 10
+
+// redactOverlap is the tail of an over-long line held back when it is
+// flushed. It covers the longest prefix of a secret that does not yet match a
+// pattern, so such a prefix is never split from the rest of its secret.
+const redactOverlap = 256
+
+const redactedMarker = "[REDACTED]"
+
+var (
+	secretPatterns = []*regexp.Regexp{
+		regexp.MustCompile(`gh[pousr]_[A-Za-z0-9_]{20,}`),
+		regexp.MustCompile(`(?i)(api[_-]?key|token|secret|password)=\S+`),
+		regexp.MustCompile(`-----BEGIN [A-Z ]*PRIVATE KEY-----[\s\S]*?-----END [A-Z ]*PRIVATE KEY-----`),
+	}
+	privateKeyBegin = regexp.MustCompile(`-----BEGIN [A-Z ]*PRIVATE KEY-----`)
+	privateKeyEnd   = regexp.MustCompile(`-----END [A-Z ]*PRIVATE KEY-----`)
+)
+
+// Redact replaces tokens, key=value secrets, and private key blocks.
+func Redact(value string) string {
+	out := value
+	for _, pattern := range secretPatterns {
+		out = pattern.ReplaceAllString(out, redactedMarker)
+	}
+	return out
+}
+
+// redactingWriter applies Redact line by line so streamed output is redacted
+// before it reaches a log or terminal. Private key blocks spanning lines are
+// replaced as a whole.
+type redactingWriter struct {
+	dst      io.Writer
+	pending  []byte
+	inKey    bool
+	redacted bool
+}
+
+func (w *redactingWriter) Write(p []byte) (int, error) {
+	w.pending = append(w.pending, p...)
+	for {
+		index := bytes.IndexByte(w.pending, '\n')
+		if index < 0 && len(w.pending) < maxPendingLine {
+			return len(p), nil
+		}
+		if index < 0 {
+			index = redactionSplit(w.pending) - 1
+		}
+		line := string(w.pending[:index+1])
+		w.pending = w.pending[index+1:]
+		if err := w.writeLine(line); err != nil {
+			return len(p), err
+		}
+	}
+}
+
+// redactionSplit picks how much of an over-long unterminated line to flush:
+// everything but redactOverlap bytes, moved back to the start of any secret
+// or key marker that would otherwise straddle the split. A single match
+// longer than the whole buffer is flushed as is.
+func redactionSplit(pending []byte) int {
+	split := len(pending) - redactOverlap
+	patterns := append([]*regexp.Regexp{privateKeyBegin, privateKeyEnd}, secretPatterns...)
+	for moved := true; moved && split > 0; {
+		moved = false
+		for _, pattern := range patterns {
+			for _, loc := range pattern.FindAllIndex(pending, -1) {
+				if loc[0] < split && loc[1] > split {
+					split, moved = loc[0], true
+				}
+			}
+		}
+	}
+	if split <= 0 {
+		return len(pending)
+	}
+	return split
+}
+
+func (w *redactingWriter) writeLine(line string) error {
+	if w.inKey {
+		if privateKeyEnd.MatchString(line) {
+			w.inKey = false
+			_, err := io.WriteString(w.dst, redactedMarker+"\n")
+			return err
+		}
+		return nil
+	}
+	if privateKeyBegin.MatchString(line) && !privateKeyEnd.MatchString(line) {
+		w.inKey, w.redacted = true, true
+		return nil
+	}
+	redacted := Redact(line)
+	w.redacted = w.redacted || redacted != line
+	_, err := io.WriteString(w.dst, redacted)
+	return err
+}
+
+func (w *redactingWriter) Flush() error {
+	if len(w.pending) > 0 {
+		line := string(w.pending)
+		w.pending = nil
+		if err := w.writeLine(line); err != nil {
+			return err
+		}
+	}
+	if w.inKey {
+		w.inKey = false
+		_, err := io.WriteString(w.dst, redactedMarker+"\n")
+		return err
+	}
+	return nil
+}
+
+// cappedWriter keeps the first limit bytes and counts the rest.
+type cappedWriter struct {
+	dst     io.Writer
+	limit   int64
+	written int64
+	total   int64
+}
+
+func (w *cappedWriter) Write(p []byte) (int, error) {
+	w.total += int64(len(p))
+	if remaining := w.limit - w.written; remaining > 0 {
+		chunk := p
+		if int64(len(chunk)) > remaining {
+			chunk = chunk[:remaining]
+		}
+		n, err := w.dst.Write(chunk)
+		w.written += int64(n)
+		if err != nil {
+			return len(p), err
+		}
+	}
+	return len(p), nil
+}
+
+func (w *cappedWriter) truncated() bool {
+	return w.total > w.written
+}
+
+func (w *cappedWriter) closeNote() error {
+	if !w.truncated() {
+		return nil
+	}
+	_, err := fmt.Fprintf(w.dst, "\n[truncated: %d bytes omitted]\n", w.total-w.written)
+	return err
+}
+
+// prefixWriter writes whole lines to a shared writer under a lock, prefixed
+// with the command ID, so concurrent commands do not interleave mid-line.
+type prefixWriter struct {
+	mu     *sync.Mutex
+	dst    io.Writer
+	prefix string
+}
+
+func (w prefixWriter) Write(p []byte) (int, error) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	for _, line := range bytes.SplitAfter(p, []byte("\n")) {
+		if len(line) == 0 {
+			continue
+		}
+		if _, err := io.WriteString(w.dst, w.prefix); err != nil {
+			return len(p), err
+		}
+		if _, err := w.dst.Write(line); err != nil {
+			return len(p), err
+		}
+	}
+	return len(p), nil
+}
+
+// commandStream captures one stdout or stderr stream: raw bytes in memory up
+// to the output limit, and redacted bytes to an optional log and tee.
+type commandStream struct {
+	memory   bytes.Buffer
+	capture  *cappedWriter
+	redactor *redactingWriter
+	log      *cappedWriter
+	file     *os.File
+	path     string
+}
+
+func openCommandStream(opts RunOptions, commandID, name string) (*commandStream, error) {
+	limit := opts.OutputLimit
+	if limit <= 0 {
+		limit = DefaultOutputLimit
+	}
+	stream := &commandStream{}
+	stream.capture = &cappedWriter{dst: &stream.memory, limit: limit}
+	var sinks []io.Writer
+	if opts.logDir != "" {
+		stream.path = filepath.ToSlash(filepath.Join(opts.logDir, commandID+"."+name+".log"))
+		file, err := os.Create(filepath.Join(opts.ProjectRoot, filepath.FromSlash(stream.path)))
+		if err != nil {
+			return nil, err
+		}
+		stream.file = file
+		stream.log = &cappedWriter{dst: file, limit: limit}
+		sinks = append(sinks, stream.log)
+	}
+	if opts.Tee != nil {
+		sinks = append(sinks, prefixWriter{mu: opts.teeLock, dst: opts.Tee, prefix: "[" + commandID + "] "})
+	}
+	if len(sinks) > 0 {
+		stream.redactor = &redactingWriter{dst: io.MultiWriter(sinks...)}
+	}
+	return stream, nil
+}
+
+func (s *commandStream) Write(p []byte) (int, error) {
+	_, _ = s.capture.Write(p)
+	if s.redactor != nil {
+		if _, err := s.redactor.Write(p); err != nil {
+			return len(p), err
+		}
+	}
+	return len(p), nil
+}
+
+// Close flushes the redactor, notes truncation, and closes the log file.
+func (s *commandStream) Close() error {
+	var firstErr error
+	if s.redactor != nil {
+		firstErr = s.redactor.Flush()
+	}
+	if s.log != nil {
+		if err := s.log.closeNote(); err != nil && firstErr == nil {
+			firstErr = err
+		}
+	}
+	if s.file != nil {
+		if err := s.file.Close(); err != nil && firstErr == nil {
+			firstErr = err
+		}
+	}
+	return firstErr
+}
+
+func (s *commandStream) redacted() bool {
+	return s.redactor != nil && s.redactor.redacted
+}
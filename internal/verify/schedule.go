@@ -0,0 +1,227 @@
+package verify
+
+import (
+	"context"
+	"errors"
+	"path/filepath"
+	"slices"
+	"time"
+)
+
+const commandStatusSkipped = "skipped"
+
+type taskUnit struct {
+	id       string
+	commands []int
+	deps     []string
+}
+
+type taskOutcome struct {
+	id      string
+	passed  bool
+	results map[int]CommandResult
+}
+
+// TaskDependencies maps each task ID to the task IDs it depends on, as
+// declared in the TASKS.md progress table.
+func TaskDependencies(bundles []TaskBundle) map[string][]string {
+	dependencies := make(map[string][]string)
+	for _, bundle := range bundles {
+		if len(bundle.Dependencies) > 0 {
+			dependencies[bundle.TaskID] = append([]string(nil), bundle.Dependencies...)
+		}
+	}
+	return dependencies
+}
+
+// MergeDependencies combines dependency maps, keeping each task's
+// dependencies in first-seen order without duplicates.
+func MergeDependencies(sets ...map[string][]string) map[string][]string {
+	merged := make(map[string][]string)
+	for _, set := range sets {
+		for id, dependencies := range set {
+			for _, dependency := range dependencies {
+				if !slices.Contains(merged[id], dependency) {
+					merged[id] = append(merged[id], dependency)
+				}
+			}
+		}
+	}
+	return merged
+}
+
+// scheduleCommands runs each task's commands in declaration order and
+// independent tasks concurrently, up to opts.Workers at a time. A task starts
+// only after every dependency present in the run has passed; dependencies
+// outside the run are treated as satisfied. Results keep the index of their
+// command in opts.Commands regardless of completion order.
+func scheduleCommands(ctx context.Context, opts RunOptions) []CommandResult {
+	results := make([]CommandResult, len(opts.Commands))
+	units, order := commandUnits(opts.Commands, opts.Dependencies)
+	workers := opts.Workers
+	if workers < 1 {
+		workers = 1
+	}
+	runCtx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	state := make(map[string]string, len(units))
+	done := make(chan taskOutcome)
+	running := 0
+	failed := false
+	for {
+		for progressed := true; progressed; {
+			progressed = false
+			for _, id := range order {
+				if state[id] != "" {
+					continue
+				}
+				unit := units[id]
+				blocked, failedDependency := dependencyState(unit, units, state)
+				switch {
+				case failed && opts.FailFast:
+					skipUnit(results, opts, unit, "skipped after an earlier verification failure (fail-fast)")
+				case failedDependency != "":
+					skipUnit(results, opts, unit, "skipped because dependency "+failedDependency+" did not pass")
+				case blocked || running >= workers:
+					continue
+				default:
+					state[id] = "running"
+					running++
+					go func(unit taskUnit) {
+						done <- runUnit(runCtx, opts, unit)
+					}(unit)
+					continue
+				}
+				state[id] = "fail"
+				progressed = true
+			}
+		}
+		if running == 0 {
+			break
+		}
+		outcome := <-done
+		running--
+		for index, result := range outcome.results {
+			results[index] = result
+		}
+		state[outcome.id] = "pass"
+		if !outcome.passed {
+			state[outcome.id] = "fail"
+			failed = true
+			if opts.FailFast {
+				cancel()
+			}
+		}
+	}
+	for _, id := range order {
+		if state[id] == "" {
+			skipUnit(results, opts, units[id], "task dependency cycle")
+			for _, index := range units[id].commands {
+				results[index].Status = "fail"
+			}
+		}
+	}
+	return results
+}
+
+func commandUnits(commands []Command, dependencies map[string][]string) (map[string]taskUnit, []string) {
+	units := make(map[string]taskUnit)
+	var order []string
+	for index, command := range commands {
+		id := command.TaskID
+		if id == "" {
+			id = "#" + command.ID
+		}
+		unit, ok := units[id]
+		if !ok {
+			unit = taskUnit{id: id, deps: dependencies[command.TaskID]}
+			order = append(order, id)
+		}
+		unit.commands = append(unit.commands, index)
+		units[id] = unit
+	}
+	return units, order
+}
+
+func dependencyState(unit taskUnit, units map[string]taskUnit, state map[string]string) (bool, string) {
+	blocked := false
+	for _, dependency := range unit.deps {
+		if _, ok := units[dependency]; !ok {
+			continue
+		}
+		switch state[dependency] {
+		case "pass":
+		case "fail":
+			return false, dependency
+		default:
+			blocked = true
+		}
+	}
+	return blocked, ""
+}
+
+func runUnit(ctx context.Context, opts RunOptions, unit taskUnit) taskOutcome {
+	outcome := taskOutcome{id: unit.id, passed: true, results: make(map[int]CommandResult, len(unit.commands))}
+	for _, index := range unit.commands {
+		if ctx.Err() != nil || (opts.FailFast && !outcome.passed) {
+			reason := "skipped after an earlier verification failure (fail-fast)"
+			if errors.Is(ctx.Err(), context.DeadlineExceeded) {
+				reason = "skipped after " + deadlineCause(ctx)
+			}
+			outcome.results[index] = skippedResult(opts.ProjectRoot, opts.Commands[index], reason)
+			outcome.passed = false
+			continue
+		}
+		result := executeCommand(ctx, opts, opts.Commands[index])
+		if result.Status != "pass" {
+			outcome.passed = false
+		}
+		outcome.results[index] = result
+	}
+	return outcome
+}
+
+// deadlineCause names the deadline that ended ctx: the cause a caller set
+// with context.WithTimeoutCause, or the run deadline otherwise.
+func deadlineCause(ctx context.Context) string {
+	if cause := context.Cause(ctx); cause != nil && !errors.Is(cause, context.DeadlineExceeded) {
+		return cause.Error()
+	}
+	return "the run deadline expired"
+}
+
+func skipUnit(results []CommandResult, opts RunOptions, unit taskUnit, reason string) {
+	for _, index := range unit.commands {
+		results[index] = skippedResult(opts.ProjectRoot, opts.Commands[index], reason)
+	}
+}
+
+func skippedResult(projectRoot string, command Command, reason string) CommandResult {
+	result := newCommandResult(projectRoot, command)
+	result.Status = commandStatusSkipped
+	result.Error = reason
+	result.EndedAt = result.StartedAt
+	return result
+}
+
+func newCommandResult(projectRoot string, command Command) CommandResult {
+	result := CommandResult{
+		CommandID: command.ID,
+		TaskID:    command.TaskID,
+		Argv:      append([]string(nil), command.Argv...),
+		Raw:       command.Raw,
+		Shell:     command.Shell,
+		CWD:       command.CWD,
+		StartedAt: time.Now().UTC(),
+		ExitCode:  -1,
+		Status:    "fail",
+	}
+	if result.CWD == "" {
+		result.CWD = projectRoot
+	}
+	if !filepath.IsAbs(result.CWD) {
+		result.CWD = filepath.Join(projectRoot, result.CWD)
+	}
+	return result
+}
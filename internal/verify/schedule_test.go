@@ -0,0 +1,82 @@
+package verify
+
+import (
+	"context"
+	"strings"
+	"testing"
+)
+
+func rendezvousCommand(id, task, mine, other string) Command {
+	script := "touch " + mine + "; for i in $(seq 100); do [ -f " + other + " ] && exit 0; sleep 0.05; done; exit 1"
+	return Command{ID: id, TaskID: task, Raw: script, Argv: []string{"sh", "-c", script}, Shell: true}
+}
+
+func staticCommand(id, task, program string) Command {
+	return Command{ID: id, TaskID: task, Raw: program, Argv: []string{program}}
+}
+
+func TestExecuteRunRunsIndependentTasksConcurrently(t *testing.T) {
+	run := ExecuteRun(context.Background(), RunOptions{
+		ProjectRoot: t.TempDir(),
+		Commands: []Command{
+			rendezvousCommand("T001-001", "T001", "a", "b"),
+			rendezvousCommand("T002-001", "T002", "b", "a"),
+		},
+		Workers: 2,
+	})
+	if run.Status != RunStatusPass {
+		t.Fatalf("Status = %q, results = %#v", run.Status, run.Results)
+	}
+	if run.Results[0].CommandID != "T001-001" || run.Results[1].CommandID != "T002-001" {
+		t.Fatalf("results are not in declaration order: %#v", run.Results)
+	}
+}
+
+func TestExecuteRunSkipsDependentsOfFailedTasksAndKeepsGoing(t *testing.T) {
+	run := ExecuteRun(context.Background(), RunOptions{
+		ProjectRoot: t.TempDir(),
+		Commands: []Command{
+			staticCommand("T002-001", "T002", "true"),
+			staticCommand("T001-001", "T001", "false"),
+			staticCommand("T003-001", "T003", "true"),
+		},
+		Dependencies: map[string][]string{"T002": {"T001"}, "T003": {"T999"}},
+		Workers:      4,
+	})
+	statuses := []string{run.Results[0].Status, run.Results[1].Status, run.Results[2].Status}
+	if strings.Join(statuses, ",") != "skipped,fail,pass" {
+		t.Fatalf("statuses = %v, results = %#v", statuses, run.Results)
+	}
+	if !strings.Contains(run.Results[0].Error, "dependency T001") || run.Status != RunStatusFail {
+		t.Fatalf("dependent result = %#v, run status = %q", run.Results[0], run.Status)
+	}
+}
+
+func TestExecuteRunFailFastSkipsPendingTasks(t *testing.T) {
+	run := ExecuteRun(context.Background(), RunOptions{
+		ProjectRoot: t.TempDir(),
+		Commands: []Command{
+			staticCommand("T001-001", "T001", "false"),
+			staticCommand("T001-002", "T001", "true"),
+			staticCommand("T002-001", "T002", "true"),
+		},
+		FailFast: true,
+	})
+	statuses := []string{run.Results[0].Status, run.Results[1].Status, run.Results[2].Status}
+	if strings.Join(statuses, ",") != "fail,skipped,skipped" {
+		t.Fatalf("statuses = %v, results = %#v", statuses, run.Results)
+	}
+}
+
+func TestExecuteRunReportsTaskDependencyCycles(t *testing.T) {
+	run := ExecuteRun(context.Background(), RunOptions{
+		ProjectRoot:  t.TempDir(),
+		Commands:     []Command{staticCommand("T001-001", "T001", "true"), staticCommand("T002-001", "T002", "true")},
+		Dependencies: map[string][]string{"T001": {"T002"}, "T002": {"T001"}},
+	})
+	for _, result := range run.Results {
+		if result.Status != "fail" || result.Error != "task dependency cycle" {
+			t.Fatalf("cycle result = %#v", result)
+		}
+	}
+}
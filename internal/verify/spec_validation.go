@@ -0,0 +1,214 @@
+package verify
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"gopkg.in/yaml.v3"
+)
+
+// ArtifactDir holds persisted verification runs, relative to the project root.
+const ArtifactDir = ".kit/verify"
+
+// ValidationTaskID labels commands declared in a V3 spec VALIDATION section.
+const ValidationTaskID = "VALIDATION"
+
+// ValidationFenceInfo is the fenced-code info string that marks an executable
+// validation block inside a V3 spec VALIDATION section.
+const ValidationFenceInfo = "kit-validation"
+
+var (
+	validationHeadingPattern = regexp.MustCompile(`^##\s+VALIDATION\s*$`)
+	validationFencePattern   = regexp.MustCompile("^(`{3,}|~{3,})\\s*(\\S*)\\s*$")
+)
+
+type validationEntry struct {
+	ID            string            `yaml:"id"`
+	Run           string            `yaml:"run"`
+	CWD           string            `yaml:"cwd"`
+	Needs         []string          `yaml:"needs"`
+	Env           map[string]string `yaml:"env"`
+	EnvAllow      []string          `yaml:"env_allow"`
+	EnvDeny       []string          `yaml:"env_deny"`
+	Retries       int               `yaml:"retries"`
+	Timeout       string            `yaml:"timeout"`
+	ExpectExit    []int             `yaml:"expect_exit"`
+	ExpectedFiles []string          `yaml:"expected_files"`
+}
+
+func (e *validationEntry) UnmarshalYAML(node *yaml.Node) error {
+	if node.Kind == yaml.ScalarNode {
+		e.Run = node.Value
+		return nil
+	}
+	type plain validationEntry
+	return node.Decode((*plain)(e))
+}
+
+// LoadSpecValidation parses every `kit-validation` block in the VALIDATION
+// section of a V3 SPEC.md. Each block is a YAML list whose items are either a
+// command string or a mapping with `run` and optional relative `cwd`, `id`,
+// and `needs`. Entries sharing an `id` form one task that runs in order;
+// `needs` lists task IDs that must pass first, and entries without an `id`
+// belong to the VALIDATION task. A spec without a block declares no checks.
+func LoadSpecValidation(specPath string, allowShell bool) ([]Command, map[string][]string, error) {
+	data, err := os.ReadFile(specPath)
+	if err != nil {
+		return nil, nil, err
+	}
+	var commands []Command
+	dependencies := make(map[string][]string)
+	for _, block := range validationBlocks(string(data)) {
+		var entries []validationEntry
+		if err := yaml.Unmarshal([]byte(block), &entries); err != nil {
+			return nil, nil, fmt.Errorf("%s %s block: %w", filepath.Base(specPath), ValidationFenceInfo, err)
+		}
+		for _, entry := range entries {
+			index := len(commands) + 1
+			taskID := strings.TrimSpace(entry.ID)
+			if taskID == "" {
+				taskID = ValidationTaskID
+			}
+			command, err := ParseCommand(entry.Run, ValidationTaskID, index, specPath, allowShell)
+			if err != nil {
+				return nil, nil, fmt.Errorf("%s VALIDATION command %d: %w", filepath.Base(specPath), index, err)
+			}
+			command.TaskID = taskID
+			cwd := filepath.ToSlash(filepath.Clean(strings.TrimSpace(entry.CWD)))
+			if filepath.IsAbs(cwd) || cwd == ".." || strings.HasPrefix(cwd, "../") {
+				return nil, nil, fmt.Errorf("%s VALIDATION command %d: cwd %q must stay inside the project", filepath.Base(specPath), index, entry.CWD)
+			}
+			if cwd != "." {
+				command.CWD = cwd
+			}
+			if err := applyValidationPolicy(&command, entry); err != nil {
+				return nil, nil, fmt.Errorf("%s VALIDATION command %d: %w", filepath.Base(specPath), index, err)
+			}
+			for _, need := range entry.Needs {
+				if need = strings.TrimSpace(need); need != "" {
+					dependencies[taskID] = append(dependencies[taskID], need)
+				}
+			}
+			commands = append(commands, command)
+		}
+	}
+	return commands, dependencies, nil
+}
+
+func applyValidationPolicy(command *Command, entry validationEntry) error {
+	if entry.Retries < 0 {
+		return fmt.Errorf("retries must be zero or more")
+	}
+	timeout, err := parseTimeoutSeconds(entry.Timeout)
+	if err != nil {
+		return err
+	}
+	for _, path := range entry.ExpectedFiles {
+		clean := filepath.ToSlash(filepath.Clean(strings.TrimSpace(path)))
+		if clean == "." || filepath.IsAbs(clean) || clean == ".." || strings.HasPrefix(clean, "../") {
+			return fmt.Errorf("expected file %q must be a relative path inside the project", path)
+		}
+		command.ExpectedFiles = append(command.ExpectedFiles, clean)
+	}
+	command.Env = entry.Env
+	command.EnvAllow = entry.EnvAllow
+	command.EnvDeny = entry.EnvDeny
+	command.Retries = entry.Retries
+	command.TimeoutSeconds = timeout
+	command.ExpectExit = entry.ExpectExit
+	return nil
+}
+
+// parseTimeoutSeconds accepts whole seconds or a Go duration such as `90s` or
+// `5m`, rounding partial seconds up.
+func parseTimeoutSeconds(value string) (int, error) {
+	value = strings.TrimSpace(value)
+	if value == "" {
+		return 0, nil
+	}
+	if seconds, err := strconv.Atoi(value); err == nil && seconds > 0 {
+		return seconds, nil
+	}
+	duration, err := time.ParseDuration(value)
+	if err != nil || duration <= 0 {
+		return 0, fmt.Errorf("timeout %q must be positive seconds or a duration like 90s", value)
+	}
+	return int((duration + time.Second - 1) / time.Second), nil
+}
+
+func validationBlocks(content string) []string {
+	var blocks []string
+	var current strings.Builder
+	inSection := false
+	fence := ""
+	capturing := false
+	for _, line := range strings.Split(strings.ReplaceAll(content, "\r\n", "\n"), "\n") {
+		if fence != "" {
+			if strings.HasPrefix(strings.TrimSpace(line), fence) && strings.Trim(strings.TrimSpace(line), fence[:1]) == "" {
+				if capturing {
+					blocks = append(blocks, current.String())
+					current.Reset()
+				}
+				fence, capturing = "", false
+				continue
+			}
+			if capturing {
+				current.WriteString(line + "\n")
+			}
+			continue
+		}
+		if strings.HasPrefix(line, "## ") {
+			inSection = validationHeadingPattern.MatchString(line)
+			continue
+		}
+		if match := validationFencePattern.FindStringSubmatch(strings.TrimSpace(line)); match != nil {
+			fence = match[1]
+			capturing = inSection && match[2] == ValidationFenceInfo
+		}
+	}
+	return blocks
+}
+
+// WriteRun persists run JSON as run.json in the run's ArtifactDir, defaulting
+// to ArtifactDir/<feature>/<run id>, and refreshes latest.json in the parent
+// directory. It returns the run.json path.
+func WriteRun(projectRoot string, run *Run) (string, error) {
+	if run.ArtifactDir == "" {
+		run.ArtifactDir = filepath.ToSlash(filepath.Join(ArtifactDir, run.Feature.DirName, run.RunID))
+	}
+	runDir := filepath.Join(projectRoot, filepath.FromSlash(run.ArtifactDir))
+	data, err := json.MarshalIndent(run, "", "  ")
+	if err != nil {
+		return "", err
+	}
+	data = append(data, '\n')
+	if err := os.MkdirAll(runDir, 0o755); err != nil {
+		return "", err
+	}
+	runPath := filepath.Join(runDir, "run.json")
+	if err := os.WriteFile(runPath, data, 0o644); err != nil {
+		return "", err
+	}
+	if err := os.WriteFile(filepath.Join(filepath.Dir(runDir), "latest.json"), data, 0o644); err != nil {
+		return "", err
+	}
+	return runPath, nil
+}
+
+// FeatureArtifactRoot returns the project-relative directory that holds a
+// feature's validation runs.
+func FeatureArtifactRoot(featureDirName string) string {
+	return filepath.ToSlash(filepath.Join(ArtifactDir, featureDirName))
+}
+
+// LatestRunPath returns the project-relative path of a feature's most recent
+// persisted validation run.
+func LatestRunPath(featureDirName string) string {
+	return FeatureArtifactRoot(featureDirName) + "/latest.json"
+}
@@ -0,0 +1,99 @@
+package verify
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestLoadSpecValidationParsesOnlyValidationSectionBlocks(t *testing.T) {
+	dir := t.TempDir()
+	specPath := filepath.Join(dir, "SPEC.md")
+	content := "# SPEC\n\n## CONTEXT\n\n```kit-validation\n- ignored outside validation\n```\n\n## VALIDATION\n\n" +
+		"```go\n## not a heading\n```\n\n" +
+		"```kit-validation\n- go test ./...\n- run: go vet ./...\n  cwd: internal\n  id: vet\n  needs: [VALIDATION]\n```\n\n## OUTCOME\n\nDone.\n"
+	if err := os.WriteFile(specPath, []byte(content), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	commands, dependencies, err := LoadSpecValidation(specPath, false)
+	if err != nil {
+		t.Fatalf("LoadSpecValidation() error = %v", err)
+	}
+	if len(commands) != 2 {
+		t.Fatalf("commands = %#v, want 2", commands)
+	}
+	if commands[0].ID != "VALIDATION-001" || commands[0].Raw != "go test ./..." || commands[0].CWD != "" {
+		t.Fatalf("commands[0] = %#v", commands[0])
+	}
+	if commands[1].ID != "VALIDATION-002" || commands[1].TaskID != "vet" || commands[1].CWD != "internal" {
+		t.Fatalf("commands[1] = %#v", commands[1])
+	}
+	if len(dependencies["vet"]) != 1 || dependencies["vet"][0] != ValidationTaskID {
+		t.Fatalf("dependencies = %#v", dependencies)
+	}
+}
+
+func TestLoadSpecValidationParsesCommandPolicy(t *testing.T) {
+	specPath := filepath.Join(t.TempDir(), "SPEC.md")
+	content := "## VALIDATION\n\n```kit-validation\n- run: go test ./...\n  env: {CGO_ENABLED: \"0\"}\n  env_allow: [PATH, GO*]\n  env_deny: [GOFLAGS]\n" +
+		"  retries: 2\n  timeout: 1500ms\n  expect_exit: [0, 1]\n  expected_files: [coverage.out]\n```\n"
+	if err := os.WriteFile(specPath, []byte(content), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	commands, _, err := LoadSpecValidation(specPath, false)
+	if err != nil {
+		t.Fatalf("LoadSpecValidation() error = %v", err)
+	}
+	command := commands[0]
+	if command.Env["CGO_ENABLED"] != "0" || len(command.EnvAllow) != 2 || command.EnvDeny[0] != "GOFLAGS" {
+		t.Fatalf("environment policy = %#v", command)
+	}
+	if command.Retries != 2 || command.TimeoutSeconds != 2 || len(command.ExpectExit) != 2 || command.ExpectedFiles[0] != "coverage.out" {
+		t.Fatalf("execution policy = %#v", command)
+	}
+}
+
+func TestLoadSpecValidationRejectsEscapingCWD(t *testing.T) {
+	specPath := filepath.Join(t.TempDir(), "SPEC.md")
+	content := "## VALIDATION\n\n```kit-validation\n- run: go test ./...\n  cwd: ../outside\n```\n"
+	if err := os.WriteFile(specPath, []byte(content), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if _, _, err := LoadSpecValidation(specPath, false); err == nil {
+		t.Fatal("LoadSpecValidation() accepted a cwd outside the project")
+	}
+}
+
+func TestWriteRunPersistsRunAndLatest(t *testing.T) {
+	root := t.TempDir()
+	run := ExecuteRun(context.Background(), RunOptions{
+		ProjectRoot: root,
+		Feature:     FeatureRef{DirName: "0001-alpha"},
+		Commands:    []Command{{ID: "VALIDATION-001", Argv: []string{"true"}, Raw: "true"}},
+	})
+	runPath, err := WriteRun(root, &run)
+	if err != nil {
+		t.Fatalf("WriteRun() error = %v", err)
+	}
+	if run.ArtifactDir != ".kit/verify/0001-alpha/"+run.RunID {
+		t.Fatalf("ArtifactDir = %q", run.ArtifactDir)
+	}
+	latest, err := os.ReadFile(filepath.Join(root, LatestRunPath("0001-alpha")))
+	if err != nil {
+		t.Fatal(err)
+	}
+	persisted, err := os.ReadFile(runPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var decoded Run
+	if err := json.Unmarshal(latest, &decoded); err != nil || string(latest) != string(persisted) {
+		t.Fatalf("latest.json does not mirror run.json: %v", err)
+	}
+	if decoded.Status != RunStatusPass || len(decoded.Results) != 1 {
+		t.Fatalf("persisted run = %#v", decoded)
+	}
+}
@@ -25,6 +25,19 @@ type Command struct {
 	Argv       []string `json:"argv"`
 	CWD        string   `json:"cwd,omitempty"`
 	Shell      bool     `json:"shell"`
+	// Env sets variables after EnvAllow and EnvDeny filter the inherited
+	// environment. Allow and deny entries are names or `PREFIX_*` patterns.
+	Env      map[string]string `json:"env,omitempty"`
+	EnvAllow []string          `json:"env_allow,omitempty"`
+	EnvDeny  []string          `json:"env_deny,omitempty"`
+	// Retries reruns a failed command up to this many extra times.
+	Retries int `json:"retries,omitempty"`
+	// TimeoutSeconds overrides RunOptions.Timeout for this command.
+	TimeoutSeconds int `json:"timeout_seconds,omitempty"`
+	// ExpectExit lists passing exit codes; empty means only 0 passes.
+	ExpectExit []int `json:"expect_exit,omitempty"`
+	// ExpectedFiles must exist, relative to the command CWD, after it exits.
+	ExpectedFiles []string `json:"expected_files,omitempty"`
 }
 
 type TaskBundle struct {
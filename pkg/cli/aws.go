@@ -8,6 +8,7 @@ import (
 	"github.com/spf13/cobra"
 
 	"github.com/jamesonstone/kit/v3/internal/config"
+	"github.com/jamesonstone/kit/v3/internal/usage"
 )
 
 var awsVerifyJSON bool
@@ -50,46 +51,46 @@ func runAWSVerify(cmd *cobra.Command, args []string) error {
 	}
 	cfg, inspection, err := config.LoadWithInspection(projectRoot)
 	if err != nil {
-		return err
+		return withErrorClass(usage.ErrorClassConfigInvalid, err)
 	}
 	if inspection.SchemaState == config.SchemaStateNewer {
-		return fmt.Errorf("%s", inspection.Findings[0].Message)
+		return withErrorClass(usage.ErrorClassConfigInvalid, fmt.Errorf("%s", inspection.Findings[0].Message))
 	}
 	for _, finding := range inspection.Findings {
 		if finding.Severity == config.FindingError {
-			return fmt.Errorf("invalid .kit.yaml field %s: %s; run `kit config check`", finding.Field, finding.Message)
+			return withErrorClass(usage.ErrorClassConfigInvalid, fmt.Errorf("invalid .kit.yaml field %s: %s; run `kit config check`", finding.Field, finding.Message))
 		}
 	}
 	if cfg.AWS == nil {
-		return fmt.Errorf("AWS context is not configured; run `kit config check`")
+		return withErrorClass(usage.ErrorClassConfigInvalid, fmt.Errorf("AWS context is not configured; run `kit config check`"))
 	}
 	if !cfg.AWS.IsEnabled() {
-		return fmt.Errorf("AWS context is disabled in .kit.yaml")
+		return withErrorClass(usage.ErrorClassConfigInvalid, fmt.Errorf("AWS context is disabled in .kit.yaml"))
 	}
 	if !validAWSAccountID(cfg.AWS.AccountID) || strings.TrimSpace(cfg.AWS.Profile) == "" || !config.ValidAWSRegion(cfg.AWS.Region) {
-		return fmt.Errorf("AWS context is incomplete; run `kit config check`")
+		return withErrorClass(usage.ErrorClassConfigInvalid, fmt.Errorf("AWS context is incomplete; run `kit config check`"))
 	}
 
 	profile := strings.TrimSpace(cfg.AWS.Profile)
 	region := strings.TrimSpace(cfg.AWS.Region)
 	if environmentProfile := strings.TrimSpace(os.Getenv("AWS_PROFILE")); environmentProfile != "" && environmentProfile != profile {
-		return fmt.Errorf(
+		return withErrorClass(usage.ErrorClassInvalidInput, fmt.Errorf(
 			"AWS_PROFILE %q does not match .kit.yaml profile %q; unset it or select the configured project profile",
 			environmentProfile,
 			profile,
-		)
+		))
 	}
 	identity, err := resolveAWSIdentity(profile, region)
 	if err != nil {
-		return err
+		return withErrorClass(usage.ErrorClassExternalCommand, err)
 	}
 	if identity.Account != cfg.AWS.AccountID {
-		return fmt.Errorf(
+		return withErrorClass(usage.ErrorClassCheckFailed, fmt.Errorf(
 			"AWS account mismatch: profile %q resolves to %s, but .kit.yaml expects %s",
 			profile,
 			identity.Account,
 			cfg.AWS.AccountID,
-		)
+		))
 	}
 	report := awsVerifyReport{
 		SchemaVersion: config.CurrentSchemaVersion,
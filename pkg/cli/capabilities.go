@@ -6,6 +6,8 @@ import (
 	"strings"
 
 	"github.com/spf13/cobra"
+
+	"github.com/jamesonstone/kit/v3/internal/usage"
 )
 
 type capabilitiesOptions struct {
@@ -79,16 +81,16 @@ func runCapabilities(cmd *cobra.Command, args []string, options capabilitiesOpti
 	commandPath := normalizeCapabilityQuery(strings.Join(args, " "))
 	search := strings.TrimSpace(options.search)
 	if search != "" && commandPath != "" {
-		return fmt.Errorf("--search cannot be combined with a command path")
+		return withErrorClass(usage.ErrorClassInvalidInput, fmt.Errorf("--search cannot be combined with a command path"))
 	}
 	if options.full && commandPath != "" {
-		return fmt.Errorf("--full cannot be combined with a command path")
+		return withErrorClass(usage.ErrorClassInvalidInput, fmt.Errorf("--full cannot be combined with a command path"))
 	}
 
 	if commandPath != "" {
 		record, ok := capabilityByCommandPath(commandPath)
 		if !ok {
-			return unknownCapabilityCommandError(commandPath)
+			return withErrorClass(usage.ErrorClassNotFound, unknownCapabilityCommandError(commandPath))
 		}
 		payload := capabilityDetailPayload{
 			SchemaVersion: capabilitiesSchemaVersion,
@@ -7,8 +7,10 @@ func inspectionCapabilityRecords() []capabilityRecord {
 		capability("registry status", "Inspect & Repair", "Report registry and managed-file freshness.", mutationNetwork, withNetwork("fetches the configured rules registry unless managed health is disabled"), withFlags(flag("--json", "emit machine-readable status", "read-only")), withRelated(related("health", "applies safe maintenance"))),
 		capability("health", "Inspect & Repair", "Apply safe managed updates and validate project health.", mutationWritesFiles, withNetwork("fetches the configured rules registry"), withFileWrites("applies conflict-free managed updates", "--dry-run and --diff do not write; custom and conflicting content is preserved"), withFlags(flag("--dry-run", "preview without writes", "read-only"), flag("--diff", "show dry-run diff", "read-only"), flag("--json", "emit machine-readable results")), withRelated(related("usage report", "weekly maintenance reads aggregate usage once"), related("reconcile", "curates unresolved drift"))),
 		capability("capabilities", "Inspect & Repair", "Describe exact supported commands, side effects, and safety behavior.", mutationNone, withFlags(flag("--json", "emit machine-readable records"), flag("--full", "include full supported records"), flag("--search", "search supported records")), withRelated(related("context resolve", "selects local evidence after command choice"))),
-		capability("usage", "Inspect & Repair", "Report bounded private local command usage data.", mutationNone, withNetwork("none"), withFileWrites("none"), withGitMutation("none"), withFlags(flag("--since", "bound report history"), flag("--command", "filter by normalized command"), flag("--project", "filter by anonymized project"), flag("--json", "emit machine-readable results")), withRelated(related("usage refresh", "applies bounded local storage maintenance"), related("usage clear", "removes selected local history")), withWhenToUse("Use without a subcommand as the read-only aggregate usage report."), withWhenNotToUse("Use the explicit refresh, clear, enable, or disable subcommand when local usage state should change."), withExamples("kit usage --since 30d --json"), withCaveats("Usage commands do not record themselves; no arguments, values, paths, output, prompts, environment, URLs, or secrets are collected.")),
-		capability("usage report", "Inspect & Repair", "Aggregate bounded local command usage.", mutationNone, withFlags(flag("--since", "report window such as 90d"), flag("--command", "command filter"), flag("--project", "anonymized project filter"), flag("--json", "emit JSON"))),
+		capability("usage", "Inspect & Repair", "Report bounded private local command usage data.", mutationNone, withNetwork("none"), withFileWrites("none"), withGitMutation("none"), withFlags(flag("--since", "bound report history"), flag("--command", "filter by normalized command"), flag("--project", "filter by anonymized project"), flag("--input", "aggregate export files offline"), flag("--json", "emit machine-readable results")), withRelated(related("usage refresh", "applies bounded local storage maintenance"), related("usage clear", "removes selected local history"), related("usage export", "writes an anonymized export for pooling")), withWhenToUse("Use without a subcommand as the read-only aggregate usage report."), withWhenNotToUse("Use the explicit refresh, clear, enable, or disable subcommand when local usage state should change."), withExamples("kit usage --since 30d --json"), withCaveats("Usage commands do not record themselves; no arguments, values, paths, output, prompts, environment, URLs, or secrets are collected.")),
+		capability("usage report", "Inspect & Repair", "Aggregate bounded local command usage.", mutationNone, withFlags(flag("--since", "report window such as 90d"), flag("--command", "command filter"), flag("--project", "anonymized project filter"), flag("--input", "aggregate one or more kit usage export files instead of local storage"), flag("--json", "emit JSON")), withCaveats("--input reads exports offline and never writes them into the local store; it cannot be combined with --project.")),
+		capability("usage export", "Inspect & Repair", "Write an anonymized, schema-versioned usage export.", mutationWritesFiles, withNetwork("none"), withFileWrites("writes only the --out file, outside the local usage store"), withGitMutation("none"), withFlags(flag("--out", "export file path"), flag("--since", "limit exported history"), flag("--coarsen", "truncate timestamps to none, hour, or day"), flag("--json", "emit JSON")), withRelated(related("usage report", "aggregates exports with --input")), withWhenToUse("Use to pool usage evidence across machines without sharing local project identity."), withExamples("kit usage export --out usage.jsonl --coarsen day"), withCaveats("Project IDs are re-hashed with a fresh salt per export, so exports cannot be joined by project.")),
+		capability("usage advise", "Inspect & Repair", "Rank unused or mostly failing commands and flags against the capabilities catalog.", mutationNone, withNetwork("none"), withFileWrites("none"), withGitMutation("none"), withFlags(flag("--since", "advice window such as 90d"), flag("--min-calls", "calls needed before failure or flag advice"), flag("--failure-rate", "failure rate that counts as mostly failing"), flag("--json", "emit JSON")), withRelated(related("usage report", "shows the aggregate usage behind the advice"), related("capabilities", "documents deprecation state and notes")), withWhenToUse("Use to decide which commands or flags to deprecate, remove, or investigate from local evidence."), withExamples("kit usage advise --since 180d --json"), withCaveats("Flag advice uses only calls recorded with flag names; flag values are never recorded.")),
 		capability("usage status", "Inspect & Repair", "Show effective collection settings, coverage, bounds, and diagnostics.", mutationNone, withFlags(flag("--json", "emit JSON"))),
 		capability("usage refresh", "Inspect & Repair", "Validate, rotate, and prune bounded usage storage.", mutationWritesFiles, withNetwork("none"), withFileWrites("may rotate usage shards and prune events beyond the bounded retention limits", "--dry-run validates and reports without writing"), withFlags(flag("--dry-run", "preview maintenance", "read-only"), flag("--json", "emit JSON")), withWhenToUse("Use to validate local usage storage and apply its size and retention bounds."), withWhenNotToUse("Use `kit usage status` for a read-only status view without maintenance."), withExamples("kit usage refresh --dry-run --json"), withCaveats("Usage maintenance is local-only and does not transmit telemetry.")),
 		capability("usage clear", "Inspect & Repair", "Clear selected local usage events.", mutationDestructive, withNetwork("none"), withFileWrites("rewrites local usage shards to remove matching events; --all removes all recorded usage history"), withFlags(flag("--all", "clear all history"), flag("--command", "clear one normalized command"), flag("--json", "emit JSON"), flag("--project", "clear one anonymized project or the current project"), flag("--yes", "confirm non-interactively", "destructive")), withWhenToUse("Use to remove selected private local usage history."), withWhenNotToUse("Do not use for retention maintenance; use `kit usage refresh` instead."), withExamples("kit usage clear --project current", "kit usage clear --all --yes --json"), withCaveats("Without --all, --command, or --project, the command targets the current project's anonymized usage identity and asks for confirmation unless --yes is set.")),
@@ -18,7 +20,12 @@ func inspectionCapabilityRecords() []capabilityRecord {
 		capability("config check", "Inspect & Repair", "Validate .kit.yaml and offer safe bounded repairs.", mutationWritesFiles, withNetwork("none on a complete fast path", "interactive AWS remediation may list profiles, verify STS identity, and discover enabled Regions"), withFileWrites("interactive repairs may update schema, AWS profile, account, and Region fields", "--json is read-only"), withFlags(flag("--json", "validate without prompts or writes", "read-only"))),
 		capability("aws", "Inspect & Repair", "Inspect project-bound AWS verification commands.", mutationNone, withNetwork("none"), withFileWrites("none"), withGitMutation("none"), withRelated(related("aws verify", "checks exact identity")), withWhenToUse("Use this group to discover AWS context verification commands."), withWhenNotToUse("Invoke `kit aws verify` for the STS identity check; the group itself only shows command help.")),
 		capability("aws verify", "Inspect & Repair", "Verify configured AWS profile, account, and Region through STS.", mutationNetwork, withNetwork("calls aws sts get-caller-identity using the configured profile and Region"), withFlags(flag("--json", "emit verified identity"))),
-		capability("check", "Inspect & Repair", "Validate feature or whole-project Kit contracts.", mutationNone, withFlags(flag("--all", "check all features"), flag("--project", "check the project contract"))),
+		capability("check", "Inspect & Repair", "Validate feature or whole-project Kit contracts, optionally executing a feature's declared validation.", mutationWritesFiles,
+			withNetwork("none by Kit; --run-validation commands run as declared"),
+			withFileWrites("none by default", "--run-validation writes .kit/verify/<feature>/<run-id>/run.json, redacted command logs, and latest.json", "--junit and --sarif write the requested report path"),
+			withGitMutation("none"),
+			withFlags(flag("--all", "check all features"), flag("--project", "check the project contract"), flag("--run-validation", "execute the SPEC.md kit-validation block and persist the verify run"), flag("--allow-shell", "allow shell syntax in validation commands"), flag("--workers", "run independent validation tasks concurrently"), flag("--fail-fast", "stop validation at the first failure"), flag("--junit", "write the validation run as JUnit XML"), flag("--sarif", "write project contract findings as SARIF 2.1.0")),
+			withCaveats("A failed validation command returns a nonzero status; the run is persisted either way.")),
 		capability("pr", "Inspect & Repair", "Discover pull-request repair and release-orchestration prompts.", mutationNone, withNetwork("none"), withFileWrites("none"), withGitMutation("none"), withRelated(related("pr fix", "collects active feedback and prepares the repair lane"), related("pr orchestrate", "renders a dependency-aware release prompt")), withWhenToUse("Use this group to choose between PR feedback repair and release orchestration."), withWhenNotToUse("Invoke a concrete PR subcommand to read GitHub or prepare a worktree; the group itself only shows command help.")),
 		capability("pr fix", "Inspect & Repair", "Collect active PR feedback and produce a coding-agent repair prompt.", mutationGit, withNetwork("lists/fetches GitHub PRs and paginated active review threads"), withFileWrites("prompt-only by default", "may prepare the exact writable same-repository PR-head worktree"), withGitMutation("may fetch and create/attach the exact PR-head worktree; never edits source, stages, commits, pushes, comments, resolves, or merges"), withFlags(flag("--pr", "target URL, Markdown link, owner/repo#number, or current-repo number"), flag("--coderabbit", "filter to CodeRabbit"), flag("--copy", "copy the prompt even with --output-only"), flag("--edit", "edit collected tasks"), flag("--editor", "edit collected tasks with a specific editor command"), flag("--output-only", "print prompt"), flag("--vim", "edit collected tasks with a vim-compatible editor")), withRelated(related("context resolve", "loads pr-feedback-repair evidence"), related("dispatch", "provides explicit thread resolution"))),
 		capability("pr orchestrate", "Inspect & Repair", "Resolve bounded repository scope into a release-orchestration prompt.", mutationNetwork,
@@ -31,8 +38,14 @@ func inspectionCapabilityRecords() []capabilityRecord {
 			withWhenNotToUse("Do not use it as a release executor; Kit generates instructions but does not enumerate PRs, merge, deploy, mutate infrastructure, or launch an agent."),
 			withExamples("kit pr orchestrate --repos ./service-a --repos ./service-b --verify auto --dry-run"),
 			withCaveats("Only filename-level clues and sanitized repository metadata are discovered; arguments, paths, and prompt contents are excluded from usage telemetry.")),
-		capability("improve", "Inspect & Repair", "Discover Kit's deterministic benchmark harness workflows.", mutationNone, withNetwork("none"), withFileWrites("none"), withGitMutation("none"), withFlags(flag("--json", "emit machine-readable output from the selected improve workflow")), withRelated(related("improve run", "runs a benchmark suite")), withWhenToUse("Use this group to discover benchmark-backed improvement workflows."), withWhenNotToUse("Invoke `kit improve run` to execute a benchmark suite; the group itself only shows command help.")),
-		capability("improve run", "Inspect & Repair", "Run a deterministic Kit benchmark suite in disposable fixtures.", mutationExecutesCommands, withFileWrites("writes .kit/improve run evidence", "--dry-run does not write"), withFlags(flag("--suite", "select suite"), flag("--kit-binary", "evaluate an exact binary"), flag("--dry-run", "plan without writes", "read-only"), flag("--json", "emit the run manifest"))),
+		capability("improve", "Inspect & Repair", "Discover Kit's deterministic benchmark harness workflows.", mutationNone, withNetwork("none"), withFileWrites("none"), withGitMutation("none"), withFlags(flag("--json", "emit machine-readable output from the selected improve workflow")), withRelated(related("improve run", "runs a benchmark suite"), related("improve mine", "clusters failed traces"), related("improve propose", "drafts candidates from clusters"), related("improve validate", "scores a candidate"), related("improve report", "summarizes a run"), related("improve pr-body", "renders PR evidence"), related("improve compare", "gates a candidate run against a baseline")), withWhenToUse("Use this group to discover benchmark-backed improvement workflows."), withWhenNotToUse("Invoke `kit improve run` to execute a benchmark suite; the group itself only shows command help.")),
+		capability("improve run", "Inspect & Repair", "Run a deterministic Kit benchmark suite in disposable fixtures.", mutationExecutesCommands, withFileWrites("writes .kit/improve run evidence", "--dry-run does not write run evidence", "--junit writes the requested report path"), withFlags(flag("--suite", "select suite"), flag("--kit-binary", "evaluate an exact binary"), flag("--dry-run", "plan without writes", "read-only"), flag("--json", "emit the run manifest"), flag("--junit", "write one JUnit test case per task repeat and assertion"), flag("--parallel", "run task repeats concurrently; trace order is unchanged"), flag("--split", "run held-in, held-out, or all suite tasks"))),
+		capability("improve mine", "Inspect & Repair", "Cluster failed benchmark traces into a weakness report.", mutationWritesFiles, withNetwork("none"), withFileWrites("writes weakness-report.json into the --from run directory"), withGitMutation("none"), withFlags(flag("--from", "run artifact directory; defaults to .kit/improve/latest"), flag("--json", "emit the weakness report")), withRelated(related("improve propose", "turns clusters into candidates"))),
+		capability("improve propose", "Inspect & Repair", "Generate candidate harness-change prompts from weakness clusters.", mutationWritesFiles, withNetwork("none"), withFileWrites("writes candidates/<id>/candidate.json and prompt.md into the --from run directory", "mines traces first when no weakness report exists"), withGitMutation("none"), withFlags(flag("--from", "run artifact directory"), flag("--max-candidates", "limit generated candidates"), flag("--json", "emit candidate metadata")), withRelated(related("improve validate", "scores a generated candidate"))),
+		capability("improve validate", "Inspect & Repair", "Validate candidate metadata and emit a scorecard.", mutationNone, withNetwork("none"), withFileWrites("none"), withGitMutation("none"), withFlags(flag("--from", "run artifact directory used to resolve candidate IDs"), flag("--candidate", "candidate ID or candidate.json path"), flag("--run", "score on a run of tasks the proposer did not see"), flag("--json", "emit the scorecard")), withCaveats("Without --run, scores candidate metadata only.", "Refuses to score on a run containing any task the candidate's proposer saw; run `kit improve run --split held-out` for a scoring run.")),
+		capability("improve report", "Inspect & Repair", "Summarize a benchmark run as Markdown.", mutationWritesFiles, withNetwork("none"), withFileWrites("writes report.md into the --from run directory"), withGitMutation("none"), withFlags(flag("--from", "run artifact directory"), flag("--json", "emit the Markdown with its source directory"))),
+		capability("improve pr-body", "Inspect & Repair", "Render a pull-request body carrying benchmark evidence.", mutationWritesFiles, withNetwork("none"), withFileWrites("writes report.md into the --from run directory"), withGitMutation("none; prints the body without creating a pull request"), withFlags(flag("--from", "run artifact directory"), flag("--issue", "ticket reference"), flag("--json", "emit the Markdown with its source directory"))),
+		capability("improve compare", "Inspect & Repair", "Compare a candidate benchmark run against a baseline and gate regressions.", mutationNone, withNetwork("none"), withFileWrites("none"), withGitMutation("none"), withFlags(flag("--baseline", "baseline run ID, directory, or latest"), flag("--candidate", "candidate run ID, directory, or latest"), flag("--max-token-growth", "allowed per-task stdout token growth fraction"), flag("--max-success-drop", "allowed task success rate drop"), flag("--max-determinism-drop", "allowed determinism rate drop"), flag("--allow-new-failures", "report task and assertion regressions without failing"), flag("--json", "emit the comparison")), withExamples("kit improve compare --baseline <run-id> --candidate latest --json"), withCaveats("Any threshold breach exits with status 1 after the comparison is printed.")),
 		capability("rules", "Inspect & Repair", "Discover durable repository-local ruleset commands.", mutationNone, withNetwork("none"), withFileWrites("none"), withGitMutation("none"), withRelated(related("rules list", "lists local rulesets"), related("rules add", "imports or creates a ruleset"), related("rules link", "links a ruleset to a feature")), withWhenToUse("Use this group to choose a ruleset inspection or mutation command."), withWhenNotToUse("Invoke a concrete rules subcommand to inspect or change project state; the group itself only shows command help.")),
 		rulesAddCapabilityRecord(),
 		capability("rules list", "Inspect & Repair", "List durable repository-local rulesets and their tracked registry state.", mutationNone, withNetwork("none"), withFileWrites("none"), withGitMutation("none"), withWhenToUse("Use to inspect rulesets already materialized in the current project."), withWhenNotToUse("Use `kit rules add` to browse or import the remote Kit rules registry."), withExamples("kit rules list")),
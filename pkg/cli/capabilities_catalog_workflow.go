@@ -10,17 +10,48 @@ func workflowCapabilityRecords() []capabilityRecord {
 			withExamples("kit spec invitation-flow")),
 		capability("context", "Agent Workflow", "Resolve repository-local coding-agent context.", mutationNone,
 			withNetwork("none"), withFileWrites("none"), withGitMutation("none"),
-			withRelated(related("context resolve", "returns the deterministic evidence contract")),
+			withRelated(related("context resolve", "returns the deterministic evidence contract"), related("context verify", "reports evidence drift since a saved contract"), related("context bundle", "exports resolved evidence for offline agents"), related("context lint", "validates every workflow manifest")),
 			withWhenToUse("Use this group to discover deterministic repository-local context commands."),
 			withWhenNotToUse("Invoke `kit context resolve` to produce a context contract; the group itself only shows command help."),
 			withExamples("kit context resolve --json")),
 		capability("context resolve", "Agent Workflow", "Resolve ordered workflows, rules, specs, strategies, and implementation evidence.", mutationNone,
 			withNetwork("none"), withFileWrites("none"), withGitMutation("none"),
-			withFlags(flag("--workflow", "select a local workflow"), flag("--feature", "include feature and related historical specs"), flag("--path", "add a required repository-confined path hint"), flag("--json", "emit kit.context/v1 JSON", "read-only")),
+			withFlags(flag("--workflow", "select a local workflow; repeat to compose several"), flag("--feature", "include feature and related historical specs"), flag("--path", "add a required repository-confined path hint"), flag("--budget", "drop optional evidence to fit an estimated token budget"), flag("--explain", "include the resolution trace: visit order, manifest lines, promotions, and skips"), flag("--json", "emit kit.context/v1 JSON", "read-only")),
 			withWhenToUse("Run before coding-agent work and rerun after material scope changes."),
 			withWhenNotToUse("Do not use it for network access, model inference, agent launch, Git mutation, or writes."),
-			withExamples("kit context resolve --workflow implementation-delivery --feature invitation-flow --json"),
+			withExamples("kit context resolve --workflow implementation-delivery --feature invitation-flow --json", "kit context resolve --workflow pr-feedback-repair --workflow release-orchestration --json"),
 			withCaveats("Missing or invalid required local evidence returns blocked JSON and exit status 2.")),
+		capability("context verify", "Agent Workflow", "Re-read a saved context contract's evidence and report added, removed, or changed items.", mutationNone,
+			withNetwork("none"), withFileWrites("none"), withGitMutation("none"),
+			withFlags(flag("--contract", "saved kit.context/v1 JSON contract"), flag("--json", "emit kit.context.drift/v1 JSON", "read-only")),
+			withRelated(related("context resolve", "produces the contract and reloads drifted evidence")),
+			withWhenToUse("Run during long agent sessions to detect rules, specs, or references that changed after loading."),
+			withWhenNotToUse("Do not use it as a substitute for resolving a new contract after a material scope change."),
+			withExamples("kit context resolve --json > contract.json", "kit context verify --contract contract.json --json"),
+			withCaveats("Drift touching required evidence returns exit status 2; optional-only drift is reported with exit status 0.")),
+		capability("context bundle", "Agent Workflow", "Export resolved evidence contents, the contract, and a manifest as one reproducible tar or Markdown file.", mutationWritesFiles,
+			withNetwork("none"), withFileWrites("writes only the --out file; --out - writes to stdout"), withGitMutation("none"),
+			withFlags(flag("--workflow", "select a local workflow; repeat to compose several"), flag("--feature", "include feature and related historical specs"), flag("--path", "add a required repository-confined path hint"), flag("--budget", "drop optional evidence to fit an estimated token budget"), flag("--out", "bundle file path or - for stdout"), flag("--format", "tar or markdown")),
+			withRelated(related("context resolve", "selects the bundled evidence")),
+			withWhenToUse("Use to hand exactly the resolved evidence to an agent or reviewer without repository file access."),
+			withWhenNotToUse("Do not use it as canonical repository memory; bundles are derived snapshots."),
+			withExamples("kit context bundle --workflow implementation-delivery --feature invitation-flow --out bundle.tar", "kit context bundle --format markdown --out -"),
+			withCaveats("Identical inputs produce byte-identical bundles.", "Blocked contracts write no bundle and return exit status 2.")),
+		capability("context graph", "Agent Workflow", "Render every workflow manifest, dependency, and rule reference as a DOT, Mermaid, or JSON graph.", mutationNone,
+			withNetwork("none"), withFileWrites("none"), withGitMutation("none"),
+			withFlags(flag("--format", "dot, mermaid, or kit.context.graph/v1 json", "read-only")),
+			withRelated(related("context lint", "fails on the same graph's errors")),
+			withWhenToUse("Use to review how workflows compose and which rules they load before editing manifests."),
+			withWhenNotToUse("Do not use it to select evidence for one task; use `kit context resolve`."),
+			withExamples("kit context graph --format dot | dot -Tsvg > workflows.svg", "kit context graph --format mermaid")),
+		capability("context lint", "Agent Workflow", "Validate all workflow manifests together and report every cycle, missing rule, and unreachable rule.", mutationNone,
+			withNetwork("none"), withFileWrites("none"), withGitMutation("none"),
+			withFlags(flag("--json", "emit kit.context.graph/v1 JSON", "read-only")),
+			withRelated(related("context graph", "renders the linted graph"), related("check", "--project validates installed ruleset documents")),
+			withWhenToUse("Run in CI or after editing workflow manifests to catch breakage that a single resolution path would not reach."),
+			withWhenNotToUse("Do not use it to check feature specs or evidence files."),
+			withExamples("kit context lint", "kit context lint --json"),
+			withCaveats("Errors return exit status 2; unreachable rules are warnings.")),
 		capability("dispatch", "Agent Workflow", "Produce an accountable Agent Team Plan prompt or PR-feedback repair prompt.", mutationGit,
 			withNetwork("none for file/stdin input", "--pr reads GitHub review data; --loop --watch performs bounded status polling"),
 			withFileWrites("generic prompt generation writes no project files", "PR mode may prepare the exact writable same-repository PR-head worktree"),
@@ -253,3 +253,16 @@ func isLeafCapability(command string, records []capabilityRecord) bool {
 	}
 	return true
 }
+
+func TestCapabilityRelatedCommandsResolveToRegisteredCommands(t *testing.T) {
+	rootCmd.InitDefaultHelpCmd()
+	rootCmd.InitDefaultCompletionCmd()
+	for _, record := range capabilityCatalog() {
+		for _, target := range record.RelatedCommands {
+			command, _, err := rootCmd.Find(strings.Fields(target.Command))
+			if err != nil || command == nil || command.CommandPath() != "kit "+target.Command {
+				t.Errorf("%s relates to unregistered command %q", record.Command, target.Command)
+			}
+		}
+	}
+}
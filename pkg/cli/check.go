@@ -10,10 +10,17 @@ import (
 	"github.com/jamesonstone/kit/v3/internal/config"
 	"github.com/jamesonstone/kit/v3/internal/document"
 	"github.com/jamesonstone/kit/v3/internal/feature"
+	"github.com/jamesonstone/kit/v3/internal/usage"
 )
 
 var checkAll bool
 var checkProject bool
+var checkRunValidation bool
+var checkAllowShell bool
+var checkWorkers int
+var checkFailFast bool
+var checkJUnit string
+var checkSARIF string
 
 var checkCmd = &cobra.Command{
 	Use:   "check [feature]",
@@ -28,7 +35,11 @@ Validates:
   - No unresolved placeholders
 
 Use --all to validate all features in the project.
-Use --project to validate the repo-level document contract.`,
+Use --project to validate the repo-level document contract.
+Use --run-validation to execute the feature's V3 SPEC.md kit-validation block
+and persist the run under .kit/verify/.
+Use --junit with --run-validation to also write the run as JUnit XML, and
+--sarif with --project to write contract findings as SARIF 2.1.0.`,
 	Args: cobra.MaximumNArgs(1),
 	RunE: runCheck,
 }
@@ -36,29 +47,47 @@ Use --project to validate the repo-level document contract.`,
 func init() {
 	checkCmd.Flags().BoolVar(&checkAll, "all", false, "validate all features in docs/specs/")
 	checkCmd.Flags().BoolVar(&checkProject, "project", false, "validate the repo-level document and instruction contract")
+	checkCmd.Flags().BoolVar(&checkRunValidation, "run-validation", false, "execute the SPEC.md kit-validation block and persist the run")
+	checkCmd.Flags().BoolVar(&checkAllowShell, "allow-shell", false, "allow shell syntax in --run-validation commands")
+	checkCmd.Flags().IntVar(&checkWorkers, "workers", 1, "maximum validation tasks to run concurrently")
+	checkCmd.Flags().BoolVar(&checkFailFast, "fail-fast", false, "stop validation at the first failed command")
+	checkCmd.Flags().StringVar(&checkJUnit, "junit", "", "write the --run-validation run as JUnit XML to this path")
+	checkCmd.Flags().StringVar(&checkSARIF, "sarif", "", "write --project findings as SARIF 2.1.0 to this path")
 	rootCmd.AddCommand(checkCmd)
 }
 
 func runCheck(cmd *cobra.Command, args []string) error {
 	if checkProject && len(args) > 0 {
-		return fmt.Errorf("--project cannot be used with a feature argument")
+		return withErrorClass(usage.ErrorClassInvalidInput, fmt.Errorf("--project cannot be used with a feature argument"))
+	}
+	if checkRunValidation && (checkProject || checkAll || len(args) == 0) {
+		return withErrorClass(usage.ErrorClassInvalidInput, fmt.Errorf("--run-validation requires a single feature argument"))
+	}
+	if checkJUnit != "" && !checkRunValidation {
+		return withErrorClass(usage.ErrorClassInvalidInput, fmt.Errorf("--junit requires --run-validation"))
+	}
+	if checkSARIF != "" && !checkProject {
+		return withErrorClass(usage.ErrorClassInvalidInput, fmt.Errorf("--sarif requires --project"))
+	}
+	if checkWorkers < 1 {
+		return withErrorClass(usage.ErrorClassInvalidInput, fmt.Errorf("--workers must be at least 1"))
 	}
 
 	// find project root
 	projectRoot, err := config.FindProjectRoot()
 	if err != nil {
-		return err
+		return withErrorClass(usage.ErrorClassNoProject, err)
 	}
 
 	cfg, err := config.Load(projectRoot)
 	if err != nil {
-		return err
+		return withErrorClass(usage.ErrorClassConfigInvalid, err)
 	}
 
 	specsDir := cfg.SpecsPath(projectRoot)
 
 	if checkProject {
-		return checkProjectContract(projectRoot, cfg)
+		return checkProjectContract(projectRoot, cfg, checkSARIF)
 	}
 
 	if checkAll {
@@ -66,16 +95,27 @@ func runCheck(cmd *cobra.Command, args []string) error {
 	}
 
 	if len(args) == 0 {
-		return fmt.Errorf("feature name required. Use --all to check all features")
+		return withErrorClass(usage.ErrorClassInvalidInput, fmt.Errorf("feature name required. Use --all to check all features"))
 	}
 
-	return checkFeature(projectRoot, specsDir, args[0])
+	if err := checkFeature(projectRoot, specsDir, args[0]); err != nil {
+		return err
+	}
+	if checkRunValidation {
+		return runFeatureValidation(cmd, projectRoot, specsDir, args[0], validationOptions{
+			allowShell: checkAllowShell,
+			workers:    checkWorkers,
+			failFast:   checkFailFast,
+			junitPath:  checkJUnit,
+		})
+	}
+	return nil
 }
 
 func checkFeature(projectRoot string, specsDir string, featureRef string) error {
 	feat, err := feature.Resolve(specsDir, featureRef)
 	if err != nil {
-		return fmt.Errorf("feature '%s' not found. Run 'kit spec %s' first to create it", featureRef, featureRef)
+		return withErrorClass(usage.ErrorClassNotFound, fmt.Errorf("feature '%s' not found. Run 'kit spec %s' first to create it", featureRef, featureRef))
 	}
 
 	fmt.Printf("🔎 Checking feature: %s\n", feat.DirName)
@@ -192,7 +232,7 @@ func checkFeature(projectRoot string, specsDir string, featureRef string) error
 		for _, e := range errors {
 			fmt.Printf("  - %s\n", e)
 		}
-		return fmt.Errorf("validation failed with %d error(s)", len(errors))
+		return withErrorClass(usage.ErrorClassCheckFailed, fmt.Errorf("validation failed with %d error(s)", len(errors)))
 	}
 
 	return nil
@@ -251,6 +291,6 @@ func metadataDiagnosticWarnings(doc *document.Document) []string {
 	return warnings
 }
 
-func checkProjectContract(projectRoot string, cfg *config.Config) error {
-	return checkProjectContractTo(os.Stdout, projectRoot, cfg)
+func checkProjectContract(projectRoot string, cfg *config.Config, sarifPath string) error {
+	return checkProjectContractReport(os.Stdout, projectRoot, cfg, sarifPath)
 }
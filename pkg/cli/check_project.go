@@ -7,9 +7,16 @@ import (
 
 	"github.com/jamesonstone/kit/v3/internal/config"
 	"github.com/jamesonstone/kit/v3/internal/feature"
+	"github.com/jamesonstone/kit/v3/internal/usage"
 )
 
 func checkProjectContractTo(out io.Writer, projectRoot string, cfg *config.Config) error {
+	return checkProjectContractReport(out, projectRoot, cfg, "")
+}
+
+// checkProjectContractReport validates the project contract and, when
+// sarifPath is set, also writes every finding there before reporting.
+func checkProjectContractReport(out io.Writer, projectRoot string, cfg *config.Config, sarifPath string) error {
 	if _, err := fmt.Fprintln(out, "🔎 Checking project contract..."); err != nil {
 		return err
 	}
@@ -18,6 +25,14 @@ func checkProjectContractTo(out io.Writer, projectRoot string, cfg *config.Confi
 	if err != nil {
 		return err
 	}
+	if sarifPath != "" {
+		if err := writeReconcileSARIF(sarifPath, projectRoot, report.Findings); err != nil {
+			return fmt.Errorf("write SARIF report: %w", err)
+		}
+		if _, err := fmt.Fprintf(out, "  sarif: %s\n", sarifPath); err != nil {
+			return err
+		}
+	}
 	if len(report.Findings) == 0 {
 		if _, err := fmt.Fprintln(out, "  ✅ Project contract is coherent!"); err != nil {
 			return err
@@ -67,7 +82,7 @@ func checkProjectContractTo(out io.Writer, projectRoot string, cfg *config.Confi
 		return nil
 	}
 
-	return fmt.Errorf("project validation failed with %d blocking finding(s)", len(errors)+len(blockingWarnings))
+	return withErrorClass(usage.ErrorClassCheckFailed, fmt.Errorf("project validation failed with %d blocking finding(s)", len(errors)+len(blockingWarnings)))
 }
 
 func relativeCheckPath(projectRoot, path string) string {
@@ -102,7 +117,7 @@ func checkAllFeatures(projectRoot string, specsDir string) error {
 	}
 
 	if totalErrors > 0 {
-		return fmt.Errorf("%d feature(s) have validation errors", totalErrors)
+		return withErrorClass(usage.ErrorClassCheckFailed, fmt.Errorf("%d feature(s) have validation errors", totalErrors))
 	}
 
 	fmt.Printf("✅ All %d feature(s) passed validation!\n", len(features))
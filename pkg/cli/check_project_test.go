@@ -1,6 +1,7 @@
 package cli
 
 import (
+	"os"
 	"path/filepath"
 	"strings"
 	"testing"
@@ -139,6 +140,22 @@ func TestRunCheckProjectFailsOnDuplicateFeatureNumbers(t *testing.T) {
 	if err == nil || !strings.Contains(err.Error(), "project validation failed") {
 		t.Fatalf("expected duplicate-number project validation failure, got %v", err)
 	}
+
+	sarifPath := filepath.Join(projectRoot, "reports", "kit.sarif")
+	checkSARIF = sarifPath
+	t.Cleanup(func() { checkSARIF = "" })
+	if err := runCheck(cmd, nil); err == nil {
+		t.Fatal("expected project validation failure with --sarif")
+	}
+	data, err := os.ReadFile(sarifPath)
+	if err != nil {
+		t.Fatalf("SARIF report was not written: %v", err)
+	}
+	for _, want := range []string{`"version": "2.1.0"`, `"level": "error"`, `"uri": "docs/specs/0012-`} {
+		if !strings.Contains(string(data), want) {
+			t.Fatalf("SARIF report missing %q:\n%s", want, data)
+		}
+	}
 }
 
 func setupCoherentProjectForCheck(t *testing.T) string {
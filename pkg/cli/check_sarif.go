@@ -0,0 +1,54 @@
+package cli
+
+import (
+	"io"
+	"path/filepath"
+	"strings"
+
+	"github.com/jamesonstone/kit/v3/internal/export"
+)
+
+// writeReconcileSARIF exports reconcile audit findings for code-scanning
+// UIs. Rules are keyed by the contract source that defines the expectation.
+func writeReconcileSARIF(path, projectRoot string, findings []reconcileFinding) error {
+	converted := make([]export.Finding, 0, len(findings))
+	for _, finding := range findings {
+		converted = append(converted, reconcileSARIFFinding(projectRoot, finding))
+	}
+	return export.WriteFile(path, func(w io.Writer) error {
+		return export.WriteSARIF(w, Version, converted)
+	})
+}
+
+func reconcileSARIFFinding(projectRoot string, finding reconcileFinding) export.Finding {
+	level := "warning"
+	switch {
+	case finding.Severity == reconcileSeverityError:
+		level = "error"
+	case finding.NonBlocking:
+		level = "note"
+	}
+	ruleID := "kit.contract"
+	description := "Kit project contract"
+	if finding.ContractSource != "" {
+		source := filepath.Base(finding.ContractSource)
+		ruleID += "." + strings.ToLower(strings.TrimSuffix(source, filepath.Ext(source)))
+		description = "Kit contract defined by " + filepath.ToSlash(relativeCheckPath(projectRoot, finding.ContractSource))
+	}
+	properties := map[string]any{"non_blocking": finding.NonBlocking}
+	if finding.UpdateInstruction != "" {
+		properties["update_instruction"] = finding.UpdateInstruction
+	}
+	path := ""
+	if finding.FilePath != "" {
+		path = relativeCheckPath(projectRoot, finding.FilePath)
+	}
+	return export.Finding{
+		RuleID:      ruleID,
+		Description: description,
+		Level:       level,
+		Message:     finding.Issue,
+		Path:        path,
+		Properties:  properties,
+	}
+}
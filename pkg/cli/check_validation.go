@@ -0,0 +1,135 @@
+package cli
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+
+	"github.com/spf13/cobra"
+
+	"github.com/jamesonstone/kit/v3/internal/export"
+	"github.com/jamesonstone/kit/v3/internal/feature"
+	"github.com/jamesonstone/kit/v3/internal/usage"
+	"github.com/jamesonstone/kit/v3/internal/verify"
+)
+
+type validationOptions struct {
+	allowShell bool
+	workers    int
+	failFast   bool
+	junitPath  string
+}
+
+// runFeatureValidation executes the kit-validation block of a feature's V3
+// SPEC.md and persists the verify.Run so the outcome is a reproducible
+// artifact instead of a claim.
+func runFeatureValidation(cmd *cobra.Command, projectRoot, specsDir, featureRef string, opts validationOptions) error {
+	feat, err := feature.Resolve(specsDir, featureRef)
+	if err != nil {
+		return withErrorClass(usage.ErrorClassNotFound, fmt.Errorf("feature '%s' not found. Run 'kit spec %s' first to create it", featureRef, featureRef))
+	}
+	specPath := filepath.Join(feat.Path, "SPEC.md")
+	commands, needs, err := verify.LoadSpecValidation(specPath, opts.allowShell)
+	if err != nil {
+		return err
+	}
+	dependencies, err := validationDependencies(feat.Path, needs)
+	if err != nil {
+		return err
+	}
+	ctx := cmd.Context()
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	out := cmd.OutOrStdout()
+	var tee io.Writer
+	if terminalWriterCheck(out) {
+		tee = out
+	}
+	run := verify.ExecuteRun(ctx, verify.RunOptions{
+		ProjectRoot:  projectRoot,
+		Feature:      verify.FeatureRefFromDir(feat.Path),
+		TaskIDs:      validationTaskIDs(commands),
+		Commands:     commands,
+		Dependencies: dependencies,
+		Workers:      opts.workers,
+		FailFast:     opts.failFast,
+		ArtifactRoot: verify.FeatureArtifactRoot(feat.DirName),
+		Tee:          tee,
+	})
+	runPath, err := verify.WriteRun(projectRoot, &run)
+	if err != nil {
+		return fmt.Errorf("persist validation run: %w", err)
+	}
+
+	fmt.Fprintf(out, "\n🧪 Validation %s\n", verify.RunSummary(run))
+	for _, result := range run.Results {
+		marker := "✅"
+		switch result.Status {
+		case "pass":
+		case "skipped":
+			marker = "⏭️"
+		default:
+			marker = "❌"
+		}
+		attempts := ""
+		if result.Attempts > 1 {
+			attempts = fmt.Sprintf(" attempts=%d", result.Attempts)
+		}
+		fmt.Fprintf(out, "  %s %s [%s] exit=%d%s %dms %s\n", marker, result.CommandID, result.TaskID, result.ExitCode, attempts, result.DurationMS, result.Raw)
+		if result.Status != "pass" && result.Error != "" {
+			fmt.Fprintf(out, "     %s\n", verify.Redact(result.Error))
+		}
+		if result.Status == "fail" && result.StderrPath != "" {
+			fmt.Fprintf(out, "     logs: %s, %s\n", result.StdoutPath, result.StderrPath)
+		}
+	}
+	if run.Status == verify.RunStatusNoDeclaredChecks {
+		fmt.Fprintf(out, "  ⚠️ SPEC.md VALIDATION declares no %s block\n", verify.ValidationFenceInfo)
+	}
+	if relative, relErr := filepath.Rel(projectRoot, runPath); relErr == nil {
+		runPath = relative
+	}
+	fmt.Fprintf(out, "  run: %s\n", filepath.ToSlash(runPath))
+	if opts.junitPath != "" {
+		report := export.VerifyRunJUnit(run)
+		if err := export.WriteFile(opts.junitPath, func(w io.Writer) error { return export.WriteJUnit(w, report) }); err != nil {
+			return fmt.Errorf("write JUnit report: %w", err)
+		}
+		fmt.Fprintf(out, "  junit: %s\n", opts.junitPath)
+	}
+	if run.Status == verify.RunStatusFail {
+		return withErrorClass(usage.ErrorClassCheckFailed, fmt.Errorf("validation run failed"))
+	}
+	return nil
+}
+
+// validationDependencies merges the TASKS.md progress-table dependencies of
+// the feature, when it has a TASKS.md, with the kit-validation needs.
+func validationDependencies(featurePath string, needs map[string][]string) (map[string][]string, error) {
+	// Shell VERIFY commands are parsed here only to read dependencies; they
+	// are never run by validation.
+	bundles, err := verify.LoadTaskBundles(filepath.Join(featurePath, "TASKS.md"), verify.FeatureRefFromDir(featurePath), true)
+	if errors.Is(err, os.ErrNotExist) {
+		return needs, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("read TASKS.md dependencies: %w", err)
+	}
+	return verify.MergeDependencies(verify.TaskDependencies(bundles), needs), nil
+}
+
+func validationTaskIDs(commands []verify.Command) []string {
+	var ids []string
+	seen := map[string]bool{}
+	for _, command := range commands {
+		if !seen[command.TaskID] {
+			seen[command.TaskID] = true
+			ids = append(ids, command.TaskID)
+		}
+	}
+	return ids
+}
@@ -0,0 +1,108 @@
+package cli
+
+import (
+	"bytes"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/spf13/cobra"
+
+	"github.com/jamesonstone/kit/v3/internal/config"
+	"github.com/jamesonstone/kit/v3/internal/verify"
+)
+
+func TestRunFeatureValidationPersistsRunAndFailsOnFailedCommand(t *testing.T) {
+	projectRoot := t.TempDir()
+	cfg := config.Default()
+	specsDir := cfg.SpecsPath(projectRoot)
+	specPath := filepath.Join(specsDir, "0001-alpha", "SPEC.md")
+	writeFile(t, specPath, "# SPEC\n\n## VALIDATION\n\n```kit-validation\n- true\n```\n")
+
+	var output bytes.Buffer
+	cmd := &cobra.Command{}
+	cmd.SetOut(&output)
+	if err := runFeatureValidation(cmd, projectRoot, specsDir, "alpha", validationOptions{workers: 1}); err != nil {
+		t.Fatalf("runFeatureValidation() error = %v\n%s", err, output.String())
+	}
+	data, err := os.ReadFile(filepath.Join(projectRoot, verify.LatestRunPath("0001-alpha")))
+	if err != nil {
+		t.Fatalf("latest run was not persisted: %v", err)
+	}
+	var run verify.Run
+	if err := json.Unmarshal(data, &run); err != nil {
+		t.Fatal(err)
+	}
+	if run.Status != verify.RunStatusPass || len(run.Results) != 1 || run.Results[0].CommandID != "VALIDATION-001" {
+		t.Fatalf("persisted run = %#v", run)
+	}
+	if run.Results[0].StdoutPath != ".kit/verify/0001-alpha/"+run.RunID+"/logs/VALIDATION-001.stdout.log" {
+		t.Fatalf("stdout log path = %q", run.Results[0].StdoutPath)
+	}
+	if !strings.Contains(output.String(), "run: .kit/verify/0001-alpha/"+run.RunID+"/run.json") {
+		t.Fatalf("output does not name the run artifact:\n%s", output.String())
+	}
+
+	writeFile(t, specPath, "# SPEC\n\n## VALIDATION\n\n```kit-validation\n- false\n```\n")
+	if err := runFeatureValidation(cmd, projectRoot, specsDir, "alpha", validationOptions{workers: 1}); err == nil || !strings.Contains(err.Error(), "validation run failed") {
+		t.Fatalf("failed validation error = %v", err)
+	}
+}
+
+func TestRunFeatureValidationWritesJUnitReport(t *testing.T) {
+	projectRoot := t.TempDir()
+	cfg := config.Default()
+	specsDir := cfg.SpecsPath(projectRoot)
+	writeFile(t, filepath.Join(specsDir, "0001-alpha", "SPEC.md"), "# SPEC\n\n## VALIDATION\n\n```kit-validation\n- true\n- false\n```\n")
+	junitPath := filepath.Join(projectRoot, "reports", "validation.xml")
+
+	var output bytes.Buffer
+	cmd := &cobra.Command{}
+	cmd.SetOut(&output)
+	err := runFeatureValidation(cmd, projectRoot, specsDir, "alpha", validationOptions{workers: 1, junitPath: junitPath})
+	if err == nil {
+		t.Fatalf("runFeatureValidation() should fail on the failing command\n%s", output.String())
+	}
+	data, readErr := os.ReadFile(junitPath)
+	if readErr != nil {
+		t.Fatalf("JUnit report was not written: %v", readErr)
+	}
+	for _, want := range []string{`tests="2"`, `failures="1"`, `name="VALIDATION-002"`, `<failure message="command exited 1"`} {
+		if !strings.Contains(string(data), want) {
+			t.Fatalf("JUnit report missing %q:\n%s", want, data)
+		}
+	}
+}
+
+func TestRunFeatureValidationSchedulesTaskDependencies(t *testing.T) {
+	projectRoot := t.TempDir()
+	cfg := config.Default()
+	specsDir := cfg.SpecsPath(projectRoot)
+	featureDir := filepath.Join(specsDir, "0001-alpha")
+	writeFile(t, filepath.Join(featureDir, "SPEC.md"), "# SPEC\n\n## VALIDATION\n\n```kit-validation\n- id: T001\n  run: \"false\"\n- id: T002\n  run: \"true\"\n- id: T003\n  run: \"true\"\n```\n")
+	writeFile(t, filepath.Join(featureDir, "TASKS.md"), "# TASKS\n\n## PROGRESS TABLE\n\n| ID | TASK | STATUS | OWNER | DEPENDENCIES |\n| -- | ---- | ------ | ----- | ------------ |\n| T001 | First | todo | agent | |\n| T002 | Second | todo | agent | T001 |\n| T003 | Third | todo | agent | |\n")
+
+	var output bytes.Buffer
+	cmd := &cobra.Command{}
+	cmd.SetOut(&output)
+	if err := runFeatureValidation(cmd, projectRoot, specsDir, "alpha", validationOptions{workers: 2}); err == nil {
+		t.Fatalf("runFeatureValidation() should fail on T001\n%s", output.String())
+	}
+	data, err := os.ReadFile(filepath.Join(projectRoot, verify.LatestRunPath("0001-alpha")))
+	if err != nil {
+		t.Fatal(err)
+	}
+	var run verify.Run
+	if err := json.Unmarshal(data, &run); err != nil {
+		t.Fatal(err)
+	}
+	statuses := map[string]string{}
+	for _, result := range run.Results {
+		statuses[result.TaskID] = result.Status
+	}
+	if statuses["T001"] != "fail" || statuses["T002"] != "skipped" || statuses["T003"] != "pass" {
+		t.Fatalf("task statuses = %v", statuses)
+	}
+}
@@ -10,6 +10,7 @@ import (
 	"golang.org/x/term"
 
 	"github.com/jamesonstone/kit/v3/internal/config"
+	"github.com/jamesonstone/kit/v3/internal/usage"
 )
 
 var configCheckJSON bool
@@ -66,7 +67,7 @@ func runConfigCheck(cmd *cobra.Command, args []string) error {
 	}
 	cfg, inspection, err := config.LoadWithInspection(projectRoot)
 	if err != nil {
-		return err
+		return withErrorClass(usage.ErrorClassConfigInvalid, err)
 	}
 
 	if !configCheckJSON && commandHasInteractiveTerminal(cmd) && inspection.SchemaState != config.SchemaStateNewer {
@@ -81,7 +82,7 @@ func runConfigCheck(cmd *cobra.Command, args []string) error {
 		if changed {
 			cfg, inspection, err = config.LoadWithInspection(projectRoot)
 			if err != nil {
-				return err
+				return withErrorClass(usage.ErrorClassConfigInvalid, err)
 			}
 		}
 	}
@@ -95,7 +96,7 @@ func runConfigCheck(cmd *cobra.Command, args []string) error {
 		printConfigCheckReport(cmd.OutOrStdout(), report)
 	}
 	if !report.Valid {
-		return newCLIExitError(errors.New("configuration validation failed"), 1, true)
+		return withErrorClass(usage.ErrorClassConfigInvalid, newCLIExitError(errors.New("configuration validation failed"), 1, true))
 	}
 	return nil
 }
@@ -9,6 +9,7 @@ import (
 	"golang.org/x/term"
 
 	"github.com/jamesonstone/kit/v3/internal/config"
+	"github.com/jamesonstone/kit/v3/internal/usage"
 )
 
 func runAutomaticConfigCheck(cmd *cobra.Command, args []string) error {
@@ -21,7 +22,7 @@ func runAutomaticConfigCheck(cmd *cobra.Command, args []string) error {
 	}
 	cfg, inspection, err := config.LoadWithInspection(projectRoot)
 	if err != nil {
-		return err
+		return withErrorClass(usage.ErrorClassConfigInvalid, err)
 	}
 	if inspection.SchemaState == config.SchemaStateNewer {
 		return fmt.Errorf("%s", inspection.Findings[0].Message)
@@ -9,12 +9,15 @@ import (
 
 	"github.com/jamesonstone/kit/v3/internal/config"
 	contextcontract "github.com/jamesonstone/kit/v3/internal/context"
+	"github.com/jamesonstone/kit/v3/internal/usage"
 )
 
 type contextResolveOptions struct {
-	workflow   string
+	workflows  []string
 	feature    string
 	paths      []string
+	budget     int
+	explain    bool
 	jsonOutput bool
 }
 
@@ -27,12 +30,12 @@ func newContextCommand() *cobra.Command {
 		Use:   "context",
 		Short: "Resolve deterministic repository-local coding-agent context",
 	}
-	cmd.AddCommand(newContextResolveCommand())
+	cmd.AddCommand(newContextResolveCommand(), newContextVerifyCommand(), newContextBundleCommand(), newContextGraphCommand(), newContextLintCommand())
 	return cmd
 }
 
 func newContextResolveCommand() *cobra.Command {
-	opts := &contextResolveOptions{workflow: "implementation-delivery"}
+	opts := &contextResolveOptions{workflows: []string{"implementation-delivery"}}
 	cmd := &cobra.Command{
 		Use:           "resolve",
 		Short:         "Resolve ordered local workflow, rule, specification, and reference evidence",
@@ -43,26 +46,45 @@ func newContextResolveCommand() *cobra.Command {
 			return runContextResolve(cmd, opts)
 		},
 	}
-	cmd.Flags().StringVar(&opts.workflow, "workflow", opts.workflow, "workflow slug to resolve")
-	cmd.Flags().StringVar(&opts.feature, "feature", "", "feature slug or directory to include")
-	cmd.Flags().StringArrayVar(&opts.paths, "path", nil, "required repository-relative path hint; repeatable")
+	addContextRequestFlags(cmd, opts)
+	cmd.Flags().BoolVar(&opts.explain, "explain", false, "include a step-by-step resolution trace")
 	cmd.Flags().BoolVar(&opts.jsonOutput, "json", false, "emit versioned machine-readable JSON")
 	return cmd
 }
 
-func runContextResolve(cmd *cobra.Command, opts *contextResolveOptions) error {
+func addContextRequestFlags(cmd *cobra.Command, opts *contextResolveOptions) {
+	cmd.Flags().StringArrayVar(&opts.workflows, "workflow", opts.workflows, "workflow slug to resolve; repeatable to compose workflows")
+	cmd.Flags().StringVar(&opts.feature, "feature", "", "feature slug or directory to include")
+	cmd.Flags().StringArrayVar(&opts.paths, "path", nil, "required repository-relative path hint; repeatable")
+	cmd.Flags().IntVar(&opts.budget, "budget", 0, "estimated token budget; drops optional evidence to fit")
+}
+
+func resolveContextRequest(command string, opts *contextResolveOptions) (string, contextcontract.Contract, error) {
 	projectRoot, found, err := config.FindProjectRootOptional()
 	if err != nil {
-		return err
+		return "", contextcontract.Contract{}, err
 	}
 	if !found {
-		return fmt.Errorf("kit project not initialized: run `kit init` before `kit context resolve`")
+		return "", contextcontract.Contract{}, withErrorClass(usage.ErrorClassNoProject, fmt.Errorf("kit project not initialized: run `kit init` before `kit context %s`", command))
+	}
+	if opts.budget < 0 {
+		return "", contextcontract.Contract{}, withErrorClass(usage.ErrorClassInvalidInput, fmt.Errorf("--budget must be zero or a positive token count"))
 	}
 	contract := contextcontract.Resolve(projectRoot, contextcontract.Request{
-		Workflow: opts.workflow,
-		Feature:  opts.feature,
-		Paths:    opts.paths,
+		Workflows: opts.workflows,
+		Feature:   opts.feature,
+		Paths:     opts.paths,
+		Budget:    opts.budget,
+		Explain:   opts.explain,
 	})
+	return projectRoot, contract, nil
+}
+
+func runContextResolve(cmd *cobra.Command, opts *contextResolveOptions) error {
+	_, contract, err := resolveContextRequest("resolve", opts)
+	if err != nil {
+		return err
+	}
 	if opts.jsonOutput {
 		encoder := json.NewEncoder(cmd.OutOrStdout())
 		encoder.SetIndent("", "  ")
@@ -73,7 +95,7 @@ func runContextResolve(cmd *cobra.Command, opts *contextResolveOptions) error {
 		return err
 	}
 	if contract.Blocked {
-		return newCLIExitError(errors.New("context resolution blocked by required local evidence"), 2, true)
+		return withErrorClass(usage.ErrorClassEvidenceBlocked, newCLIExitError(errors.New("context resolution blocked by required local evidence"), 2, true))
 	}
 	return nil
 }
@@ -0,0 +1,81 @@
+package cli
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	contextcontract "github.com/jamesonstone/kit/v3/internal/context"
+	"github.com/jamesonstone/kit/v3/internal/usage"
+)
+
+type contextBundleOptions struct {
+	request contextResolveOptions
+	out     string
+	format  string
+}
+
+func newContextBundleCommand() *cobra.Command {
+	opts := &contextBundleOptions{request: contextResolveOptions{workflows: []string{"implementation-delivery"}}, format: "tar"}
+	cmd := &cobra.Command{
+		Use:           "bundle",
+		Short:         "Export resolved evidence, its contract, and a manifest as one reproducible file",
+		Args:          cobra.NoArgs,
+		SilenceUsage:  true,
+		SilenceErrors: true,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			return runContextBundle(cmd, opts)
+		},
+	}
+	addContextRequestFlags(cmd, &opts.request)
+	cmd.Flags().StringVar(&opts.out, "out", "", "bundle file to write, or - for stdout")
+	cmd.Flags().StringVar(&opts.format, "format", opts.format, "bundle format: tar or markdown")
+	return cmd
+}
+
+func runContextBundle(cmd *cobra.Command, opts *contextBundleOptions) error {
+	out := strings.TrimSpace(opts.out)
+	if out == "" {
+		return withErrorClass(usage.ErrorClassInvalidInput, fmt.Errorf("--out is required"))
+	}
+	format := strings.ToLower(strings.TrimSpace(opts.format))
+	if format != "tar" && format != "markdown" {
+		return withErrorClass(usage.ErrorClassInvalidInput, fmt.Errorf("unsupported --format %q: use tar or markdown", opts.format))
+	}
+	projectRoot, contract, err := resolveContextRequest("bundle", &opts.request)
+	if err != nil {
+		return err
+	}
+	if contract.Blocked {
+		if renderErr := renderContextContract(cmd, contract); renderErr != nil {
+			return renderErr
+		}
+		return withErrorClass(usage.ErrorClassEvidenceBlocked, newCLIExitError(errors.New("context bundle blocked by required local evidence"), 2, true))
+	}
+	bundle, err := contextcontract.BuildBundle(projectRoot, contract)
+	if err != nil {
+		return err
+	}
+	var data bytes.Buffer
+	if format == "markdown" {
+		err = bundle.WriteMarkdown(&data)
+	} else {
+		err = bundle.WriteTar(&data)
+	}
+	if err != nil {
+		return err
+	}
+	if out == "-" {
+		_, err = cmd.OutOrStdout().Write(data.Bytes())
+		return err
+	}
+	if err := os.WriteFile(out, data.Bytes(), 0o644); err != nil {
+		return fmt.Errorf("write context bundle: %w", err)
+	}
+	_, err = fmt.Fprintf(cmd.OutOrStdout(), "Wrote %s context bundle with %d evidence items to %s\n", format, len(bundle.Manifest.Entries), out)
+	return err
+}
@@ -0,0 +1,52 @@
+package cli
+
+import (
+	"bytes"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/spf13/cobra"
+)
+
+func TestRunContextBundleWritesReproducibleFile(t *testing.T) {
+	root := setupContextCLIProject(t, false)
+	setWorkingDirectory(t, root)
+	outDir := t.TempDir()
+	var contents [2][]byte
+	for index := range contents {
+		path := filepath.Join(outDir, "bundle.md")
+		cmd := &cobra.Command{}
+		cmd.SetOut(&bytes.Buffer{})
+		opts := &contextBundleOptions{request: contextResolveOptions{workflows: []string{"test"}}, out: path, format: "markdown"}
+		if err := runContextBundle(cmd, opts); err != nil {
+			t.Fatal(err)
+		}
+		data, err := os.ReadFile(path)
+		if err != nil {
+			t.Fatal(err)
+		}
+		contents[index] = data
+	}
+	if !bytes.Equal(contents[0], contents[1]) || !strings.Contains(string(contents[0]), "evidence.md") {
+		t.Fatalf("bundle is not reproducible or lacks evidence:\n%s", contents[0])
+	}
+}
+
+func TestRunContextBundleWritesNothingWhenBlocked(t *testing.T) {
+	root := setupContextCLIProject(t, true)
+	setWorkingDirectory(t, root)
+	path := filepath.Join(t.TempDir(), "bundle.tar")
+	cmd := &cobra.Command{}
+	cmd.SetOut(&bytes.Buffer{})
+	err := runContextBundle(cmd, &contextBundleOptions{request: contextResolveOptions{workflows: []string{"test"}}, out: path, format: "tar"})
+	var exitErr *cliExitError
+	if !errors.As(err, &exitErr) || exitErr.code != 2 {
+		t.Fatalf("blocked bundle error = %#v", err)
+	}
+	if _, statErr := os.Stat(path); !os.IsNotExist(statErr) {
+		t.Fatalf("blocked bundle wrote %s", path)
+	}
+}
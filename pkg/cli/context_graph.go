@@ -0,0 +1,128 @@
+package cli
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/jamesonstone/kit/v3/internal/config"
+	contextcontract "github.com/jamesonstone/kit/v3/internal/context"
+	"github.com/jamesonstone/kit/v3/internal/usage"
+)
+
+type contextGraphOptions struct {
+	format     string
+	jsonOutput bool
+}
+
+func newContextGraphCommand() *cobra.Command {
+	opts := &contextGraphOptions{format: "dot"}
+	cmd := &cobra.Command{
+		Use:           "graph",
+		Short:         "Render every workflow manifest and rule reference as a dependency graph",
+		Args:          cobra.NoArgs,
+		SilenceUsage:  true,
+		SilenceErrors: true,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			return runContextGraph(cmd, opts)
+		},
+	}
+	cmd.Flags().StringVar(&opts.format, "format", opts.format, "graph format: dot, mermaid, or json")
+	return cmd
+}
+
+func newContextLintCommand() *cobra.Command {
+	opts := &contextGraphOptions{}
+	cmd := &cobra.Command{
+		Use:           "lint",
+		Short:         "Validate all workflow manifests, rule references, cycles, and reachability",
+		Args:          cobra.NoArgs,
+		SilenceUsage:  true,
+		SilenceErrors: true,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			return runContextLint(cmd, opts)
+		},
+	}
+	cmd.Flags().BoolVar(&opts.jsonOutput, "json", false, "emit versioned machine-readable JSON")
+	return cmd
+}
+
+func loadContextGraph(command string) (contextcontract.Graph, error) {
+	projectRoot, found, err := config.FindProjectRootOptional()
+	if err != nil {
+		return contextcontract.Graph{}, err
+	}
+	if !found {
+		return contextcontract.Graph{}, withErrorClass(usage.ErrorClassNoProject, fmt.Errorf("kit project not initialized: run `kit init` before `kit context %s`", command))
+	}
+	return contextcontract.LoadGraph(projectRoot)
+}
+
+func runContextGraph(cmd *cobra.Command, opts *contextGraphOptions) error {
+	format := strings.ToLower(strings.TrimSpace(opts.format))
+	if format != "dot" && format != "mermaid" && format != "json" {
+		return withErrorClass(usage.ErrorClassInvalidInput, fmt.Errorf("--format must be dot, mermaid, or json"))
+	}
+	graph, err := loadContextGraph("graph")
+	if err != nil {
+		return err
+	}
+	switch format {
+	case "json":
+		return writeContextGraphJSON(cmd, graph)
+	case "mermaid":
+		_, err = fmt.Fprint(cmd.OutOrStdout(), graph.Mermaid())
+	default:
+		_, err = fmt.Fprint(cmd.OutOrStdout(), graph.DOT())
+	}
+	return err
+}
+
+func runContextLint(cmd *cobra.Command, opts *contextGraphOptions) error {
+	graph, err := loadContextGraph("lint")
+	if err != nil {
+		return err
+	}
+	if opts.jsonOutput {
+		err = writeContextGraphJSON(cmd, graph)
+	} else {
+		err = renderContextLint(cmd, graph)
+	}
+	if err != nil {
+		return err
+	}
+	if !graph.Valid {
+		return withErrorClass(usage.ErrorClassCheckFailed, newCLIExitError(errors.New("workflow graph has lint errors"), 2, true))
+	}
+	return nil
+}
+
+func writeContextGraphJSON(cmd *cobra.Command, graph contextcontract.Graph) error {
+	encoder := json.NewEncoder(cmd.OutOrStdout())
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(graph)
+}
+
+func renderContextLint(cmd *cobra.Command, graph contextcontract.Graph) error {
+	out := cmd.OutOrStdout()
+	state := "valid"
+	if !graph.Valid {
+		state = "invalid"
+	}
+	if _, err := fmt.Fprintf(out, "Workflow graph %s: workflows=%d rules=%d cycles=%d unreachable=%d\n", state, len(graph.Workflows), len(graph.Rules), len(graph.Cycles), len(graph.UnreachableRules)); err != nil {
+		return err
+	}
+	for _, diagnostic := range graph.Diagnostics {
+		location := diagnostic.Path
+		if location == "" {
+			location = "-"
+		}
+		if _, err := fmt.Fprintf(out, "  %-7s %-20s %s: %s\n", diagnostic.Level, diagnostic.Code, location, diagnostic.Message); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,48 @@
+package cli
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/spf13/cobra"
+
+	contextcontract "github.com/jamesonstone/kit/v3/internal/context"
+)
+
+func TestRunContextLintExitsTwoOnWorkflowCycle(t *testing.T) {
+	root := setupContextCLIProject(t, false)
+	setWorkingDirectory(t, root)
+	var output bytes.Buffer
+	cmd := &cobra.Command{}
+	cmd.SetOut(&output)
+	if err := runContextLint(cmd, &contextGraphOptions{}); err != nil {
+		t.Fatalf("valid graph error = %v\n%s", err, output.String())
+	}
+
+	writeFile(t, filepath.Join(root, "docs/references/workflows/loop.md"), "---\nkind: workflow\nslug: loop\ndescription: cycle\ndependencies:\n  - loop\n---\n# Loop\n")
+	output.Reset()
+	err := runContextLint(cmd, &contextGraphOptions{jsonOutput: true})
+	var exitErr *cliExitError
+	if !errors.As(err, &exitErr) || exitErr.code != 2 {
+		t.Fatalf("cycle error = %#v, want exit code 2", err)
+	}
+	var graph contextcontract.Graph
+	if decodeErr := json.Unmarshal(output.Bytes(), &graph); decodeErr != nil {
+		t.Fatalf("graph JSON is invalid: %v\n%s", decodeErr, output.String())
+	}
+	if graph.Valid || len(graph.Cycles) != 1 || graph.Cycles[0][0] != "loop" {
+		t.Fatalf("graph = %#v", graph)
+	}
+
+	output.Reset()
+	if err := runContextGraph(cmd, &contextGraphOptions{format: "mermaid"}); err != nil {
+		t.Fatal(err)
+	}
+	if !strings.HasPrefix(output.String(), "flowchart LR\n") || !strings.Contains(output.String(), "workflow_test --> rule_local") {
+		t.Fatalf("mermaid output = %s", output.String())
+	}
+}
@@ -15,7 +15,7 @@ func renderContextContract(cmd *cobra.Command, contract contextcontract.Contract
 	if contract.Blocked {
 		state = "blocked"
 	}
-	if _, err := fmt.Fprintf(out, "Context %s: workflow=%s evidence=%d\n", state, contract.Request.Workflow, len(contract.Evidence)); err != nil {
+	if _, err := fmt.Fprintf(out, "Context %s: workflow=%s evidence=%d tokens~%d\n", state, contextWorkflowLabel(contract.Request), len(contract.Evidence), contract.Tokens); err != nil {
 		return err
 	}
 	for _, item := range contract.Evidence {
@@ -26,6 +26,16 @@ func renderContextContract(cmd *cobra.Command, contract contextcontract.Contract
 		if _, err := fmt.Fprintf(out, "  %-12s %-8s %-7s %s\n", item.Kind, requirement, item.State, item.Path); err != nil {
 			return err
 		}
+		for _, span := range item.Spans {
+			if _, err := fmt.Fprintf(out, "    %s %q lines %d-%d\n", span.SelectorType, span.Selector, span.StartLine, span.EndLine); err != nil {
+				return err
+			}
+		}
+	}
+	for _, skipped := range contract.Skipped {
+		if _, err := fmt.Fprintf(out, "  %-12s skipped  %s (workflow %s when %s)\n", skipped.Kind, skipped.Path, skipped.Workflow, skipped.Condition); err != nil {
+			return err
+		}
 	}
 	for _, diagnostic := range contract.Diagnostics {
 		path := ""
@@ -36,5 +46,31 @@ func renderContextContract(cmd *cobra.Command, contract contextcontract.Contract
 			return err
 		}
 	}
+	return renderContextTrace(cmd, contract.Trace)
+}
+
+func renderContextTrace(cmd *cobra.Command, trace []contextcontract.TraceStep) error {
+	if len(trace) == 0 {
+		return nil
+	}
+	out := cmd.OutOrStdout()
+	if _, err := fmt.Fprintln(out, "Resolution trace:"); err != nil {
+		return err
+	}
+	for _, step := range trace {
+		if _, err := fmt.Fprintf(out, "  %3d. %-14s %s\n", step.Step, step.Action, step.Path); err != nil {
+			return err
+		}
+		if _, err := fmt.Fprintf(out, "       from %s: %s\n", step.Source, step.Detail); err != nil {
+			return err
+		}
+	}
 	return nil
 }
+
+func contextWorkflowLabel(request contextcontract.Request) string {
+	if len(request.Workflows) == 0 {
+		return request.Workflow
+	}
+	return strings.Join(request.Workflows, ",")
+}
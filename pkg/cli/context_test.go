@@ -20,8 +20,8 @@ func TestRunContextResolveEmitsReadyDeterministicJSONWithoutWrites(t *testing.T)
 	setWorkingDirectory(t, root)
 	before := contextCLISnapshot(t, root)
 
-	first := resolveContextJSON(t, &contextResolveOptions{workflow: "test", jsonOutput: true})
-	second := resolveContextJSON(t, &contextResolveOptions{workflow: "test", jsonOutput: true})
+	first := resolveContextJSON(t, &contextResolveOptions{workflows: []string{"test"}, jsonOutput: true})
+	second := resolveContextJSON(t, &contextResolveOptions{workflows: []string{"test"}, jsonOutput: true})
 	if !stdreflect.DeepEqual(first, second) {
 		t.Fatalf("context JSON changed across resolutions:\n%#v\n%#v", first, second)
 	}
@@ -34,13 +34,29 @@ func TestRunContextResolveEmitsReadyDeterministicJSONWithoutWrites(t *testing.T)
 	}
 }
 
+func TestRunContextResolveExplainRendersTrace(t *testing.T) {
+	root := setupContextCLIProject(t, false)
+	setWorkingDirectory(t, root)
+	var output bytes.Buffer
+	cmd := &cobra.Command{}
+	cmd.SetOut(&output)
+	if err := runContextResolve(cmd, &contextResolveOptions{workflows: []string{"test"}, explain: true}); err != nil {
+		t.Fatal(err)
+	}
+	for _, want := range []string{"Resolution trace:", "visit-workflow", "from --workflow: requested workflow test", "from docs/references/workflows/test.md:"} {
+		if !bytes.Contains(output.Bytes(), []byte(want)) {
+			t.Fatalf("explain output missing %q:\n%s", want, output.String())
+		}
+	}
+}
+
 func TestRunContextResolveReturnsBlockedJSONAndExitTwo(t *testing.T) {
 	root := setupContextCLIProject(t, true)
 	setWorkingDirectory(t, root)
 	var output bytes.Buffer
 	cmd := &cobra.Command{}
 	cmd.SetOut(&output)
-	err := runContextResolve(cmd, &contextResolveOptions{workflow: "test", jsonOutput: true})
+	err := runContextResolve(cmd, &contextResolveOptions{workflows: []string{"test"}, jsonOutput: true})
 	var exitErr *cliExitError
 	if !errors.As(err, &exitErr) || exitErr.code != 2 || !exitErr.silent {
 		t.Fatalf("blocked error = %#v, want silent exit code 2", err)
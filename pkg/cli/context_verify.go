@@ -0,0 +1,103 @@
+package cli
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/jamesonstone/kit/v3/internal/config"
+	contextcontract "github.com/jamesonstone/kit/v3/internal/context"
+	"github.com/jamesonstone/kit/v3/internal/usage"
+)
+
+type contextVerifyOptions struct {
+	contract   string
+	jsonOutput bool
+}
+
+func newContextVerifyCommand() *cobra.Command {
+	opts := &contextVerifyOptions{}
+	cmd := &cobra.Command{
+		Use:           "verify",
+		Short:         "Report evidence drift since a saved context contract was resolved",
+		Args:          cobra.NoArgs,
+		SilenceUsage:  true,
+		SilenceErrors: true,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			return runContextVerify(cmd, opts)
+		},
+	}
+	cmd.Flags().StringVar(&opts.contract, "contract", "", "saved kit.context/v1 JSON contract to verify")
+	cmd.Flags().BoolVar(&opts.jsonOutput, "json", false, "emit versioned machine-readable JSON")
+	return cmd
+}
+
+func runContextVerify(cmd *cobra.Command, opts *contextVerifyOptions) error {
+	projectRoot, found, err := config.FindProjectRootOptional()
+	if err != nil {
+		return err
+	}
+	if !found {
+		return withErrorClass(usage.ErrorClassNoProject, fmt.Errorf("kit project not initialized: run `kit init` before `kit context verify`"))
+	}
+	if strings.TrimSpace(opts.contract) == "" {
+		return withErrorClass(usage.ErrorClassInvalidInput, fmt.Errorf("--contract is required"))
+	}
+	data, err := os.ReadFile(strings.TrimSpace(opts.contract))
+	if err != nil {
+		return fmt.Errorf("read context contract: %w", err)
+	}
+	var saved contextcontract.Contract
+	if err := json.Unmarshal(data, &saved); err != nil {
+		return withErrorClass(usage.ErrorClassInvalidInput, fmt.Errorf("parse context contract: %w", err))
+	}
+	drift, err := contextcontract.Verify(projectRoot, saved)
+	if err != nil {
+		return err
+	}
+	if opts.jsonOutput {
+		encoder := json.NewEncoder(cmd.OutOrStdout())
+		encoder.SetIndent("", "  ")
+		if err := encoder.Encode(drift); err != nil {
+			return err
+		}
+	} else if err := renderContextDrift(cmd, drift); err != nil {
+		return err
+	}
+	if drift.Material {
+		return withErrorClass(usage.ErrorClassEvidenceDrift, newCLIExitError(errors.New("context contract has material evidence drift"), 2, true))
+	}
+	return nil
+}
+
+func renderContextDrift(cmd *cobra.Command, drift contextcontract.Drift) error {
+	out := cmd.OutOrStdout()
+	state := "fresh"
+	if drift.Material {
+		state = "stale"
+	} else if !drift.Fresh {
+		state = "drifted"
+	}
+	if _, err := fmt.Fprintf(out, "Context %s: workflow=%s changes=%d\n", state, contextWorkflowLabel(drift.Request), len(drift.Changes)); err != nil {
+		return err
+	}
+	for _, change := range drift.Changes {
+		requirement := "optional"
+		if change.Required {
+			requirement = "required"
+		}
+		if _, err := fmt.Fprintf(out, "  %-8s %-12s %-8s %s\n", change.Change, change.Kind, requirement, change.Path); err != nil {
+			return err
+		}
+	}
+	if drift.Material {
+		if _, err := fmt.Fprintln(out, "  Reload changed required evidence, then rerun kit context resolve."); err != nil {
+			return err
+		}
+	}
+	return nil
+}
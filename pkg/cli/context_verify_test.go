@@ -0,0 +1,50 @@
+package cli
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/spf13/cobra"
+
+	contextcontract "github.com/jamesonstone/kit/v3/internal/context"
+)
+
+func TestRunContextVerifyExitsTwoOnRequiredEvidenceDrift(t *testing.T) {
+	root := setupContextCLIProject(t, false)
+	setWorkingDirectory(t, root)
+	saved := resolveContextJSON(t, &contextResolveOptions{workflows: []string{"test"}, jsonOutput: true})
+	data, err := json.Marshal(saved)
+	if err != nil {
+		t.Fatal(err)
+	}
+	contractPath := filepath.Join(t.TempDir(), "contract.json")
+	if err := os.WriteFile(contractPath, data, 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	var output bytes.Buffer
+	cmd := &cobra.Command{}
+	cmd.SetOut(&output)
+	if err := runContextVerify(cmd, &contextVerifyOptions{contract: contractPath, jsonOutput: true}); err != nil {
+		t.Fatalf("fresh contract error = %v", err)
+	}
+
+	writeFile(t, filepath.Join(root, "evidence.md"), "changed evidence\n")
+	output.Reset()
+	err = runContextVerify(cmd, &contextVerifyOptions{contract: contractPath, jsonOutput: true})
+	var exitErr *cliExitError
+	if !errors.As(err, &exitErr) || exitErr.code != 2 {
+		t.Fatalf("drift error = %#v, want exit code 2", err)
+	}
+	var drift contextcontract.Drift
+	if decodeErr := json.Unmarshal(output.Bytes(), &drift); decodeErr != nil {
+		t.Fatalf("drift JSON is invalid: %v\n%s", decodeErr, output.String())
+	}
+	if !drift.Material || len(drift.Changes) != 1 || drift.Changes[0].Path != "evidence.md" {
+		t.Fatalf("drift = %#v", drift)
+	}
+}
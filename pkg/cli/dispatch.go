@@ -6,6 +6,8 @@ import (
 	"strings"
 
 	"github.com/spf13/cobra"
+
+	"github.com/jamesonstone/kit/v3/internal/usage"
 )
 
 var (
@@ -66,14 +68,14 @@ func init() {
 func runDispatch(cmd *cobra.Command, args []string) error {
 	outputOnly, _ := cmd.Flags().GetBool("output-only")
 	if dispatchWatch && !dispatchLoop {
-		return fmt.Errorf("--watch requires --loop")
+		return withErrorClass(usage.ErrorClassInvalidInput, fmt.Errorf("--watch requires --loop"))
 	}
 	if dispatchYes && !dispatchResolve {
-		return fmt.Errorf("--yes requires --resolve")
+		return withErrorClass(usage.ErrorClassInvalidInput, fmt.Errorf("--yes requires --resolve"))
 	}
 	if dispatchLoop {
 		if dispatchResolve {
-			return fmt.Errorf("--resolve cannot be used with --loop")
+			return withErrorClass(usage.ErrorClassInvalidInput, fmt.Errorf("--resolve cannot be used with --loop"))
 		}
 		return runDispatchReviewLoopAlias(cmd, outputOnly)
 	}
@@ -93,7 +95,7 @@ func runDispatch(cmd *cobra.Command, args []string) error {
 
 	tasks, err := normalizeDispatchTasks(rawInput)
 	if err != nil {
-		return err
+		return withErrorClass(usage.ErrorClassInvalidInput, err)
 	}
 
 	cwd, err := os.Getwd()
@@ -113,7 +115,7 @@ func runDispatch(cmd *cobra.Command, args []string) error {
 			promptOptions.PRTarget,
 		)
 		if err != nil {
-			return err
+			return withErrorClass(usage.ErrorClassExternalCommand, err)
 		}
 		workingDirectory = repair.WorktreePath
 		promptOptions.PRTarget = repair.PRURL
@@ -137,10 +139,10 @@ func runDispatch(cmd *cobra.Command, args []string) error {
 
 func runDispatchReviewLoopAlias(cmd *cobra.Command, outputOnly bool) error {
 	if strings.TrimSpace(dispatchFile) != "" {
-		return fmt.Errorf("--file cannot be used with --loop")
+		return withErrorClass(usage.ErrorClassInvalidInput, fmt.Errorf("--file cannot be used with --loop"))
 	}
 	if strings.TrimSpace(dispatchPR) == "" {
-		return fmt.Errorf("--loop requires --pr")
+		return withErrorClass(usage.ErrorClassInvalidInput, fmt.Errorf("--loop requires --pr"))
 	}
 
 	return reviewLoopExecutor(cmd, reviewLoopOptions{
@@ -6,6 +6,8 @@ import (
 	"strings"
 
 	"github.com/spf13/cobra"
+
+	"github.com/jamesonstone/kit/v3/internal/usage"
 )
 
 var dispatchReviewThreadResolver = resolveDispatchReviewThread
@@ -35,28 +37,28 @@ type dispatchReviewThreadMutationResponse struct {
 
 func runDispatchPRResolve(cmd *cobra.Command) error {
 	if strings.TrimSpace(dispatchPR) == "" {
-		return fmt.Errorf("--resolve requires --pr")
+		return withErrorClass(usage.ErrorClassInvalidInput, fmt.Errorf("--resolve requires --pr"))
 	}
 	if strings.TrimSpace(dispatchFile) != "" {
-		return fmt.Errorf("--file cannot be used with --resolve")
+		return withErrorClass(usage.ErrorClassInvalidInput, fmt.Errorf("--file cannot be used with --resolve"))
 	}
 	if dispatchWatch {
-		return fmt.Errorf("--watch requires --loop")
+		return withErrorClass(usage.ErrorClassInvalidInput, fmt.Errorf("--watch requires --loop"))
 	}
 	if dispatchCopy || dispatchOutputOnly {
-		return fmt.Errorf("--copy and --output-only cannot be used with --resolve")
+		return withErrorClass(usage.ErrorClassInvalidInput, fmt.Errorf("--copy and --output-only cannot be used with --resolve"))
 	}
 	if !dispatchYes {
-		return fmt.Errorf("--resolve mutates GitHub review threads; rerun with --yes after confirming fixes or no-op decisions are complete")
+		return withErrorClass(usage.ErrorClassInvalidInput, fmt.Errorf("--resolve mutates GitHub review threads; rerun with --yes after confirming fixes or no-op decisions are complete"))
 	}
 
 	target, err := resolveDispatchPRTarget(dispatchPR)
 	if err != nil {
-		return err
+		return withErrorClass(usage.ErrorClassInvalidInput, err)
 	}
 	threads, err := fetchDispatchPRReviewThreads(target)
 	if err != nil {
-		return err
+		return withErrorClass(usage.ErrorClassExternalCommand, err)
 	}
 	candidates := collectDispatchReviewResolutionCandidates(threads, dispatchCodeRabbit)
 	if len(candidates) == 0 {
@@ -66,12 +68,12 @@ func runDispatchPRResolve(cmd *cobra.Command) error {
 
 	for index, candidate := range candidates {
 		if err := dispatchReviewThreadResolver(candidate.ThreadID); err != nil {
-			return fmt.Errorf("failed to resolve review thread %s after %d/%d successful resolutions: %w",
+			return withErrorClass(usage.ErrorClassExternalCommand, fmt.Errorf("failed to resolve review thread %s after %d/%d successful resolutions: %w",
 				candidate.ThreadID,
 				index,
 				len(candidates),
 				err,
-			)
+			))
 		}
 	}
 
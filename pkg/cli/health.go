@@ -12,6 +12,7 @@ import (
 	"github.com/spf13/cobra"
 
 	"github.com/jamesonstone/kit/v3/internal/config"
+	"github.com/jamesonstone/kit/v3/internal/usage"
 )
 
 const (
@@ -61,10 +62,10 @@ func runHealth(cmd *cobra.Command, _ []string) error {
 	diffOutput, _ := cmd.Flags().GetBool("diff")
 	jsonOutput, _ := cmd.Flags().GetBool("json")
 	if diffOutput && !dryRun {
-		return fmt.Errorf("--diff requires --dry-run")
+		return withErrorClass(usage.ErrorClassInvalidInput, fmt.Errorf("--diff requires --dry-run"))
 	}
 	if diffOutput && jsonOutput {
-		return fmt.Errorf("--diff cannot be combined with --json")
+		return withErrorClass(usage.ErrorClassInvalidInput, fmt.Errorf("--diff cannot be combined with --json"))
 	}
 
 	projectRoot, err := config.FindProjectRoot()
@@ -73,7 +74,7 @@ func runHealth(cmd *cobra.Command, _ []string) error {
 	}
 	cfg, err := config.Load(projectRoot)
 	if err != nil {
-		return err
+		return withErrorClass(usage.ErrorClassConfigInvalid, err)
 	}
 	report := healthReport{
 		Managed:      cfg.IsHealthManaged(),
@@ -127,7 +128,7 @@ func runHealth(cmd *cobra.Command, _ []string) error {
 	}
 	updatedCfg, err := config.Load(projectRoot)
 	if err != nil {
-		return fmt.Errorf("failed to reload config after Kit health refresh: %w", err)
+		return withErrorClass(usage.ErrorClassConfigInvalid, fmt.Errorf("failed to reload config after Kit health refresh: %w", err))
 	}
 	registryReport, err := buildRegistryStatusReport(projectRoot, updatedCfg)
 	if err != nil {
@@ -158,7 +159,7 @@ func runHealth(cmd *cobra.Command, _ []string) error {
 		return err
 	}
 	if checkErr != nil {
-		return &silentCLIError{err: checkErr}
+		return withErrorClass(usage.ErrorClassCheckFailed, &silentCLIError{err: checkErr})
 	}
 	return nil
 }
@@ -3,6 +3,7 @@ package cli
 import (
 	"context"
 	"fmt"
+	"io"
 	"os"
 	"os/exec"
 	"strings"
@@ -11,7 +12,9 @@ import (
 	"github.com/spf13/cobra"
 
 	"github.com/jamesonstone/kit/v3/internal/config"
+	"github.com/jamesonstone/kit/v3/internal/export"
 	"github.com/jamesonstone/kit/v3/internal/improve"
+	"github.com/jamesonstone/kit/v3/internal/usage"
 )
 
 type improveOptions struct {
@@ -19,6 +22,9 @@ type improveOptions struct {
 	kitBinary string
 	dryRun    bool
 	json      bool
+	junit     string
+	parallel  int
+	split     string
 }
 
 func init() {
@@ -37,7 +43,7 @@ func newImproveCommand() *cobra.Command {
 		},
 	}
 	cmd.PersistentFlags().BoolVar(&opts.json, "json", false, "emit machine-readable JSON output")
-	cmd.AddCommand(newImproveRunCommand(opts))
+	cmd.AddCommand(newImproveRunCommand(opts), newImproveMineCommand(opts), newImproveProposeCommand(opts), newImproveValidateCommand(opts), newImproveReportCommand(opts), newImprovePRBodyCommand(opts), newImproveCompareCommand(opts))
 	return cmd
 }
 
@@ -48,6 +54,12 @@ func newImproveRunCommand(opts *improveOptions) *cobra.Command {
 		Args:         cobra.NoArgs,
 		SilenceUsage: true,
 		RunE: func(cmd *cobra.Command, _ []string) error {
+			if opts.parallel < 1 {
+				return withErrorClass(usage.ErrorClassInvalidInput, fmt.Errorf("--parallel must be at least 1"))
+			}
+			if err := improve.ValidateSplit(opts.split); err != nil {
+				return withErrorClass(usage.ErrorClassInvalidInput, err)
+			}
 			root, err := config.FindProjectRoot()
 			if err != nil {
 				return err
@@ -60,15 +72,22 @@ func newImproveRunCommand(opts *improveOptions) *cobra.Command {
 				ProjectRoot: root, SuiteName: opts.suite, DryRun: opts.dryRun,
 				RunnerBinary: currentExecutable(), KitBinary: kitBinary,
 				KitVersion: Version, GitCommit: currentGitCommit(root),
+				Parallel: opts.parallel, Split: opts.split,
 			})
 			if err != nil {
 				return err
 			}
+			if opts.junit != "" {
+				report := export.ImproveRunJUnit(manifest)
+				if err := export.WriteFile(opts.junit, func(w io.Writer) error { return export.WriteJUnit(w, report) }); err != nil {
+					return fmt.Errorf("write JUnit report: %w", err)
+				}
+			}
 			if opts.json {
 				if err := outputJSON(cmd.OutOrStdout(), manifest); err != nil {
 					return err
 				}
-			} else if _, err := fmt.Fprintf(cmd.OutOrStdout(), "kit improve run %s: %s (%d traces)\n", manifest.RunID, manifest.Status, len(manifest.Traces)); err != nil {
+			} else if _, err := fmt.Fprintf(cmd.OutOrStdout(), "kit improve run %s: %s (%d %s traces)\n", manifest.RunID, manifest.Status, len(manifest.Traces), manifest.Split); err != nil {
 				return err
 			}
 			return improveRunFailure(manifest)
@@ -77,12 +96,15 @@ func newImproveRunCommand(opts *improveOptions) *cobra.Command {
 	cmd.Flags().StringVar(&opts.suite, "suite", "default", "benchmark suite name")
 	cmd.Flags().StringVar(&opts.kitBinary, "kit-binary", "", "Kit executable evaluated by the suite; defaults to the current executable")
 	cmd.Flags().BoolVar(&opts.dryRun, "dry-run", false, "plan the run without writing artifacts")
+	cmd.Flags().StringVar(&opts.split, "split", improve.SplitHeldIn, "suite tasks to run: held-in, held-out, or all")
+	cmd.Flags().IntVar(&opts.parallel, "parallel", 1, "maximum task repeats to run concurrently in isolated workspaces")
+	cmd.Flags().StringVar(&opts.junit, "junit", "", "also write the run as JUnit XML to this path")
 	return cmd
 }
 
 func improveRunFailure(manifest improve.RunManifest) error {
 	if manifest.Status == "failed" {
-		return fmt.Errorf("kit improve benchmark %s failed; inspect %s", manifest.RunID, manifest.RunDir)
+		return withErrorClass(usage.ErrorClassCheckFailed, fmt.Errorf("kit improve benchmark %s failed; inspect %s", manifest.RunID, manifest.RunDir))
 	}
 	return nil
 }
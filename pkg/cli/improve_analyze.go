@@ -0,0 +1,172 @@
+package cli
+
+import (
+	"fmt"
+	"path/filepath"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/jamesonstone/kit/v3/internal/config"
+	"github.com/jamesonstone/kit/v3/internal/improve"
+	"github.com/jamesonstone/kit/v3/internal/usage"
+)
+
+type improveAnalyzeOptions struct {
+	from          string
+	maxCandidates int
+	candidate     string
+	issue         string
+	run           string
+}
+
+type improveMarkdownOutput struct {
+	SourceDir string `json:"source_dir"`
+	Markdown  string `json:"markdown"`
+}
+
+func newImproveMineCommand(opts *improveOptions) *cobra.Command {
+	local := &improveAnalyzeOptions{}
+	cmd := newImproveAnalyzeCommand("mine", "Cluster failed benchmark traces into a weakness report", func(cmd *cobra.Command, root string) error {
+		report, err := improve.Mine(root, local.from)
+		if err != nil {
+			return err
+		}
+		if opts.json {
+			return outputJSON(cmd.OutOrStdout(), report)
+		}
+		out := cmd.OutOrStdout()
+		if _, err := fmt.Fprintf(out, "kit improve mine: %d weakness clusters from %s\n", len(report.Clusters), report.SourceDir); err != nil {
+			return err
+		}
+		for _, cluster := range report.Clusters {
+			if _, err := fmt.Fprintf(out, "  - %s [%s, confidence %s, reproduced %d]\n", cluster.Signature, cluster.Actionability, cluster.Confidence, cluster.ReproducibilityCount); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	addImproveFromFlag(cmd, local)
+	return cmd
+}
+
+func newImproveProposeCommand(opts *improveOptions) *cobra.Command {
+	local := &improveAnalyzeOptions{}
+	cmd := newImproveAnalyzeCommand("propose", "Generate candidate harness changes from weakness clusters", func(cmd *cobra.Command, root string) error {
+		if local.maxCandidates < 1 {
+			return withErrorClass(usage.ErrorClassInvalidInput, fmt.Errorf("--max-candidates must be at least 1"))
+		}
+		candidates, err := improve.Propose(root, local.from, local.maxCandidates)
+		if err != nil {
+			return err
+		}
+		if opts.json {
+			if candidates == nil {
+				candidates = []improve.Candidate{}
+			}
+			return outputJSON(cmd.OutOrStdout(), candidates)
+		}
+		out := cmd.OutOrStdout()
+		if _, err := fmt.Fprintf(out, "kit improve propose: %d candidates\n", len(candidates)); err != nil {
+			return err
+		}
+		for _, candidate := range candidates {
+			if _, err := fmt.Fprintf(out, "  - %s: %s\n    prompt: %s\n", candidate.ID, candidate.Summary, relativeCheckPath(root, candidate.PromptPath)); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	addImproveFromFlag(cmd, local)
+	cmd.Flags().IntVar(&local.maxCandidates, "max-candidates", 3, "maximum candidates to generate, one per weakness cluster")
+	return cmd
+}
+
+func newImproveValidateCommand(opts *improveOptions) *cobra.Command {
+	local := &improveAnalyzeOptions{}
+	cmd := newImproveAnalyzeCommand("validate", "Validate candidate metadata and emit a scorecard", func(cmd *cobra.Command, root string) error {
+		if strings.TrimSpace(local.candidate) == "" {
+			return withErrorClass(usage.ErrorClassInvalidInput, fmt.Errorf("--candidate is required"))
+		}
+		scorecard, err := improve.Validate(root, improve.CandidatePath(root, local.from, local.candidate), local.run)
+		if err != nil {
+			return err
+		}
+		if opts.json {
+			return outputJSON(cmd.OutOrStdout(), scorecard)
+		}
+		out := cmd.OutOrStdout()
+		if _, err := fmt.Fprintf(out, "kit improve validate %s: %s (score %d)\n", scorecard.CandidateID, scorecard.Acceptance, scorecard.Score); err != nil {
+			return err
+		}
+		for _, reason := range scorecard.Reasons {
+			if _, err := fmt.Fprintf(out, "  - %s\n", reason); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	addImproveFromFlag(cmd, local)
+	cmd.Flags().StringVar(&local.candidate, "candidate", "", "candidate ID under --from, or a path to candidate.json")
+	cmd.Flags().StringVar(&local.run, "run", "", "score the candidate on this run ID, run directory, or latest; refused when it holds tasks the proposer saw")
+	return cmd
+}
+
+func newImproveReportCommand(opts *improveOptions) *cobra.Command {
+	local := &improveAnalyzeOptions{}
+	cmd := newImproveAnalyzeCommand("report", "Summarize a benchmark run as a Markdown report", func(cmd *cobra.Command, root string) error {
+		report, err := improve.Report(root, local.from)
+		if err != nil {
+			return err
+		}
+		return writeImproveMarkdown(cmd, opts, local.from, report)
+	})
+	addImproveFromFlag(cmd, local)
+	return cmd
+}
+
+func newImprovePRBodyCommand(opts *improveOptions) *cobra.Command {
+	local := &improveAnalyzeOptions{}
+	cmd := newImproveAnalyzeCommand("pr-body", "Render a pull-request body carrying benchmark evidence", func(cmd *cobra.Command, root string) error {
+		body, err := improve.PullRequestBody(root, local.from, local.issue)
+		if err != nil {
+			return err
+		}
+		return writeImproveMarkdown(cmd, opts, local.from, body)
+	})
+	addImproveFromFlag(cmd, local)
+	cmd.Flags().StringVar(&local.issue, "issue", "", "ticket reference for the Ticket section, such as #123")
+	return cmd
+}
+
+func newImproveAnalyzeCommand(use, short string, run func(*cobra.Command, string) error) *cobra.Command {
+	return &cobra.Command{
+		Use:          use,
+		Short:        short,
+		Args:         cobra.NoArgs,
+		SilenceUsage: true,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			root, err := config.FindProjectRoot()
+			if err != nil {
+				return err
+			}
+			return run(cmd, root)
+		},
+	}
+}
+
+func addImproveFromFlag(cmd *cobra.Command, opts *improveAnalyzeOptions) {
+	cmd.Flags().StringVar(&opts.from, "from", "", "run artifact directory; defaults to .kit/improve/latest")
+}
+
+func writeImproveMarkdown(cmd *cobra.Command, opts *improveOptions, from, markdown string) error {
+	if opts.json {
+		source := from
+		if strings.TrimSpace(source) == "" {
+			source = filepath.ToSlash(filepath.Join(improve.ArtifactDir, "latest"))
+		}
+		return outputJSON(cmd.OutOrStdout(), improveMarkdownOutput{SourceDir: source, Markdown: markdown})
+	}
+	_, err := fmt.Fprint(cmd.OutOrStdout(), markdown)
+	return err
+}
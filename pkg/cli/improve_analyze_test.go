@@ -0,0 +1,146 @@
+package cli
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/jamesonstone/kit/v3/internal/config"
+	"github.com/jamesonstone/kit/v3/internal/improve"
+)
+
+func TestImproveAnalysisCommandsRunEndToEnd(t *testing.T) {
+	root := t.TempDir()
+	if err := config.Save(root, config.Default()); err != nil {
+		t.Fatalf("config.Save() error = %v", err)
+	}
+	runDir := filepath.Join(root, ".kit", "improve", "runs", "run-1")
+	traces := []improve.Trace{
+		{TaskID: "alpha", RepeatIndex: 1, Status: "failed", FailureSignature: "alpha:pkg/cli:exit"},
+		{TaskID: "alpha", RepeatIndex: 2, Status: "failed", FailureSignature: "alpha:pkg/cli:exit"},
+		{TaskID: "beta", RepeatIndex: 1, Status: "passed"},
+	}
+	for _, trace := range traces {
+		data, err := json.Marshal(trace)
+		if err != nil {
+			t.Fatal(err)
+		}
+		writeFile(t, filepath.Join(runDir, "traces", fmt.Sprintf("%s-%d.json", trace.TaskID, trace.RepeatIndex)), string(data))
+	}
+	manifest, err := json.Marshal(improve.RunManifest{SchemaVersion: improve.SchemaVersion, RunID: "run-1", Suite: "default", Status: "failed", Traces: traces})
+	if err != nil {
+		t.Fatal(err)
+	}
+	writeFile(t, filepath.Join(runDir, "run.json"), string(manifest))
+	setWorkingDirectory(t, root)
+	from := ".kit/improve/runs/run-1"
+
+	var report improve.WeaknessReport
+	decodeImproveJSON(t, []string{"mine", "--from", from, "--json"}, &report)
+	if len(report.Clusters) != 1 || report.Clusters[0].Actionability != "actionable" || report.Clusters[0].ReproducibilityCount != 2 {
+		t.Fatalf("weakness report = %+v", report)
+	}
+
+	var candidates []improve.Candidate
+	decodeImproveJSON(t, []string{"propose", "--from", from, "--max-candidates", "1", "--json"}, &candidates)
+	if len(candidates) != 1 || candidates[0].ID != "candidate-001" {
+		t.Fatalf("candidates = %+v", candidates)
+	}
+
+	var scorecard improve.Scorecard
+	decodeImproveJSON(t, []string{"validate", "--from", from, "--candidate", "candidate-001", "--json"}, &scorecard)
+	if scorecard.CandidateID != "candidate-001" || scorecard.Acceptance != "metadata-only" {
+		t.Fatalf("scorecard = %+v", scorecard)
+	}
+
+	body := executeImprove(t, "pr-body", "--from", from, "--issue", "#42")
+	for _, want := range []string{"## Ticket\n\nRefs #42", "- Suite: `default`", "- `alpha`: failed"} {
+		if !strings.Contains(body, want) {
+			t.Fatalf("pr-body missing %q:\n%s", want, body)
+		}
+	}
+	if _, err := os.Stat(filepath.Join(runDir, "report.md")); err != nil {
+		t.Fatalf("report.md was not written: %v", err)
+	}
+}
+
+func TestImproveValidateRequiresCandidate(t *testing.T) {
+	root := t.TempDir()
+	if err := config.Save(root, config.Default()); err != nil {
+		t.Fatalf("config.Save() error = %v", err)
+	}
+	setWorkingDirectory(t, root)
+	cmd := newImproveCommand()
+	cmd.SetOut(&bytes.Buffer{})
+	cmd.SetErr(&bytes.Buffer{})
+	cmd.SetArgs([]string{"validate"})
+	if err := cmd.Execute(); err == nil || !strings.Contains(err.Error(), "--candidate is required") {
+		t.Fatalf("kit improve validate error = %v", err)
+	}
+}
+
+func decodeImproveJSON(t *testing.T, args []string, out any) {
+	t.Helper()
+	output := executeImprove(t, args...)
+	if err := json.Unmarshal([]byte(output), out); err != nil {
+		t.Fatalf("kit improve %s output is not JSON: %v\n%s", args[0], err, output)
+	}
+}
+
+func executeImprove(t *testing.T, args ...string) string {
+	t.Helper()
+	cmd := newImproveCommand()
+	var out bytes.Buffer
+	cmd.SetOut(&out)
+	cmd.SetErr(&out)
+	cmd.SetArgs(args)
+	if err := cmd.Execute(); err != nil {
+		t.Fatalf("kit improve %v error = %v\n%s", args, err, out.String())
+	}
+	return out.String()
+}
+
+func TestImproveCompareFailsOnTokenRegression(t *testing.T) {
+	root := t.TempDir()
+	if err := config.Save(root, config.Default()); err != nil {
+		t.Fatalf("config.Save() error = %v", err)
+	}
+	for id, tokens := range map[string]int{"base": 100, "cand": 150} {
+		manifest := improve.RunManifest{RunID: id, Traces: []improve.Trace{{
+			TaskID:   "alpha",
+			Status:   "passed",
+			Commands: []improve.CommandTrace{{Stdout: improve.TextMetrics{EstimatedTokens: tokens}}},
+		}}}
+		data, err := json.Marshal(manifest)
+		if err != nil {
+			t.Fatal(err)
+		}
+		writeFile(t, filepath.Join(root, ".kit", "improve", "runs", id, "run.json"), string(data))
+	}
+	setWorkingDirectory(t, root)
+
+	cmd := newImproveCommand()
+	var out bytes.Buffer
+	cmd.SetOut(&out)
+	cmd.SetErr(&out)
+	cmd.SetArgs([]string{"compare", "--baseline", "base", "--candidate", "cand"})
+	err := cmd.Execute()
+	var exitErr *cliExitError
+	if !errors.As(err, &exitErr) || exitErr.code != 1 {
+		t.Fatalf("kit improve compare error = %v, want exit 1\n%s", err, out.String())
+	}
+	if !strings.Contains(out.String(), "task alpha stdout estimated tokens max grew 50.0% (100 -> 150)") {
+		t.Fatalf("comparison output:\n%s", out.String())
+	}
+
+	var comparison improve.Comparison
+	decodeImproveJSON(t, []string{"compare", "--baseline", "base", "--candidate", "cand", "--max-token-growth", "0.5", "--json"}, &comparison)
+	if comparison.Status != "pass" || comparison.Tasks[0].CandidateTokensMax != 150 {
+		t.Fatalf("comparison = %+v", comparison)
+	}
+}
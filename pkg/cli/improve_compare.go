@@ -0,0 +1,87 @@
+package cli
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/jamesonstone/kit/v3/internal/improve"
+	"github.com/jamesonstone/kit/v3/internal/usage"
+)
+
+type improveCompareOptions struct {
+	baseline   string
+	candidate  string
+	thresholds improve.CompareThresholds
+}
+
+func newImproveCompareCommand(opts *improveOptions) *cobra.Command {
+	local := &improveCompareOptions{}
+	cmd := newImproveAnalyzeCommand("compare", "Compare a candidate benchmark run against a baseline run", func(cmd *cobra.Command, root string) error {
+		if strings.TrimSpace(local.baseline) == "" || strings.TrimSpace(local.candidate) == "" {
+			return withErrorClass(usage.ErrorClassInvalidInput, fmt.Errorf("--baseline and --candidate are required"))
+		}
+		thresholds := local.thresholds
+		if thresholds.MaxTokenGrowth < 0 || thresholds.MaxSuccessRateDrop < 0 || thresholds.MaxDeterminismDrop < 0 {
+			return fmt.Errorf("comparison thresholds must be zero or positive")
+		}
+		comparison, err := improve.Compare(root, local.baseline, local.candidate, thresholds)
+		if err != nil {
+			return err
+		}
+		if opts.json {
+			err = outputJSON(cmd.OutOrStdout(), comparison)
+		} else {
+			err = renderImproveComparison(cmd, comparison)
+		}
+		if err != nil {
+			return err
+		}
+		if comparison.Status != "pass" {
+			return withErrorClass(usage.ErrorClassCheckFailed, newCLIExitError(errors.New("candidate run regressed against the baseline"), 1, true))
+		}
+		return nil
+	})
+	cmd.Flags().StringVar(&local.baseline, "baseline", "", "baseline run ID, run directory, or latest")
+	cmd.Flags().StringVar(&local.candidate, "candidate", "", "candidate run ID, run directory, or latest")
+	cmd.Flags().Float64Var(&local.thresholds.MaxTokenGrowth, "max-token-growth", 0.1, "allowed per-task growth of the largest stdout token estimate, as a fraction")
+	cmd.Flags().Float64Var(&local.thresholds.MaxSuccessRateDrop, "max-success-drop", 0, "allowed drop in task success rate, as a fraction")
+	cmd.Flags().Float64Var(&local.thresholds.MaxDeterminismDrop, "max-determinism-drop", 0, "allowed drop in repeated-output determinism rate, as a fraction")
+	cmd.Flags().BoolVar(&local.thresholds.AllowNewFailures, "allow-new-failures", false, "report regressed tasks and newly failing assertions without failing")
+	return cmd
+}
+
+func renderImproveComparison(cmd *cobra.Command, comparison improve.Comparison) error {
+	out := cmd.OutOrStdout()
+	if _, err := fmt.Fprintf(out, "kit improve compare %s -> %s: %s\n", comparison.BaselineRunID, comparison.CandidateRunID, comparison.Status); err != nil {
+		return err
+	}
+	if _, err := fmt.Fprintf(out, "  success %.3f -> %.3f, determinism %.3f -> %.3f, stdout tokens %.0f -> %.0f\n",
+		comparison.TaskSuccessRate.Baseline, comparison.TaskSuccessRate.Candidate,
+		comparison.DeterminismRate.Baseline, comparison.DeterminismRate.Candidate,
+		comparison.StdoutEstimatedTokens.Baseline, comparison.StdoutEstimatedTokens.Candidate); err != nil {
+		return err
+	}
+	for _, task := range comparison.Tasks {
+		determinism := ""
+		if task.DeterminismChange != "" {
+			determinism = ", determinism " + task.DeterminismChange
+		}
+		if _, err := fmt.Fprintf(out, "  - %s %s: %d/%d -> %d/%d passed, tokens max %d -> %d%s\n", task.TaskID, task.Status, task.BaselinePassed, task.BaselineRuns, task.CandidatePassed, task.CandidateRuns, task.BaselineTokensMax, task.CandidateTokensMax, determinism); err != nil {
+			return err
+		}
+	}
+	for _, assertion := range comparison.NewlyFailingAssertions {
+		if _, err := fmt.Fprintf(out, "  newly failing: %s assertion %d %s: %s\n", assertion.TaskID, assertion.Index+1, assertion.Type, assertion.Message); err != nil {
+			return err
+		}
+	}
+	for _, regression := range comparison.Regressions {
+		if _, err := fmt.Fprintf(out, "  ❌ %s\n", regression); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -64,9 +64,25 @@ func TestImproveRunPropagatesHumanReadableWriteError(t *testing.T) {
 	}
 }
 
+func TestImproveRunRejectsUnknownSplit(t *testing.T) {
+	cmd := newImproveRunCommand(&improveOptions{})
+	cmd.SetOut(&bytes.Buffer{})
+	cmd.SetErr(&bytes.Buffer{})
+	cmd.SetArgs([]string{"--dry-run", "--split", "held-over"})
+
+	if err := cmd.Execute(); err == nil || !strings.Contains(err.Error(), `unsupported split "held-over"`) {
+		t.Fatalf("kit improve run --split held-over error = %v", err)
+	}
+}
+
 func TestImproveCommandRegistersSubcommands(t *testing.T) {
 	cmd := newImproveCommand()
-	if len(cmd.Commands()) != 1 || cmd.Commands()[0].Name() != "run" {
-		t.Fatalf("improve subcommands = %#v, want only run", cmd.Commands())
+	var names []string
+	for _, sub := range cmd.Commands() {
+		names = append(names, sub.Name())
+	}
+	want := []string{"compare", "mine", "pr-body", "propose", "report", "run", "validate"}
+	if strings.Join(names, ",") != strings.Join(want, ",") {
+		t.Fatalf("improve subcommands = %v, want %v", names, want)
 	}
 }
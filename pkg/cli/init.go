@@ -10,6 +10,7 @@ import (
 	"github.com/jamesonstone/kit/v3/internal/config"
 	"github.com/jamesonstone/kit/v3/internal/document"
 	"github.com/jamesonstone/kit/v3/internal/templates"
+	"github.com/jamesonstone/kit/v3/internal/usage"
 )
 
 var initCopy bool
@@ -73,19 +74,19 @@ func init() {
 
 func runInit(cmd *cobra.Command, args []string) error {
 	if initForce && !initRefresh {
-		return fmt.Errorf("--force requires --refresh")
+		return withErrorClass(usage.ErrorClassInvalidInput, fmt.Errorf("--force requires --refresh"))
 	}
 	if initDryRun && !initRefresh {
-		return fmt.Errorf("--dry-run requires --refresh")
+		return withErrorClass(usage.ErrorClassInvalidInput, fmt.Errorf("--dry-run requires --refresh"))
 	}
 	if initDiff && !initRefresh {
-		return fmt.Errorf("--diff requires --refresh")
+		return withErrorClass(usage.ErrorClassInvalidInput, fmt.Errorf("--diff requires --refresh"))
 	}
 	if initDiff && !initDryRun {
-		return fmt.Errorf("--diff requires --dry-run")
+		return withErrorClass(usage.ErrorClassInvalidInput, fmt.Errorf("--diff requires --dry-run"))
 	}
 	if len(initRefreshFiles) > 0 && !initRefresh {
-		return fmt.Errorf("--file requires --refresh")
+		return withErrorClass(usage.ErrorClassInvalidInput, fmt.Errorf("--file requires --refresh"))
 	}
 
 	cwd, err := os.Getwd()
@@ -107,7 +108,7 @@ func runInit(cmd *cobra.Command, args []string) error {
 	if config.Exists(cwd) {
 		deliveryCfg, err = config.Load(cwd)
 		if err != nil {
-			return err
+			return withErrorClass(usage.ErrorClassConfigInvalid, err)
 		}
 	}
 	deliveryBaseline, err := captureManagedFileDeliveryBaseline(
@@ -130,7 +131,7 @@ func runInit(cmd *cobra.Command, args []string) error {
 		}
 		existing, err := config.Load(cwd)
 		if err != nil {
-			return err
+			return withErrorClass(usage.ErrorClassConfigInvalid, err)
 		}
 		cfg = existing
 		if !config.IsInstructionScaffoldVersionSupported(cfg.InstructionScaffoldVersion) {
@@ -153,7 +154,7 @@ func runInit(cmd *cobra.Command, args []string) error {
 	if !initOutputOnly && streamsHaveInteractiveTerminal(os.Stdin, os.Stdout) {
 		inspectionCfg, inspection, err := config.LoadWithInspection(cwd)
 		if err != nil {
-			return err
+			return withErrorClass(usage.ErrorClassConfigInvalid, err)
 		}
 		changed, err := remediateProjectConfig(cwd, inspectionCfg, inspection, configRemediationOptions{
 			Interactive: true,
@@ -166,7 +167,7 @@ func runInit(cmd *cobra.Command, args []string) error {
 		if changed {
 			cfg, err = config.Load(cwd)
 			if err != nil {
-				return err
+				return withErrorClass(usage.ErrorClassConfigInvalid, err)
 			}
 		}
 	}
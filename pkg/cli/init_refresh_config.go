@@ -6,6 +6,7 @@ import (
 	"path/filepath"
 
 	"github.com/jamesonstone/kit/v3/internal/config"
+	"github.com/jamesonstone/kit/v3/internal/usage"
 	"gopkg.in/yaml.v3"
 )
 
@@ -30,7 +31,7 @@ func initRefreshConfig(
 		before = string(data)
 		existing, currentInspection, err := config.LoadWithInspection(projectRoot)
 		if err != nil {
-			return nil, nil, fmt.Errorf("failed to load %s: %w", config.ConfigFileName, err)
+			return nil, nil, withErrorClass(usage.ErrorClassConfigInvalid, fmt.Errorf("failed to load %s: %w", config.ConfigFileName, err))
 		}
 		if currentInspection.SchemaState == config.SchemaStateNewer {
 			return nil, nil, fmt.Errorf("%s", currentInspection.Findings[0].Message)
@@ -7,6 +7,7 @@ import (
 	"github.com/spf13/cobra"
 
 	"github.com/jamesonstone/kit/v3/internal/instructions"
+	"github.com/jamesonstone/kit/v3/internal/usage"
 )
 
 var instructionsCmd = newInstructionsCommand()
@@ -37,15 +38,15 @@ func runInstructions(cmd *cobra.Command, args []string) error {
 		return err
 	}
 	if cmd.Flags().Changed("version") && version == "" {
-		return fmt.Errorf(
+		return withErrorClass(usage.ErrorClassInvalidInput, fmt.Errorf(
 			"--version cannot be empty; available versions: %s",
 			strings.Join(instructions.AgentInstructionVersions(), ", "),
-		)
+		))
 	}
 
 	content, err := instructions.AgentInstructions(version)
 	if err != nil {
-		return err
+		return withErrorClass(usage.ErrorClassNotFound, err)
 	}
 	_, err = fmt.Fprint(cmd.OutOrStdout(), content)
 	return err
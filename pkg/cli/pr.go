@@ -7,6 +7,8 @@ import (
 	"strings"
 
 	"github.com/spf13/cobra"
+
+	"github.com/jamesonstone/kit/v3/internal/usage"
 )
 
 var (
@@ -120,7 +122,7 @@ func runPRFixCommand(cmd *cobra.Command, _ []string, opts prFixOptions) error {
 	if prRef == "" {
 		selected, err := selectPRFixOpenPullRequest(cmd.InOrStdin(), cmd.OutOrStdout())
 		if err != nil {
-			return err
+			return withErrorClass(usage.ErrorClassExternalCommand, err)
 		}
 		prRef = selected
 	}
@@ -139,7 +141,7 @@ func runPRFixCommand(cmd *cobra.Command, _ []string, opts prFixOptions) error {
 func runPRFixDispatchPrompt(cmd *cobra.Command, opts prFixDispatchOptions) error {
 	prInput, found, err := loadPRFixDispatchInput(opts)
 	if err != nil {
-		return err
+		return withErrorClass(usage.ErrorClassExternalCommand, err)
 	}
 	if !found {
 		_, err := fmt.Fprintln(cmd.OutOrStdout(), "No actionable PR review comments found.")
@@ -148,7 +150,7 @@ func runPRFixDispatchPrompt(cmd *cobra.Command, opts prFixDispatchOptions) error
 
 	tasks, err := normalizeDispatchTasks(prInput.RawTasks)
 	if err != nil {
-		return err
+		return withErrorClass(usage.ErrorClassInvalidInput, err)
 	}
 
 	cwd, err := os.Getwd()
@@ -163,7 +165,7 @@ func runPRFixDispatchPrompt(cmd *cobra.Command, opts prFixDispatchOptions) error
 		opts.PRRef,
 	)
 	if err != nil {
-		return err
+		return withErrorClass(usage.ErrorClassExternalCommand, err)
 	}
 
 	prompt := buildDispatchPrompt(
@@ -9,6 +9,7 @@ import (
 	"github.com/spf13/cobra"
 
 	"github.com/jamesonstone/kit/v3/internal/releaseprompt"
+	"github.com/jamesonstone/kit/v3/internal/usage"
 )
 
 type prOrchestrateOptions struct {
@@ -83,17 +84,17 @@ func runPROrchestrate(cmd *cobra.Command, opts prOrchestrateOptions) error {
 	interactive := prOrchestrateInteractiveCheck(cmd.InOrStdin(), cmd.ErrOrStderr())
 	if len(opts.Repositories) == 0 && strings.TrimSpace(opts.Root) == "" {
 		if !interactive {
-			return fmt.Errorf("repository scope is required in noninteractive mode; use --repos or --root")
+			return withErrorClass(usage.ErrorClassInvalidInput, fmt.Errorf("repository scope is required in noninteractive mode; use --repos or --root"))
 		}
 		repositories, root, err := promptPROrchestrateScope(cmd.InOrStdin(), cmd.ErrOrStderr())
 		if err != nil {
-			return err
+			return withErrorClass(usage.ErrorClassInvalidInput, err)
 		}
 		opts.Repositories, opts.Root = repositories, root
 	}
 	config, err := prOrchestrateResolve(cmd.Context(), releasePromptInput(opts), prOrchestrateRunner)
 	if err != nil {
-		return err
+		return withErrorClass(usage.ErrorClassInvalidInput, err)
 	}
 	prompt, err := prOrchestrateRender(config)
 	if err != nil {
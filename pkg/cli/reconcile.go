@@ -9,6 +9,7 @@ import (
 
 	"github.com/jamesonstone/kit/v3/internal/config"
 	"github.com/jamesonstone/kit/v3/internal/feature"
+	"github.com/jamesonstone/kit/v3/internal/usage"
 	"github.com/spf13/cobra"
 	"golang.org/x/term"
 )
@@ -66,10 +67,10 @@ func init() {
 
 func runReconcile(cmd *cobra.Command, args []string) error {
 	if reconcileAll && len(args) > 0 {
-		return fmt.Errorf("--all cannot be used with a feature argument")
+		return withErrorClass(usage.ErrorClassInvalidInput, fmt.Errorf("--all cannot be used with a feature argument"))
 	}
 	if reconcileDiff && !reconcileDryRun {
-		return fmt.Errorf("--diff requires --dry-run")
+		return withErrorClass(usage.ErrorClassInvalidInput, fmt.Errorf("--diff requires --dry-run"))
 	}
 
 	projectRoot, err := config.FindProjectRoot()
@@ -79,14 +80,14 @@ func runReconcile(cmd *cobra.Command, args []string) error {
 
 	cfg, err := config.Load(projectRoot)
 	if err != nil {
-		return fmt.Errorf("failed to load config: %w", err)
+		return withErrorClass(usage.ErrorClassConfigInvalid, fmt.Errorf("failed to load config: %w", err))
 	}
 
 	var feat *feature.Feature
 	if len(args) == 1 {
 		feat, err = loadFeatureWithState(cfg.SpecsPath(projectRoot), cfg, args[0])
 		if err != nil {
-			return fmt.Errorf("failed to resolve feature: %w", err)
+			return withErrorClass(usage.ErrorClassNotFound, fmt.Errorf("failed to resolve feature: %w", err))
 		}
 	}
 
@@ -133,7 +134,7 @@ func runReconcile(cmd *cobra.Command, args []string) error {
 		if !reconcileDryRun {
 			cfg, err = config.Load(projectRoot)
 			if err != nil {
-				return fmt.Errorf("failed to reload config after included file refresh: %w", err)
+				return withErrorClass(usage.ErrorClassConfigInvalid, fmt.Errorf("failed to reload config after included file refresh: %w", err))
 			}
 		}
 	}
@@ -9,6 +9,7 @@ import (
 	"github.com/spf13/cobra"
 
 	"github.com/jamesonstone/kit/v3/internal/config"
+	"github.com/jamesonstone/kit/v3/internal/usage"
 )
 
 const statusKitManagedStateDisabled = "disabled"
@@ -51,7 +52,7 @@ func runRegistryStatus(cmd *cobra.Command, _ []string) error {
 	}
 	cfg, err := config.Load(projectRoot)
 	if err != nil {
-		return err
+		return withErrorClass(usage.ErrorClassConfigInvalid, err)
 	}
 	report, err := buildRegistryStatusReport(projectRoot, cfg)
 	if err != nil {
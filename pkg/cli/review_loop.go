@@ -6,6 +6,8 @@ import (
 	"strings"
 
 	"github.com/spf13/cobra"
+
+	"github.com/jamesonstone/kit/v3/internal/usage"
 )
 
 var (
@@ -17,7 +19,7 @@ var (
 
 func runReviewLoop(cmd *cobra.Command, opts reviewLoopOptions) error {
 	if strings.TrimSpace(opts.PRRef) == "" {
-		return fmt.Errorf("--pr is required")
+		return withErrorClass(usage.ErrorClassInvalidInput, fmt.Errorf("--pr is required"))
 	}
 	cwd, err := os.Getwd()
 	if err != nil {
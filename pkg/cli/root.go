@@ -8,6 +8,7 @@ import (
 	"time"
 
 	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
 	"golang.org/x/term"
 
 	"github.com/jamesonstone/kit/v3/internal/commandset"
@@ -27,11 +28,15 @@ var rootCmd = &cobra.Command{
 
 func init() {
 	rootCmd.SetVersionTemplate("kit version {{.Version}}\n")
+	rootCmd.SetFlagErrorFunc(func(_ *cobra.Command, err error) error {
+		return withErrorClass(usage.ErrorClassInvalidInput, err)
+	})
 	configureRootHelp()
 }
 
 func Execute() {
 	pruneCommandTree(rootCmd, "")
+	classifyArgErrors(rootCmd)
 	started := time.Now()
 	executed, err := rootCmd.ExecuteC()
 	recordUsage(executed, err, time.Since(started))
@@ -88,9 +93,11 @@ func recordUsage(executed *cobra.Command, commandErr error, elapsed time.Duratio
 	if !found {
 		projectRoot = ""
 	}
+	var flags []string
+	executed.Flags().Visit(func(flag *pflag.Flag) { flags = append(flags, flag.Name) })
 	_ = usage.Record(usage.RecordInput{
 		Command: path, Version: Version, ExitCode: exitCode, Elapsed: elapsed,
-		ProjectRoot: projectRoot,
+		ProjectRoot: projectRoot, Flags: flags, ErrorClass: usageErrorClass(commandErr),
 		Interactive: term.IsTerminal(int(os.Stdin.Fd())) && term.IsTerminal(int(os.Stdout.Fd())),
 	})
 }
@@ -12,6 +12,7 @@ import (
 	"github.com/jamesonstone/kit/v3/internal/config"
 	"github.com/jamesonstone/kit/v3/internal/document"
 	"github.com/jamesonstone/kit/v3/internal/templates"
+	"github.com/jamesonstone/kit/v3/internal/usage"
 )
 
 const (
@@ -98,6 +99,7 @@ type rulesetMetadata struct {
 	Description       string   `yaml:"description"`
 	Status            string   `yaml:"status"`
 	AppliesTo         []string `yaml:"applies_to"`
+	Paths             []string `yaml:"paths,omitempty"`
 	ReadPolicyDefault string   `yaml:"read_policy_default"`
 	RegistryScope     string   `yaml:"registry_scope"`
 }
@@ -147,10 +149,10 @@ func runRulesAdd(cmd *cobra.Command, args []string) error {
 
 	readPolicyDefault, policyExplicit, err := selectedRulesetReadPolicy()
 	if err != nil {
-		return err
+		return withErrorClass(usage.ErrorClassInvalidInput, err)
 	}
 	if rulesAddInline && (rulesAddUseVim || rulesAddEditor != "") {
-		return fmt.Errorf("--inline cannot be used with --vim or --editor")
+		return withErrorClass(usage.ErrorClassInvalidInput, fmt.Errorf("--inline cannot be used with --vim or --editor"))
 	}
 
 	if len(args) == 0 {
@@ -161,15 +163,15 @@ func runRulesAdd(cmd *cobra.Command, args []string) error {
 	}
 
 	if rulesAddCustom {
-		return fmt.Errorf("--custom requires interactive `kit rules add` with no slug")
+		return withErrorClass(usage.ErrorClassInvalidInput, fmt.Errorf("--custom requires interactive `kit rules add` with no slug"))
 	}
 	if rulesAddCopy || rulesAddOutputOnly || rulesAddUseVim || rulesAddEditor != "" || rulesAddInline {
-		return fmt.Errorf("--copy, --output-only, --vim, --editor, and --inline require interactive `kit rules add` with no slug")
+		return withErrorClass(usage.ErrorClassInvalidInput, fmt.Errorf("--copy, --output-only, --vim, --editor, and --inline require interactive `kit rules add` with no slug"))
 	}
 
 	slug := strings.TrimSpace(args[0])
 	if err := validateRulesetSlug(slug); err != nil {
-		return err
+		return withErrorClass(usage.ErrorClassInvalidInput, err)
 	}
 
 	input := rulesetAddInput{
@@ -222,7 +224,7 @@ func runRulesAddInteractive(cmd *cobra.Command, projectRoot, readPolicyDefault s
 func createRuleset(projectRoot string, input rulesetAddInput) (string, error) {
 	path := rulesetPath(projectRoot, input.Slug)
 	if document.Exists(path) && !rulesAddForce {
-		return "", fmt.Errorf("ruleset %q already exists at %s; use --force to overwrite", input.Slug, rulesetTarget(input.Slug))
+		return "", withErrorClass(usage.ErrorClassInvalidInput, fmt.Errorf("ruleset %q already exists at %s; use --force to overwrite", input.Slug, rulesetTarget(input.Slug)))
 	}
 	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
 		return "", fmt.Errorf("failed to create ruleset directory: %w", err)
@@ -254,7 +256,7 @@ func runRulesList(cmd *cobra.Command, args []string) error {
 	}
 	cfg, err := config.Load(projectRoot)
 	if err != nil {
-		return fmt.Errorf("failed to load config: %w", err)
+		return withErrorClass(usage.ErrorClassConfigInvalid, fmt.Errorf("failed to load config: %w", err))
 	}
 	return printRulesetList(cmd.OutOrStdout(), projectRoot, cfg, rulesets)
 }
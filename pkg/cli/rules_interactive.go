@@ -12,6 +12,7 @@ import (
 	"github.com/jamesonstone/kit/v3/internal/config"
 	"github.com/jamesonstone/kit/v3/internal/document"
 	"github.com/jamesonstone/kit/v3/internal/feature"
+	"github.com/jamesonstone/kit/v3/internal/usage"
 )
 
 func runRulesView(cmd *cobra.Command, args []string) error {
@@ -21,7 +22,7 @@ func runRulesView(cmd *cobra.Command, args []string) error {
 	}
 	slug := strings.TrimSpace(args[0])
 	if err := validateRulesetSlug(slug); err != nil {
-		return err
+		return withErrorClass(usage.ErrorClassInvalidInput, err)
 	}
 	content, source, err := loadRulesetViewContent(cmd.Context(), projectRoot, slug)
 	if err != nil {
@@ -38,25 +39,25 @@ func runRulesLink(cmd *cobra.Command, args []string) error {
 	}
 	cfg, err := config.Load(projectRoot)
 	if err != nil {
-		return fmt.Errorf("failed to load config: %w", err)
+		return withErrorClass(usage.ErrorClassConfigInvalid, fmt.Errorf("failed to load config: %w", err))
 	}
 	feat, err := feature.Resolve(cfg.SpecsPath(projectRoot), args[0])
 	if err != nil {
-		return fmt.Errorf("feature %q not found", args[0])
+		return withErrorClass(usage.ErrorClassNotFound, fmt.Errorf("feature %q not found", args[0]))
 	}
 
 	slug := strings.TrimSpace(args[1])
 	if err := validateRulesetSlug(slug); err != nil {
-		return err
+		return withErrorClass(usage.ErrorClassInvalidInput, err)
 	}
 	readPolicy := strings.TrimSpace(rulesLinkReadPolicy)
 	if readPolicy != document.ReferenceReadPolicyMust && readPolicy != document.ReferenceReadPolicyConditional {
-		return fmt.Errorf("--read-policy must be one of: must, conditional")
+		return withErrorClass(usage.ErrorClassInvalidInput, fmt.Errorf("--read-policy must be one of: must, conditional"))
 	}
 
 	ruleset, err := loadRuleset(projectRoot, slug)
 	if err != nil {
-		return err
+		return withErrorClass(usage.ErrorClassNotFound, err)
 	}
 	if issues := validateRulesetDocument(ruleset, slug); len(issues) > 0 {
 		return fmt.Errorf("ruleset %q is invalid: %s", slug, strings.Join(issues, "; "))
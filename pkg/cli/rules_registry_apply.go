@@ -10,13 +10,14 @@ import (
 
 	"github.com/jamesonstone/kit/v3/internal/config"
 	"github.com/jamesonstone/kit/v3/internal/document"
+	"github.com/jamesonstone/kit/v3/internal/usage"
 )
 
 func applyRegistryRulesetSelection(projectRoot string, entries []registrySelectorEntry) (registrySelectorSummary, error) {
 	var summary registrySelectorSummary
 	cfg, err := config.Load(projectRoot)
 	if err != nil {
-		return summary, fmt.Errorf("failed to load config: %w", err)
+		return summary, withErrorClass(usage.ErrorClassConfigInvalid, fmt.Errorf("failed to load config: %w", err))
 	}
 	configChanged := false
 	for _, entry := range entries {
@@ -127,7 +128,7 @@ func loadRulesetViewContent(ctx context.Context, projectRoot, slug string) (stri
 		}
 		parsed := parseRuleset(string(content), localPath)
 		if issues := validateRulesetDocument(parsed, slug); len(issues) > 0 {
-			return "", "", fmt.Errorf("local ruleset %s is invalid: %s", rulesetTarget(slug), strings.Join(issues, "; "))
+			return "", "", withErrorClass(usage.ErrorClassCheckFailed, fmt.Errorf("local ruleset %s is invalid: %s", rulesetTarget(slug), strings.Join(issues, "; ")))
 		}
 		return string(content), rulesetTarget(slug), nil
 	}
@@ -139,12 +140,12 @@ func loadRulesetViewContent(ctx context.Context, projectRoot, slug string) (stri
 	defer cancel()
 	registry, err := rulesetRegistryFetcher(ctx)
 	if err != nil {
-		return "", "", err
+		return "", "", withErrorClass(usage.ErrorClassNetwork, err)
 	}
 	for _, item := range projectRulesetRegistry(registry) {
 		if item.Slug == slug {
 			return item.Content, rulesetRegistryRulesetURL(slug), nil
 		}
 	}
-	return "", "", fmt.Errorf("ruleset %q was not found locally or in the Kit registry", slug)
+	return "", "", withErrorClass(usage.ErrorClassNotFound, fmt.Errorf("ruleset %q was not found locally or in the Kit registry", slug))
 }
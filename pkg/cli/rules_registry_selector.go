@@ -13,6 +13,7 @@ import (
 
 	"github.com/jamesonstone/kit/v3/internal/config"
 	"github.com/jamesonstone/kit/v3/internal/document"
+	"github.com/jamesonstone/kit/v3/internal/usage"
 )
 
 func buildRegistrySelectorEntries(projectRoot string, registry []registryRuleset) ([]registrySelectorEntry, error) {
@@ -21,7 +22,7 @@ func buildRegistrySelectorEntries(projectRoot string, registry []registryRuleset
 	})
 	cfg, err := config.Load(projectRoot)
 	if err != nil {
-		return nil, fmt.Errorf("failed to load config: %w", err)
+		return nil, withErrorClass(usage.ErrorClassConfigInvalid, fmt.Errorf("failed to load config: %w", err))
 	}
 
 	entries := make([]registrySelectorEntry, 0, len(registry))
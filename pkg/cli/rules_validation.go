@@ -6,6 +6,7 @@ import (
 	"path/filepath"
 	"strings"
 
+	contextcontract "github.com/jamesonstone/kit/v3/internal/context"
 	"github.com/jamesonstone/kit/v3/internal/document"
 	"github.com/jamesonstone/kit/v3/internal/feature"
 )
@@ -37,6 +38,11 @@ func validateRulesetDocument(ruleset rulesetDocument, expectedSlug string) []str
 			issues = append(issues, fmt.Sprintf("front matter applies_to entry %q is invalid", appliesTo))
 		}
 	}
+	for _, glob := range ruleset.Metadata.Paths {
+		if err := contextcontract.ValidatePathGlob(glob); err != nil {
+			issues = append(issues, fmt.Sprintf("front matter paths entry is invalid: %v", err))
+		}
+	}
 	if ruleset.Metadata.ReadPolicyDefault == "" || !validRulesetReadPolicy(ruleset.Metadata.ReadPolicyDefault) {
 		issues = append(issues, "front matter read_policy_default must be must, conditional, evidence, or skip")
 	}
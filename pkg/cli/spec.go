@@ -12,6 +12,7 @@ import (
 	"github.com/jamesonstone/kit/v3/internal/feature"
 	"github.com/jamesonstone/kit/v3/internal/rollup"
 	"github.com/jamesonstone/kit/v3/internal/templates"
+	"github.com/jamesonstone/kit/v3/internal/usage"
 )
 
 var specCmd = &cobra.Command{
@@ -36,7 +37,7 @@ func init() {
 
 func runNativePlanSpec(cmd *cobra.Command, args []string) error {
 	if len(args) == 0 {
-		return fmt.Errorf("feature name required: use `kit spec <feature>`")
+		return withErrorClass(usage.ErrorClassInvalidInput, fmt.Errorf("feature name required: use `kit spec <feature>`"))
 	}
 
 	projectRoot, err := config.FindProjectRoot()
@@ -45,7 +46,7 @@ func runNativePlanSpec(cmd *cobra.Command, args []string) error {
 	}
 	cfg, err := config.Load(projectRoot)
 	if err != nil {
-		return err
+		return withErrorClass(usage.ErrorClassConfigInvalid, err)
 	}
 	specsDir := cfg.SpecsPath(projectRoot)
 	if err := os.MkdirAll(specsDir, 0o755); err != nil {
@@ -9,6 +9,7 @@ import (
 
 	"github.com/jamesonstone/kit/v3/internal/config"
 	"github.com/jamesonstone/kit/v3/internal/feature"
+	"github.com/jamesonstone/kit/v3/internal/usage"
 )
 
 var statusCmd = &cobra.Command{
@@ -64,7 +65,7 @@ func runStatus(cmd *cobra.Command, args []string) error {
 
 	cfg, err := config.Load(projectRoot)
 	if err != nil {
-		return err
+		return withErrorClass(usage.ErrorClassConfigInvalid, err)
 	}
 	kitManaged, err := buildStatusKitManagedSummary(projectRoot, cfg)
 	if err != nil {
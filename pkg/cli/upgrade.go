@@ -6,6 +6,8 @@ import (
 	"runtime"
 
 	"github.com/spf13/cobra"
+
+	"github.com/jamesonstone/kit/v3/internal/usage"
 )
 
 var upgradeYes bool
@@ -30,7 +32,7 @@ func runUpgrade(cmd *cobra.Command, args []string) error {
 	current := currentVersion()
 	release, err := latestStableRelease(current)
 	if err != nil {
-		return err
+		return withErrorClass(usage.ErrorClassNetwork, err)
 	}
 
 	latest := displayVersion(release.TagName)
@@ -60,32 +62,32 @@ func runUpgrade(cmd *cobra.Command, args []string) error {
 
 	assetName, err := selectAssetName(release.TagName, runtime.GOOS, runtime.GOARCH)
 	if err != nil {
-		return fmt.Errorf("%w. install manually with `%s`", err, manualInstallHint)
+		return withErrorClass(usage.ErrorClassNotFound, fmt.Errorf("%w. install manually with `%s`", err, manualInstallHint))
 	}
 
 	assetURL, ok := findAssetURL(release.Assets, assetName)
 	if !ok {
-		return fmt.Errorf(
+		return withErrorClass(usage.ErrorClassNotFound, fmt.Errorf(
 			"no release asset for %s/%s: expected %s. install manually with `%s`",
 			runtime.GOOS,
 			runtime.GOARCH,
 			assetName,
 			manualInstallHint,
-		)
+		))
 	}
 
 	checksumsURL, ok := findAssetURL(release.Assets, "checksums.txt")
 	if !ok {
-		return fmt.Errorf("release %s is missing checksums.txt", latest)
+		return withErrorClass(usage.ErrorClassNotFound, fmt.Errorf("release %s is missing checksums.txt", latest))
 	}
 
 	archiveBytes, err := downloadBytes(assetURL, current)
 	if err != nil {
-		return err
+		return withErrorClass(usage.ErrorClassNetwork, err)
 	}
 	checksumBytes, err := downloadBytes(checksumsURL, current)
 	if err != nil {
-		return err
+		return withErrorClass(usage.ErrorClassNetwork, err)
 	}
 
 	checksums, err := parseChecksums(string(checksumBytes))
@@ -94,16 +96,16 @@ func runUpgrade(cmd *cobra.Command, args []string) error {
 	}
 	expectedHash, ok := checksums[assetName]
 	if !ok {
-		return fmt.Errorf("checksums.txt is missing %s", assetName)
+		return withErrorClass(usage.ErrorClassCheckFailed, fmt.Errorf("checksums.txt is missing %s", assetName))
 	}
 	actualHash := fmt.Sprintf("%x", sha256.Sum256(archiveBytes))
 	if expectedHash != actualHash {
-		return fmt.Errorf(
+		return withErrorClass(usage.ErrorClassCheckFailed, fmt.Errorf(
 			"checksum mismatch for %s: expected %s, got %s",
 			assetName,
 			expectedHash,
 			actualHash,
-		)
+		))
 	}
 
 	newBinary, err := extractBinary(archiveBytes, runtime.GOOS)
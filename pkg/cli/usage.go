@@ -1,11 +1,9 @@
 package cli
 
 import (
-	"bufio"
 	"encoding/json"
 	"errors"
 	"fmt"
-	"io"
 	"sort"
 	"strconv"
 	"strings"
@@ -20,14 +18,10 @@ import (
 
 type usageReportOptions struct {
 	since, command, project string
+	inputs                  []string
 	jsonOutput              bool
 }
 
-type usageClearOptions struct {
-	all, yes, jsonOutput bool
-	command, project     string
-}
-
 func init() {
 	rootCmd.AddCommand(newUsageCommand())
 }
@@ -42,7 +36,7 @@ func newUsageCommand() *cobra.Command {
 		},
 	}
 	addUsageReportFlags(cmd, reportOpts)
-	cmd.AddCommand(newUsageReportCommand(), newUsageStatusCommand(), newUsageRefreshCommand())
+	cmd.AddCommand(newUsageReportCommand(), newUsageStatusCommand(), newUsageRefreshCommand(), newUsageExportCommand(), newUsageAdviseCommand())
 	cmd.AddCommand(newUsageClearCommand(), newUsageToggleCommand(true), newUsageToggleCommand(false))
 	return cmd
 }
@@ -65,6 +59,7 @@ func addUsageReportFlags(cmd *cobra.Command, opts *usageReportOptions) {
 	cmd.Flags().StringVar(&opts.since, "since", opts.since, "report window such as 24h, 30d, or 90d")
 	cmd.Flags().StringVar(&opts.command, "command", "", "filter by normalized command path")
 	cmd.Flags().StringVar(&opts.project, "project", "", "filter by anonymized project ID or current")
+	cmd.Flags().StringArrayVar(&opts.inputs, "input", nil, "aggregate a kit usage export file instead of local storage; repeatable")
 	cmd.Flags().BoolVar(&opts.jsonOutput, "json", false, "emit machine-readable JSON")
 }
 
@@ -74,6 +69,9 @@ func runUsageReport(cmd *cobra.Command, opts *usageReportOptions) error {
 		return err
 	}
 	filter := usage.Filter{Since: time.Now().UTC().Add(-duration), Command: strings.TrimSpace(opts.command)}
+	if len(opts.inputs) > 0 && opts.project != "" {
+		return fmt.Errorf("--project cannot be combined with --input; exported project IDs are salted per export")
+	}
 	if opts.project != "" {
 		filter.ProjectID, err = resolveUsageProjectFilter(opts.project)
 		if err != nil {
@@ -83,7 +81,12 @@ func runUsageReport(cmd *cobra.Command, opts *usageReportOptions) error {
 			filter.ProjectID = "no-recorded-project"
 		}
 	}
-	report, err := usage.BuildReport(filter)
+	var report usage.Report
+	if len(opts.inputs) > 0 {
+		report, err = usage.BuildReportFromExports(filter, opts.inputs)
+	} else {
+		report, err = usage.BuildReport(filter)
+	}
 	if err != nil {
 		return err
 	}
@@ -148,61 +151,6 @@ func newUsageRefreshCommand() *cobra.Command {
 	return cmd
 }
 
-func newUsageClearCommand() *cobra.Command {
-	opts := &usageClearOptions{}
-	cmd := &cobra.Command{
-		Use:   "clear",
-		Short: "Remove selected local usage history",
-		Args:  cobra.NoArgs,
-		RunE:  func(cmd *cobra.Command, _ []string) error { return runUsageClear(cmd, opts) },
-	}
-	cmd.Flags().BoolVar(&opts.all, "all", false, "remove all usage history")
-	cmd.Flags().StringVar(&opts.command, "command", "", "remove history for one normalized command path")
-	cmd.Flags().StringVar(&opts.project, "project", "", "remove history for an anonymized project ID or current")
-	cmd.Flags().BoolVar(&opts.yes, "yes", false, "confirm removal without prompting")
-	cmd.Flags().BoolVar(&opts.jsonOutput, "json", false, "emit machine-readable JSON")
-	return cmd
-}
-
-func runUsageClear(cmd *cobra.Command, opts *usageClearOptions) error {
-	if opts.all && (opts.command != "" || opts.project != "") {
-		return fmt.Errorf("--all cannot be combined with --command or --project")
-	}
-	filter := usage.Filter{Command: strings.TrimSpace(opts.command)}
-	var err error
-	if opts.project != "" {
-		filter.ProjectID, err = resolveUsageProjectFilter(opts.project)
-		if err != nil {
-			return err
-		}
-	}
-	if !opts.all && filter.Command == "" && filter.ProjectID == "" {
-		filter.ProjectID, err = resolveUsageProjectFilter("current")
-		if err != nil {
-			return err
-		}
-		if filter.ProjectID == "" {
-			return fmt.Errorf("no usage identity exists for the current project")
-		}
-	}
-	if !opts.yes {
-		confirmed, err := confirmUsageClear(cmd.InOrStdin(), cmd.ErrOrStderr())
-		if err != nil || !confirmed {
-			return err
-		}
-	}
-	removed, err := usage.Clear(filter, opts.all)
-	if err != nil {
-		return err
-	}
-	result := map[string]any{"schema_version": usage.SchemaVersion, "removed_events": removed}
-	if opts.jsonOutput {
-		return writeUsageJSON(cmd, result)
-	}
-	_, err = fmt.Fprintf(cmd.OutOrStdout(), "Removed %d usage events.\n", removed)
-	return err
-}
-
 func newUsageToggleCommand(enabled bool) *cobra.Command {
 	name := "disable"
 	if enabled {
@@ -273,24 +221,6 @@ func resolveUsageProjectFilter(value string) (string, error) {
 	return usage.CurrentProjectID(root)
 }
 
-func confirmUsageClear(in io.Reader, out io.Writer) (bool, error) {
-	if _, err := fmt.Fprint(out, "Remove selected Kit usage history? [y/N]: "); err != nil {
-		return false, err
-	}
-	line, err := bufio.NewReader(in).ReadString('\n')
-	if err != nil && !errors.Is(err, io.EOF) {
-		return false, err
-	}
-	switch strings.ToLower(strings.TrimSpace(line)) {
-	case "y", "yes":
-		return true, nil
-	case "", "n", "no":
-		return false, nil
-	default:
-		return false, fmt.Errorf("answer must be yes or no")
-	}
-}
-
 func writeUsageJSON(cmd *cobra.Command, value any) error {
 	encoder := json.NewEncoder(cmd.OutOrStdout())
 	encoder.SetIndent("", "  ")
@@ -0,0 +1,109 @@
+package cli
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/jamesonstone/kit/v3/internal/commandset"
+	"github.com/jamesonstone/kit/v3/internal/usage"
+)
+
+type usageAdviseOptions struct {
+	since       string
+	minCalls    int
+	failureRate float64
+	jsonOutput  bool
+}
+
+func newUsageAdviseCommand() *cobra.Command {
+	opts := &usageAdviseOptions{since: "90d", minCalls: 5, failureRate: 0.5}
+	cmd := &cobra.Command{
+		Use:   "advise",
+		Short: "Rank unused or mostly failing commands and flags against the capabilities catalog",
+		Args:  cobra.NoArgs,
+		RunE:  func(cmd *cobra.Command, _ []string) error { return runUsageAdvise(cmd, opts) },
+	}
+	cmd.Flags().StringVar(&opts.since, "since", opts.since, "advice window such as 30d or 90d")
+	cmd.Flags().IntVar(&opts.minCalls, "min-calls", opts.minCalls, "calls needed before failure or flag advice is given")
+	cmd.Flags().Float64Var(&opts.failureRate, "failure-rate", opts.failureRate, "failure rate, from 0 to 1, at which a command or flag is mostly failing")
+	cmd.Flags().BoolVar(&opts.jsonOutput, "json", false, "emit machine-readable JSON")
+	return cmd
+}
+
+func runUsageAdvise(cmd *cobra.Command, opts *usageAdviseOptions) error {
+	duration, err := parseUsageDuration(opts.since)
+	if err != nil {
+		return err
+	}
+	if opts.minCalls < 1 {
+		return fmt.Errorf("--min-calls must be at least 1")
+	}
+	if opts.failureRate <= 0 || opts.failureRate > 1 {
+		return fmt.Errorf("--failure-rate must be greater than 0 and at most 1")
+	}
+	report, err := usage.BuildReport(usage.Filter{Since: time.Now().UTC().Add(-duration)})
+	if err != nil {
+		return err
+	}
+	advice := usage.Advise(report, usageAdviceCatalog(), usage.AdviseOptions{MinCalls: opts.minCalls, FailureRate: opts.failureRate})
+	if opts.jsonOutput {
+		return writeUsageJSON(cmd, advice)
+	}
+	return renderUsageAdvice(cmd, advice)
+}
+
+// usageAdviceCatalog lists the capability records of every recorded command.
+// Usage commands never record themselves, so they are never advised on.
+func usageAdviceCatalog() []usage.CatalogCommand {
+	recorded := map[string]bool{}
+	for _, path := range commandset.TelemetryPaths() {
+		recorded[path] = true
+	}
+	var catalog []usage.CatalogCommand
+	for _, record := range capabilityCatalog() {
+		if !recorded[record.Command] {
+			continue
+		}
+		entry := usage.CatalogCommand{Command: record.Command, Deprecated: record.Deprecated, DeprecationNote: record.DeprecationNote}
+		for _, flag := range record.DetailedFlagBehavior {
+			entry.Flags = append(entry.Flags, strings.TrimLeft(flag.Name, "-"))
+		}
+		catalog = append(catalog, entry)
+	}
+	return catalog
+}
+
+func renderUsageAdvice(cmd *cobra.Command, advice usage.AdviceReport) error {
+	out := cmd.OutOrStdout()
+	if _, err := fmt.Fprintf(out, "Usage advice since %s: %d calls; failing means >= %.0f%% of at least %d calls\n", advice.Since.Format("2006-01-02"), advice.TotalCalls, 100*advice.FailureRateThreshold, advice.MinCalls); err != nil {
+		return err
+	}
+	if len(advice.Advice) == 0 {
+		if _, err := fmt.Fprintln(out, "No advice: every catalog command and flag is in use without a high failure rate."); err != nil {
+			return err
+		}
+	}
+	for _, item := range advice.Advice {
+		target := item.Command
+		if item.Flag != "" {
+			target += " --" + item.Flag
+		}
+		if _, err := fmt.Fprintf(out, "%2d. [%d] %s %s: %s\n", item.Rank, item.Score, item.Kind, target, item.Recommendation); err != nil {
+			return err
+		}
+		if item.DeprecationNote != "" {
+			if _, err := fmt.Fprintf(out, "    Deprecation: %s\n", item.DeprecationNote); err != nil {
+				return err
+			}
+		}
+	}
+	for _, diagnostic := range advice.Diagnostics {
+		if _, err := fmt.Fprintf(out, "%s: %s\n", diagnostic.Level, diagnostic.Message); err != nil {
+			return err
+		}
+	}
+	return nil
+}
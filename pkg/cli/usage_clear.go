@@ -0,0 +1,91 @@
+package cli
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/jamesonstone/kit/v3/internal/usage"
+)
+
+type usageClearOptions struct {
+	all, yes, jsonOutput bool
+	command, project     string
+}
+
+func newUsageClearCommand() *cobra.Command {
+	opts := &usageClearOptions{}
+	cmd := &cobra.Command{
+		Use:   "clear",
+		Short: "Remove selected local usage history",
+		Args:  cobra.NoArgs,
+		RunE:  func(cmd *cobra.Command, _ []string) error { return runUsageClear(cmd, opts) },
+	}
+	cmd.Flags().BoolVar(&opts.all, "all", false, "remove all usage history")
+	cmd.Flags().StringVar(&opts.command, "command", "", "remove history for one normalized command path")
+	cmd.Flags().StringVar(&opts.project, "project", "", "remove history for an anonymized project ID or current")
+	cmd.Flags().BoolVar(&opts.yes, "yes", false, "confirm removal without prompting")
+	cmd.Flags().BoolVar(&opts.jsonOutput, "json", false, "emit machine-readable JSON")
+	return cmd
+}
+
+func runUsageClear(cmd *cobra.Command, opts *usageClearOptions) error {
+	if opts.all && (opts.command != "" || opts.project != "") {
+		return fmt.Errorf("--all cannot be combined with --command or --project")
+	}
+	filter := usage.Filter{Command: strings.TrimSpace(opts.command)}
+	var err error
+	if opts.project != "" {
+		filter.ProjectID, err = resolveUsageProjectFilter(opts.project)
+		if err != nil {
+			return err
+		}
+	}
+	if !opts.all && filter.Command == "" && filter.ProjectID == "" {
+		filter.ProjectID, err = resolveUsageProjectFilter("current")
+		if err != nil {
+			return err
+		}
+		if filter.ProjectID == "" {
+			return fmt.Errorf("no usage identity exists for the current project")
+		}
+	}
+	if !opts.yes {
+		confirmed, err := confirmUsageClear(cmd.InOrStdin(), cmd.ErrOrStderr())
+		if err != nil || !confirmed {
+			return err
+		}
+	}
+	removed, err := usage.Clear(filter, opts.all)
+	if err != nil {
+		return err
+	}
+	result := map[string]any{"schema_version": usage.SchemaVersion, "removed_events": removed}
+	if opts.jsonOutput {
+		return writeUsageJSON(cmd, result)
+	}
+	_, err = fmt.Fprintf(cmd.OutOrStdout(), "Removed %d usage events.\n", removed)
+	return err
+}
+
+func confirmUsageClear(in io.Reader, out io.Writer) (bool, error) {
+	if _, err := fmt.Fprint(out, "Remove selected Kit usage history? [y/N]: "); err != nil {
+		return false, err
+	}
+	line, err := bufio.NewReader(in).ReadString('\n')
+	if err != nil && !errors.Is(err, io.EOF) {
+		return false, err
+	}
+	switch strings.ToLower(strings.TrimSpace(line)) {
+	case "y", "yes":
+		return true, nil
+	case "", "n", "no":
+		return false, nil
+	default:
+		return false, fmt.Errorf("answer must be yes or no")
+	}
+}
@@ -0,0 +1,86 @@
+package cli
+
+import (
+	"context"
+	"errors"
+	"os"
+	"os/exec"
+
+	"github.com/spf13/cobra"
+
+	"github.com/jamesonstone/kit/v3/internal/config"
+	"github.com/jamesonstone/kit/v3/internal/usage"
+)
+
+// classifiedError tags a command error with a usage error class. Only the
+// class is recorded; the wrapped message is still shown to the user.
+type classifiedError struct {
+	err   error
+	class usage.ErrorClass
+}
+
+func (e *classifiedError) Error() string {
+	if e == nil || e.err == nil {
+		return ""
+	}
+	return e.err.Error()
+}
+
+func (e *classifiedError) Unwrap() error {
+	if e == nil {
+		return nil
+	}
+	return e.err
+}
+
+func withErrorClass(class usage.ErrorClass, err error) error {
+	if err == nil {
+		return nil
+	}
+	return &classifiedError{err: err, class: class}
+}
+
+// usageErrorClass reports timeouts and interruptions first, since they can
+// surface through any return, then prefers the class attached at the error
+// return and falls back to well-known causes before reporting the failure as
+// unclassified.
+func usageErrorClass(err error) usage.ErrorClass {
+	if err == nil {
+		return ""
+	}
+	var classified *classifiedError
+	var exitErr *exec.ExitError
+	var registryErr *initRefreshRegistryError
+	switch {
+	case errors.Is(err, context.DeadlineExceeded):
+		return usage.ErrorClassTimeout
+	case errors.Is(err, context.Canceled):
+		return usage.ErrorClassInterrupted
+	case errors.As(err, &classified):
+		return classified.class
+	case errors.Is(err, config.ErrProjectNotFound):
+		return usage.ErrorClassNoProject
+	case errors.Is(err, os.ErrPermission):
+		return usage.ErrorClassPermission
+	case errors.Is(err, os.ErrNotExist):
+		return usage.ErrorClassNotFound
+	case errors.As(err, &exitErr), errors.Is(err, exec.ErrNotFound):
+		return usage.ErrorClassExternalCommand
+	case errors.As(err, &registryErr):
+		return usage.ErrorClassNetwork
+	}
+	return usage.ErrorClassUnclassified
+}
+
+// classifyArgErrors tags positional argument validation failures in the
+// command tree as invalid input, as SetFlagErrorFunc does for flag errors.
+func classifyArgErrors(cmd *cobra.Command) {
+	if validate := cmd.Args; validate != nil {
+		cmd.Args = func(cmd *cobra.Command, args []string) error {
+			return withErrorClass(usage.ErrorClassInvalidInput, validate(cmd, args))
+		}
+	}
+	for _, child := range cmd.Commands() {
+		classifyArgErrors(child)
+	}
+}
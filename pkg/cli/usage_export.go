@@ -0,0 +1,120 @@
+package cli
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/jamesonstone/kit/v3/internal/usage"
+)
+
+type usageExportOptions struct {
+	out, since, coarsen string
+	jsonOutput          bool
+}
+
+type usageExportResult struct {
+	SchemaVersion string `json:"schema_version"`
+	Path          string `json:"path"`
+	Events        int    `json:"events"`
+	Coarsen       string `json:"coarsen"`
+}
+
+func newUsageExportCommand() *cobra.Command {
+	opts := &usageExportOptions{coarsen: usage.CoarsenNone}
+	cmd := &cobra.Command{
+		Use:   "export",
+		Short: "Write an anonymized usage export for offline pooling",
+		Args:  cobra.NoArgs,
+		RunE:  func(cmd *cobra.Command, _ []string) error { return runUsageExport(cmd, opts) },
+	}
+	cmd.Flags().StringVar(&opts.out, "out", "", "export file to write, such as usage.jsonl")
+	cmd.Flags().StringVar(&opts.since, "since", "", "export only this window, such as 30d; defaults to all retained events")
+	cmd.Flags().StringVar(&opts.coarsen, "coarsen", opts.coarsen, "truncate timestamps to none, hour, or day")
+	cmd.Flags().BoolVar(&opts.jsonOutput, "json", false, "emit machine-readable JSON")
+	return cmd
+}
+
+func runUsageExport(cmd *cobra.Command, opts *usageExportOptions) error {
+	out := strings.TrimSpace(opts.out)
+	if out == "" {
+		return fmt.Errorf("--out is required")
+	}
+	if err := usage.ValidateCoarsen(opts.coarsen); err != nil {
+		return err
+	}
+	exportOpts := usage.ExportOptions{Coarsen: opts.coarsen}
+	if strings.TrimSpace(opts.since) != "" {
+		duration, err := parseUsageDuration(opts.since)
+		if err != nil {
+			return err
+		}
+		exportOpts.Since = time.Now().UTC().Add(-duration)
+	}
+	if err := refuseUsageStorePath(out); err != nil {
+		return err
+	}
+	header, err := writeUsageExport(out, exportOpts)
+	if err != nil {
+		return err
+	}
+	result := usageExportResult{SchemaVersion: usage.ExportSchemaVersion, Path: out, Events: header.Events, Coarsen: header.Coarsen}
+	if opts.jsonOutput {
+		return writeUsageJSON(cmd, result)
+	}
+	_, err = fmt.Fprintf(cmd.OutOrStdout(), "Exported %d usage events to %s (timestamps coarsened: %s).\n", result.Events, out, result.Coarsen)
+	return err
+}
+
+// writeUsageExport writes the export to a temporary file beside out and
+// renames it into place only once the export succeeds, so a failed export
+// leaves any existing file at out untouched.
+func writeUsageExport(out string, opts usage.ExportOptions) (header usage.ExportHeader, resultErr error) {
+	temp, err := os.CreateTemp(filepath.Dir(out), ".kit-usage-export-*.tmp")
+	if err != nil {
+		return header, err
+	}
+	tempPath := temp.Name()
+	closed := false
+	defer func() {
+		if !closed {
+			if closeErr := temp.Close(); closeErr != nil && resultErr == nil {
+				resultErr = closeErr
+			}
+		}
+		_ = os.Remove(tempPath)
+	}()
+	if err := temp.Chmod(0o600); err != nil {
+		return header, err
+	}
+	header, err = usage.Export(temp, opts)
+	if err != nil {
+		return header, err
+	}
+	closed = true
+	if err := temp.Close(); err != nil {
+		return header, err
+	}
+	return header, os.Rename(tempPath, out)
+}
+
+// refuseUsageStorePath keeps exports out of the local usage store so they are
+// never read back as local shards.
+func refuseUsageStorePath(path string) error {
+	dir, err := usage.Directory()
+	if err != nil {
+		return err
+	}
+	absolute, err := filepath.Abs(path)
+	if err != nil {
+		return err
+	}
+	if rel, err := filepath.Rel(dir, absolute); err == nil && rel != ".." && !strings.HasPrefix(rel, ".."+string(filepath.Separator)) {
+		return fmt.Errorf("usage exports must be written outside the local usage store %s", dir)
+	}
+	return nil
+}
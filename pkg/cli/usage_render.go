@@ -15,9 +15,42 @@ func renderUsageReport(cmd *cobra.Command, report usage.Report) error {
 		return err
 	}
 	for _, item := range report.Commands {
-		if _, err := fmt.Fprintf(out, "  %-24s %5d calls  %4d failed\n", item.Command, item.Calls, item.Failures); err != nil {
+		if _, err := fmt.Fprintf(out, "  %-24s %5d calls  %4d failed  %s\n", item.Command, item.Calls, item.Failures, formatUsageLatency(item.Latency)); err != nil {
 			return err
 		}
+		if len(item.FailureClasses) > 0 {
+			if _, err := fmt.Fprintf(out, "    failure classes: %s\n", formatUsageFailureClasses(item.FailureClasses)); err != nil {
+				return err
+			}
+		}
+		if len(item.Versions) < 2 {
+			continue
+		}
+		for _, version := range item.Versions {
+			if _, err := fmt.Fprintf(out, "    %-22s %5d calls  %4d failed  %s\n", version.Version, version.Calls, version.Failures, formatUsageLatency(version.Latency)); err != nil {
+				return err
+			}
+		}
+	}
+	if len(report.Versions) > 0 {
+		if _, err := fmt.Fprintln(out, "By version:"); err != nil {
+			return err
+		}
+		for _, version := range report.Versions {
+			if _, err := fmt.Fprintf(out, "  %-24s %5d calls  %5.1f%% failed  %s\n", version.Version, version.Calls, 100*version.FailureRate, formatUsageLatency(version.Latency)); err != nil {
+				return err
+			}
+		}
+	}
+	if len(report.Weekly) > 0 {
+		if _, err := fmt.Fprintln(out, "Weekly (UTC, from Monday):"); err != nil {
+			return err
+		}
+		for _, week := range report.Weekly {
+			if _, err := fmt.Fprintf(out, "  %-24s %5d calls  %5.1f%% failed  %s\n", week.WeekStart, week.Calls, 100*week.FailureRate, formatUsageLatency(week.Latency)); err != nil {
+				return err
+			}
+		}
 	}
 	if len(report.ZeroUseCommands) > 0 {
 		if _, err := fmt.Fprintf(out, "Zero observed use: %s\n", strings.Join(report.ZeroUseCommands, ", ")); err != nil {
@@ -39,6 +72,11 @@ func renderUsageStatus(cmd *cobra.Command, status usage.StorageStatus) error {
 	if _, err := fmt.Fprintf(out, "Storage: %s (%d shards, %d/%d bytes, %d-day retention)\n", status.Directory, status.ShardCount, status.TotalBytes, status.MaxTotalBytes, status.RetentionDays); err != nil {
 		return err
 	}
+	if status.SpooledEvents > 0 {
+		if _, err := fmt.Fprintf(out, "Spooled: %d events awaiting compaction into shards\n", status.SpooledEvents); err != nil {
+			return err
+		}
+	}
 	for _, diagnostic := range status.Diagnostics {
 		if _, err := fmt.Fprintf(out, "  %s: %s\n", diagnostic.Level, diagnostic.Message); err != nil {
 			return err
@@ -46,3 +84,15 @@ func renderUsageStatus(cmd *cobra.Command, status usage.StorageStatus) error {
 	}
 	return nil
 }
+
+func formatUsageLatency(latency usage.LatencySummary) string {
+	return fmt.Sprintf("p50 %dms  p90 %dms  p99 %dms", latency.P50MS, latency.P90MS, latency.P99MS)
+}
+
+func formatUsageFailureClasses(classes []usage.FailureClassSummary) string {
+	parts := make([]string, 0, len(classes))
+	for _, class := range classes {
+		parts = append(parts, fmt.Sprintf("%s %d", class.Class, class.Failures))
+	}
+	return strings.Join(parts, ", ")
+}
@@ -2,12 +2,19 @@ package cli
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
 	"testing"
 	"time"
 
 	"github.com/spf13/cobra"
 
+	"github.com/jamesonstone/kit/v3/internal/config"
 	"github.com/jamesonstone/kit/v3/internal/usage"
 )
 
@@ -50,3 +57,149 @@ func TestParseUsageDurationAcceptsBoundedDayWindow(t *testing.T) {
 		}
 	}
 }
+
+func TestUsageExportFeedsOfflineReportInputs(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	if err := usage.Record(usage.RecordInput{Command: "status", Version: "v3.0.0", Elapsed: time.Millisecond}); err != nil {
+		t.Fatal(err)
+	}
+	out := filepath.Join(t.TempDir(), "usage.jsonl")
+	cmd := &cobra.Command{}
+	cmd.SetOut(&bytes.Buffer{})
+	if err := runUsageExport(cmd, &usageExportOptions{out: out, coarsen: usage.CoarsenHour}); err != nil {
+		t.Fatalf("runUsageExport() error = %v", err)
+	}
+	dir, _ := usage.Directory()
+	if err := runUsageExport(cmd, &usageExportOptions{out: filepath.Join(dir, "export.jsonl"), coarsen: usage.CoarsenNone}); err == nil || !strings.Contains(err.Error(), "outside the local usage store") {
+		t.Fatalf("export into the usage store error = %v", err)
+	}
+
+	var output bytes.Buffer
+	cmd.SetOut(&output)
+	if err := runUsageReport(cmd, &usageReportOptions{since: "90d", inputs: []string{out, out}, jsonOutput: true}); err != nil {
+		t.Fatalf("runUsageReport(--input) error = %v", err)
+	}
+	var report usage.Report
+	if err := json.Unmarshal(output.Bytes(), &report); err != nil {
+		t.Fatalf("invalid usage JSON: %v\n%s", err, output.String())
+	}
+	if report.Inputs != 2 || report.TotalCalls != 2 {
+		t.Fatalf("merged report = %#v", report)
+	}
+	err := runUsageReport(cmd, &usageReportOptions{since: "90d", inputs: []string{out}, project: "current"})
+	if err == nil || !strings.Contains(err.Error(), "--project cannot be combined with --input") {
+		t.Fatalf("runUsageReport(--input --project) error = %v", err)
+	}
+}
+
+func TestFailedUsageExportLeavesExistingOutputUntouched(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	dir, _ := usage.Directory()
+	if err := os.MkdirAll(dir, 0o700); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, time.Now().UTC().Format("2006-01")+"-0001.jsonl"), []byte("{torn\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	outDir := t.TempDir()
+	out := filepath.Join(outDir, "usage.jsonl")
+	if err := os.WriteFile(out, []byte("previous export\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	cmd := &cobra.Command{}
+	cmd.SetOut(&bytes.Buffer{})
+	if err := runUsageExport(cmd, &usageExportOptions{out: out, coarsen: usage.CoarsenNone}); err == nil {
+		t.Fatal("export over a torn shard succeeded")
+	}
+	if content, err := os.ReadFile(out); err != nil || string(content) != "previous export\n" {
+		t.Fatalf("existing output after failed export = %q, %v", content, err)
+	}
+	if entries, _ := os.ReadDir(outDir); len(entries) != 1 {
+		t.Fatalf("failed export left temporary files: %v", entries)
+	}
+}
+
+func TestUsageAdviseJoinsRecordedUsageWithCatalog(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	for range 5 {
+		if err := usage.Record(usage.RecordInput{Command: "status", Version: "v3.0.0", ExitCode: 1, Flags: []string{"json"}}); err != nil {
+			t.Fatal(err)
+		}
+	}
+	var output bytes.Buffer
+	cmd := &cobra.Command{}
+	cmd.SetOut(&output)
+	if err := runUsageAdvise(cmd, &usageAdviseOptions{since: "90d", minCalls: 5, failureRate: 0.5, jsonOutput: true}); err != nil {
+		t.Fatalf("runUsageAdvise() error = %v", err)
+	}
+	var advice usage.AdviceReport
+	if err := json.Unmarshal(output.Bytes(), &advice); err != nil {
+		t.Fatalf("invalid advice JSON: %v\n%s", err, output.String())
+	}
+	found := map[string]bool{}
+	for _, item := range advice.Advice {
+		if strings.HasPrefix(item.Command, "usage") {
+			t.Fatalf("usage commands are never recorded but were advised on: %+v", item)
+		}
+		found[item.Kind+" "+item.Command+" "+item.Flag] = true
+	}
+	if !found["failing status "] || !found["failing status json"] || !found["unused health "] {
+		t.Fatalf("advice = %+v", advice.Advice)
+	}
+	if err := runUsageAdvise(cmd, &usageAdviseOptions{since: "90d", minCalls: 5, failureRate: 1.5}); err == nil || !strings.Contains(err.Error(), "--failure-rate") {
+		t.Fatalf("runUsageAdvise(--failure-rate 1.5) error = %v", err)
+	}
+}
+
+func TestUsageErrorClassPrefersTaggedClassAndKnownCauses(t *testing.T) {
+	tagged := withErrorClass(usage.ErrorClassEvidenceBlocked, newCLIExitError(errors.New("blocked"), 2, true))
+	var exitErr *cliExitError
+	if !errors.As(tagged, &exitErr) || exitErr.code != 2 {
+		t.Fatalf("classified error hides the exit code: %v", tagged)
+	}
+	cases := map[error]usage.ErrorClass{
+		nil:                                    "",
+		fmt.Errorf("resolve: %w", tagged):      usage.ErrorClassEvidenceBlocked,
+		context.DeadlineExceeded:               usage.ErrorClassTimeout,
+		fmt.Errorf("read: %w", os.ErrNotExist): usage.ErrorClassNotFound,
+		errors.New("something else"):           usage.ErrorClassUnclassified,
+		fmt.Errorf("spec: %w", config.ErrProjectNotFound):                                usage.ErrorClassNoProject,
+		withErrorClass(usage.ErrorClassInvalidInput, context.Canceled):                   usage.ErrorClassInterrupted,
+		fmt.Errorf("refresh: %w", &initRefreshRegistryError{err: errors.New("offline")}): usage.ErrorClassNetwork,
+	}
+	for err, want := range cases {
+		if got := usageErrorClass(err); got != want {
+			t.Fatalf("usageErrorClass(%v) = %q; want %q", err, got, want)
+		}
+	}
+	if got := usageErrorClass(rootCmd.FlagErrorFunc()(rootCmd, errors.New("unknown flag: --nope"))); got != usage.ErrorClassInvalidInput {
+		t.Fatalf("flag parse error class = %q", got)
+	}
+	parent := &cobra.Command{Use: "kit"}
+	child := &cobra.Command{Use: "child", Args: cobra.NoArgs}
+	parent.AddCommand(child)
+	classifyArgErrors(parent)
+	if got := usageErrorClass(child.Args(child, []string{"extra"})); got != usage.ErrorClassInvalidInput {
+		t.Fatalf("argument error class = %q", got)
+	}
+}
+
+func TestCommandReturnPathsCarryErrorClasses(t *testing.T) {
+	t.Chdir(t.TempDir())
+	cmd := &cobra.Command{}
+	cmd.SetOut(&bytes.Buffer{})
+	cases := map[string]struct {
+		err  error
+		want usage.ErrorClass
+	}{
+		"spec without a feature":              {runNativePlanSpec(cmd, nil), usage.ErrorClassInvalidInput},
+		"spec outside a project":              {runNativePlanSpec(cmd, []string{"demo"}), usage.ErrorClassNoProject},
+		"status outside a project":            {runStatus(cmd, nil), usage.ErrorClassNoProject},
+		"capabilities for an unknown command": {runCapabilities(cmd, []string{"no-such-command"}, capabilitiesOptions{}), usage.ErrorClassNotFound},
+	}
+	for name, tc := range cases {
+		if got := usageErrorClass(tc.err); got != tc.want {
+			t.Errorf("%s: class = %q for %v; want %q", name, got, tc.err, tc.want)
+		}
+	}
+}